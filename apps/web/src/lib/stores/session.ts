@@ -4,6 +4,7 @@ import { api, ApiError } from "$lib/api/client";
 import { getToken as getPlayerToken } from "$lib/auth/playerAuth";
 
 const STORAGE_KEY = "kidsplanet_play_token";
+const GUEST_ID_KEY = "kidsplanet_guest_id";
 
 export type StartSessionResponse = {
     play_token: string;
@@ -16,6 +17,22 @@ type SessionState = {
     loading: boolean;
 };
 
+// getGuestId returns this browser's guest id, creating it on first use. The
+// API keeps it on guest sessions to relate games played by the same guest.
+function getGuestId(): string | undefined {
+    if (!browser) return undefined;
+    try {
+        let id = localStorage.getItem(GUEST_ID_KEY);
+        if (!id) {
+            id = crypto.randomUUID();
+            localStorage.setItem(GUEST_ID_KEY, id);
+        }
+        return id;
+    } catch {
+        return undefined;
+    }
+}
+
 function createSessionStore() {
     const { subscribe, set, update } = writable<SessionState>({
         playToken: null,
@@ -87,17 +104,19 @@ function createSessionStore() {
         update((s) => ({ ...s, loading: true }));
         try {
             const playerToken = getPlayerToken();
+            const guestId = getGuestId();
             let data: StartSessionResponse;
             try {
                 data = await api.post<StartSessionResponse>(
                     "/sessions/start",
-                    { game_id: gid },
+                    { game_id: gid, guest_id: guestId },
                     { token: playerToken ?? undefined },
                 );
             } catch (e) {
                 if (playerToken && e instanceof ApiError && e.status === 401) {
                     data = await api.post<StartSessionResponse>("/sessions/start", {
                         game_id: gid,
+                        guest_id: guestId,
                     });
                 } else {
                     throw e;
@@ -1,3 +1,6 @@
+-- EXTENSIONS
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
 -- ENUM TYPES
 DO $$
     BEGIN
@@ -60,24 +63,6 @@ CREATE TRIGGER trg_users_set_updated_at
     FOR EACH ROW
 EXECUTE FUNCTION set_updated_at();
 
--- PLAYERS
-CREATE TABLE IF NOT EXISTS players
-(
-    id         BIGSERIAL PRIMARY KEY,
-    user_id    BIGINT UNIQUE, -- allow NULL if you really want orphan players
-    nickname   VARCHAR(100) NOT NULL,
-    age        INT,
-    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
-
-    CONSTRAINT fk_players_user
-        FOREIGN KEY (user_id)
-            REFERENCES users (id)
-            ON DELETE SET NULL,
-
-    CONSTRAINT ck_players_age_non_negative
-        CHECK (age IS NULL OR age >= 0)
-);
-
 -- EDUCATION CATEGORIES
 CREATE TABLE IF NOT EXISTS education_categories
 (
@@ -104,6 +89,111 @@ CREATE TABLE IF NOT EXISTS age_categories
 CREATE INDEX IF NOT EXISTS idx_age_categories_range
     ON age_categories (min_age, max_age);
 
+-- PLAYERS
+-- Child profiles; a parent account (users.role = 'player') owns several.
+-- The age band reuses age_categories.
+CREATE TABLE IF NOT EXISTS players
+(
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT       NOT NULL,
+    nickname        VARCHAR(100) NOT NULL,
+    birth_year      INT,
+    age_category_id BIGINT,
+    avatar          VARCHAR(32)  NOT NULL,
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_players_user
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_players_age_category
+        FOREIGN KEY (age_category_id)
+            REFERENCES age_categories (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT ck_players_birth_year
+        CHECK (birth_year IS NULL OR birth_year >= 1900)
+);
+
+-- Upgrade from the first baseline, where a user had at most one player with a
+-- plain age and players outlived their user. Each step is a no-op on a fresh
+-- database and on re-runs.
+ALTER TABLE players
+    DROP CONSTRAINT IF EXISTS players_user_id_key,
+    ADD COLUMN IF NOT EXISTS birth_year      INT,
+    ADD COLUMN IF NOT EXISTS age_category_id BIGINT,
+    ADD COLUMN IF NOT EXISTS avatar          VARCHAR(32),
+    ADD COLUMN IF NOT EXISTS updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW();
+
+DO $$
+    BEGIN
+        IF EXISTS (SELECT 1
+                   FROM information_schema.columns
+                   WHERE table_schema = current_schema()
+                     AND table_name = 'players'
+                     AND column_name = 'age') THEN
+            UPDATE players
+            SET birth_year = EXTRACT(YEAR FROM NOW())::INT - age
+            WHERE birth_year IS NULL
+              AND age BETWEEN 0 AND 100;
+
+            ALTER TABLE players DROP COLUMN age;
+        END IF;
+
+        -- Players whose user was deleted; the new FK cascades instead.
+        DELETE FROM players WHERE user_id IS NULL;
+
+        UPDATE players SET avatar = 'bear' WHERE avatar IS NULL;
+
+        ALTER TABLE players
+            ALTER COLUMN user_id SET NOT NULL,
+            ALTER COLUMN avatar SET NOT NULL;
+
+        IF EXISTS (SELECT 1
+                   FROM pg_constraint
+                   WHERE conrelid = 'players'::regclass
+                     AND conname = 'fk_players_user'
+                     AND confdeltype <> 'c') THEN
+            ALTER TABLE players DROP CONSTRAINT fk_players_user;
+        END IF;
+
+        IF NOT EXISTS (SELECT 1
+                       FROM pg_constraint
+                       WHERE conrelid = 'players'::regclass
+                         AND conname = 'fk_players_user') THEN
+            ALTER TABLE players
+                ADD CONSTRAINT fk_players_user
+                    FOREIGN KEY (user_id)
+                        REFERENCES users (id)
+                        ON DELETE CASCADE;
+        END IF;
+
+        IF NOT EXISTS (SELECT 1
+                       FROM pg_constraint
+                       WHERE conrelid = 'players'::regclass
+                         AND conname = 'fk_players_age_category') THEN
+            ALTER TABLE players
+                ADD CONSTRAINT fk_players_age_category
+                    FOREIGN KEY (age_category_id)
+                        REFERENCES age_categories (id)
+                        ON DELETE SET NULL;
+        END IF;
+
+        IF NOT EXISTS (SELECT 1
+                       FROM pg_constraint
+                       WHERE conrelid = 'players'::regclass
+                         AND conname = 'ck_players_birth_year') THEN
+            ALTER TABLE players
+                ADD CONSTRAINT ck_players_birth_year
+                    CHECK (birth_year IS NULL OR birth_year >= 1900);
+        END IF;
+    END$$;
+
+CREATE INDEX IF NOT EXISTS idx_players_user_id
+    ON players (user_id, id);
+
 -- GAMES
 CREATE TABLE IF NOT EXISTS games
 (
@@ -117,9 +207,16 @@ CREATE TABLE IF NOT EXISTS games
     age_category_id BIGINT       NOT NULL,
     free            BOOLEAN      NOT NULL DEFAULT TRUE,
     status          game_status  NOT NULL DEFAULT 'draft',
+    publish_at      TIMESTAMPTZ,
+    unpublish_at    TIMESTAMPTZ,
+    deleted_at      TIMESTAMPTZ,
     created_by      BIGINT       NOT NULL,
     created_at      TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
     updated_at      TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    search_vector   TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', title), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(description, '')), 'C')
+        ) STORED,
 
     CONSTRAINT fk_games_age_category
         FOREIGN KEY (age_category_id)
@@ -129,9 +226,39 @@ CREATE TABLE IF NOT EXISTS games
     CONSTRAINT fk_games_created_by
         FOREIGN KEY (created_by)
             REFERENCES users (id)
-            ON DELETE RESTRICT
+            ON DELETE RESTRICT,
+
+    CONSTRAINT ck_games_schedule_window
+        CHECK (publish_at IS NULL OR unpublish_at IS NULL OR unpublish_at > publish_at)
 );
 
+-- Columns added since the first baseline, for databases created from it.
+-- Each statement is a no-op on a fresh database and on re-runs.
+ALTER TABLE games
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('simple', title), 'A') ||
+        setweight(to_tsvector('simple', COALESCE(description, '')), 'C')
+        ) STORED;
+
+ALTER TABLE games
+    ADD COLUMN IF NOT EXISTS publish_at   TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS unpublish_at TIMESTAMPTZ;
+
+DO $$
+    BEGIN
+        IF NOT EXISTS (SELECT 1
+                       FROM pg_constraint
+                       WHERE conrelid = 'games'::regclass
+                         AND conname = 'ck_games_schedule_window') THEN
+            ALTER TABLE games
+                ADD CONSTRAINT ck_games_schedule_window
+                    CHECK (publish_at IS NULL OR unpublish_at IS NULL OR unpublish_at > publish_at);
+        END IF;
+    END$$;
+
+ALTER TABLE games
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
 CREATE INDEX IF NOT EXISTS idx_games_status
     ON games (status);
 
@@ -148,6 +275,24 @@ CREATE INDEX IF NOT EXISTS idx_games_active
     ON games (status)
     WHERE status = 'active';
 
+CREATE INDEX IF NOT EXISTS idx_games_publish_at
+    ON games (publish_at)
+    WHERE publish_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_games_unpublish_at
+    ON games (unpublish_at)
+    WHERE unpublish_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_games_deleted_at
+    ON games (deleted_at)
+    WHERE deleted_at IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_games_search_vector
+    ON games USING GIN (search_vector);
+
+CREATE INDEX IF NOT EXISTS idx_games_title_trgm
+    ON games USING GIN (title gin_trgm_ops);
+
 DROP TRIGGER IF EXISTS trg_games_set_updated_at ON games;
 CREATE TRIGGER trg_games_set_updated_at
     BEFORE UPDATE ON games
@@ -187,22 +332,61 @@ CREATE TABLE IF NOT EXISTS sessions
     game_id            BIGINT       NOT NULL,
     client_session_id  TEXT,        -- optional: if your frontend generates a string/uuid session id
     started_at         TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    preview            BOOLEAN      NOT NULL DEFAULT FALSE, -- started with an admin preview token
+    profile_id         BIGINT,      -- active child profile, if any
+    expires_at         TIMESTAMPTZ, -- play token expiry; the latest the session can run
+    ended_at           TIMESTAMPTZ, -- first game_end event, when the game reports one
+    guest_id           TEXT,        -- portal's guest id for sessions without a player
 
     CONSTRAINT fk_sessions_game
         FOREIGN KEY (game_id)
             REFERENCES games (id)
-            ON DELETE CASCADE
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_sessions_profile
+        FOREIGN KEY (profile_id)
+            REFERENCES players (id)
+            ON DELETE SET NULL
 );
 
+-- Columns added since the first baseline, for databases created from it.
+ALTER TABLE sessions
+    ADD COLUMN IF NOT EXISTS preview    BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS profile_id BIGINT,
+    ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS ended_at   TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS guest_id   TEXT;
+
+DO $$
+    BEGIN
+        IF NOT EXISTS (SELECT 1
+                       FROM pg_constraint
+                       WHERE conrelid = 'sessions'::regclass
+                         AND conname = 'fk_sessions_profile') THEN
+            ALTER TABLE sessions
+                ADD CONSTRAINT fk_sessions_profile
+                    FOREIGN KEY (profile_id)
+                        REFERENCES players (id)
+                        ON DELETE SET NULL;
+        END IF;
+    END$$;
+
 CREATE INDEX IF NOT EXISTS idx_sessions_started_at
     ON sessions (started_at DESC);
 
 CREATE INDEX IF NOT EXISTS idx_sessions_game_id_started_at
     ON sessions (game_id, started_at DESC);
 
+CREATE INDEX IF NOT EXISTS idx_sessions_profile_id_started_at
+    ON sessions (profile_id, started_at DESC);
+
 CREATE INDEX IF NOT EXISTS idx_sessions_client_session_id
     ON sessions (client_session_id);
 
+CREATE INDEX IF NOT EXISTS idx_sessions_guest_id
+    ON sessions (guest_id)
+    WHERE guest_id IS NOT NULL;
+
 -- LEADERBOARD SUBMISSIONS
 CREATE TABLE IF NOT EXISTS leaderboard_submissions
 (
@@ -259,6 +443,7 @@ CREATE TABLE IF NOT EXISTS analytics_events
     event_data JSONB,
     ip         TEXT,
     user_agent TEXT,
+    preview    BOOLEAN     NOT NULL DEFAULT FALSE,
 
     CONSTRAINT fk_analytics_events_game
         FOREIGN KEY (game_id)
@@ -271,6 +456,10 @@ CREATE TABLE IF NOT EXISTS analytics_events
             ON DELETE SET NULL
 );
 
+-- Added since the first baseline, for databases created from it.
+ALTER TABLE analytics_events
+    ADD COLUMN IF NOT EXISTS preview BOOLEAN NOT NULL DEFAULT FALSE;
+
 CREATE INDEX IF NOT EXISTS idx_analytics_events_session_id
     ON analytics_events (session_id);
 
@@ -282,3 +471,443 @@ CREATE INDEX IF NOT EXISTS idx_analytics_events_created_at
 
 CREATE INDEX IF NOT EXISTS idx_analytics_events_event_name_created_at_game_id
     ON analytics_events (event_name, created_at DESC, game_id);
+
+-- GAME BUILDS
+CREATE TABLE IF NOT EXISTS game_builds
+(
+    id                BIGSERIAL PRIMARY KEY,
+    game_id           BIGINT      NOT NULL,
+    upload_object_key TEXT        NOT NULL,
+    file_count        INT         NOT NULL DEFAULT 0,
+    total_bytes       BIGINT      NOT NULL DEFAULT 0,
+    is_current        BOOLEAN     NOT NULL DEFAULT FALSE,
+    cache_version     INT         NOT NULL DEFAULT 0,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    promoted_at       TIMESTAMPTZ,
+
+    CONSTRAINT fk_game_builds_game
+        FOREIGN KEY (game_id)
+            REFERENCES games (id)
+            ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_game_builds_current
+    ON game_builds (game_id)
+    WHERE is_current;
+
+CREATE INDEX IF NOT EXISTS idx_game_builds_game_id_created_at
+    ON game_builds (game_id, created_at DESC);
+
+-- GAME BUILD FILES
+CREATE TABLE IF NOT EXISTS game_build_files
+(
+    id           BIGSERIAL PRIMARY KEY,
+    build_id     BIGINT       NOT NULL,
+    path         TEXT         NOT NULL,
+    size_bytes   BIGINT       NOT NULL,
+    content_type VARCHAR(255),
+    sha256       CHAR(64),
+
+    CONSTRAINT uq_game_build_files_path UNIQUE (build_id, path),
+
+    CONSTRAINT fk_game_build_files_build
+        FOREIGN KEY (build_id)
+            REFERENCES game_builds (id)
+            ON DELETE CASCADE
+);
+
+-- GAME MEDIA
+CREATE TABLE IF NOT EXISTS game_media
+(
+    id            BIGSERIAL PRIMARY KEY,
+    game_id       BIGINT      NOT NULL,
+    kind          VARCHAR(20) NOT NULL,
+    position      INT         NOT NULL DEFAULT 0,
+    object_prefix TEXT        NOT NULL,
+    width         INT         NOT NULL,
+    height        INT         NOT NULL,
+    variants      JSONB       NOT NULL DEFAULT '{}'::jsonb,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT ck_game_media_kind
+        CHECK (kind IN ('thumbnail', 'screenshot')),
+
+    CONSTRAINT fk_game_media_game
+        FOREIGN KEY (game_id)
+            REFERENCES games (id)
+            ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_game_media_thumbnail
+    ON game_media (game_id)
+    WHERE kind = 'thumbnail';
+
+CREATE INDEX IF NOT EXISTS idx_game_media_game_id_kind
+    ON game_media (game_id, kind, position);
+
+-- GAME SLUG HISTORY
+-- Previous slugs of renamed games; public lookups 301 to the current slug.
+CREATE TABLE IF NOT EXISTS game_slug_history
+(
+    id         BIGSERIAL PRIMARY KEY,
+    game_id    BIGINT       NOT NULL,
+    slug       VARCHAR(150) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_game_slug_history_game
+        FOREIGN KEY (game_id)
+            REFERENCES games (id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_slug_history_game_id
+    ON game_slug_history (game_id);
+
+-- GAME PLAY DAILY
+-- Per-game, per-day (UTC) game_start counts. Incremented on ingestion and
+-- periodically reconciled from analytics_events.
+CREATE TABLE IF NOT EXISTS game_play_daily
+(
+    game_id BIGINT NOT NULL,
+    day     DATE   NOT NULL,
+    plays   BIGINT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (game_id, day),
+
+    CONSTRAINT fk_game_play_daily_game
+        FOREIGN KEY (game_id)
+            REFERENCES games (id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_play_daily_day
+    ON game_play_daily (day);
+
+-- GAME TRANSLATIONS
+-- Per-locale title/description. The base games columns hold the default
+-- locale (DEFAULT_LOCALE); public reads fall back to them.
+CREATE TABLE IF NOT EXISTS game_translations
+(
+    game_id     BIGINT       NOT NULL,
+    locale      VARCHAR(3)   NOT NULL,
+    title       VARCHAR(150) NOT NULL,
+    description TEXT,
+    updated_at  TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (game_id, locale),
+
+    CONSTRAINT fk_game_translations_game
+        FOREIGN KEY (game_id)
+            REFERENCES games (id)
+            ON DELETE CASCADE
+);
+
+-- EDUCATION CATEGORY TRANSLATIONS
+CREATE TABLE IF NOT EXISTS education_category_translations
+(
+    education_category_id BIGINT       NOT NULL,
+    locale                VARCHAR(3)   NOT NULL,
+    name                  VARCHAR(100) NOT NULL,
+    updated_at            TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (education_category_id, locale),
+
+    CONSTRAINT fk_education_category_translations_category
+        FOREIGN KEY (education_category_id)
+            REFERENCES education_categories (id)
+            ON DELETE CASCADE
+);
+
+-- AGE CATEGORY TRANSLATIONS
+CREATE TABLE IF NOT EXISTS age_category_translations
+(
+    age_category_id BIGINT      NOT NULL,
+    locale          VARCHAR(3)  NOT NULL,
+    label           VARCHAR(50) NOT NULL,
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (age_category_id, locale),
+
+    CONSTRAINT fk_age_category_translations_category
+        FOREIGN KEY (age_category_id)
+            REFERENCES age_categories (id)
+            ON DELETE CASCADE
+);
+
+-- GAME RELATED
+-- Precomputed "more like this" rows, rebuilt by the related-games job from
+-- shared education categories, age-range overlap and co-plays.
+CREATE TABLE IF NOT EXISTS game_related
+(
+    game_id           BIGINT           NOT NULL,
+    related_game_id   BIGINT           NOT NULL,
+    score             DOUBLE PRECISION NOT NULL,
+    shared_categories INT              NOT NULL DEFAULT 0,
+    age_overlap       DOUBLE PRECISION NOT NULL DEFAULT 0,
+    co_plays          INT              NOT NULL DEFAULT 0,
+    computed_at       TIMESTAMPTZ      NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (game_id, related_game_id),
+
+    CONSTRAINT fk_game_related_game
+        FOREIGN KEY (game_id)
+            REFERENCES games (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_game_related_related_game
+        FOREIGN KEY (related_game_id)
+            REFERENCES games (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT ck_game_related_not_self
+        CHECK (game_id <> related_game_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_related_game_score
+    ON game_related (game_id, score DESC);
+
+CREATE INDEX IF NOT EXISTS idx_game_related_related_game_id
+    ON game_related (related_game_id);
+
+-- COLLECTIONS
+-- Hand-curated shelves. Public reads show a collection while it is active
+-- and NOW() falls inside the optional [starts_at, ends_at) window.
+CREATE TABLE IF NOT EXISTS collections
+(
+    id             BIGSERIAL PRIMARY KEY,
+    slug           VARCHAR(150) NOT NULL UNIQUE,
+    title          VARCHAR(150) NOT NULL,
+    description    TEXT,
+    hero_image_url VARCHAR(2048),
+    position       INT          NOT NULL DEFAULT 0,
+    active         BOOLEAN      NOT NULL DEFAULT TRUE,
+    starts_at      TIMESTAMPTZ,
+    ends_at        TIMESTAMPTZ,
+    created_by     BIGINT,
+    created_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at     TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_collections_created_by
+        FOREIGN KEY (created_by)
+            REFERENCES users (id)
+            ON DELETE SET NULL,
+
+    CONSTRAINT ck_collections_window
+        CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_collections_position
+    ON collections (position, id);
+
+-- COLLECTION GAMES
+CREATE TABLE IF NOT EXISTS collection_games
+(
+    collection_id BIGINT NOT NULL,
+    game_id       BIGINT NOT NULL,
+    position      INT    NOT NULL,
+
+    PRIMARY KEY (collection_id, game_id),
+
+    CONSTRAINT fk_collection_games_collection
+        FOREIGN KEY (collection_id)
+            REFERENCES collections (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_collection_games_game
+        FOREIGN KEY (game_id)
+            REFERENCES games (id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_collection_games_collection_position
+    ON collection_games (collection_id, position);
+
+CREATE INDEX IF NOT EXISTS idx_collection_games_game_id
+    ON collection_games (game_id);
+
+-- PLAYER FAVORITES
+-- Keyed by the player's public id, the same id carried in player JWTs.
+CREATE TABLE IF NOT EXISTS player_favorites
+(
+    player_id  UUID        NOT NULL,
+    game_id    BIGINT      NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (player_id, game_id),
+
+    CONSTRAINT fk_player_favorites_player
+        FOREIGN KEY (player_id)
+            REFERENCES users (public_id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_player_favorites_game
+        FOREIGN KEY (game_id)
+            REFERENCES games (id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_player_favorites_player_created_at
+    ON player_favorites (player_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_player_favorites_game_id
+    ON player_favorites (game_id);
+
+-- GAME REACTIONS
+-- One reaction per member per game. member is the signed-in player's
+-- leaderboard member, p:<player_id>[:<profile_id>]; guests cannot react.
+CREATE TABLE IF NOT EXISTS game_reactions
+(
+    game_id    BIGINT      NOT NULL,
+    member     TEXT        NOT NULL,
+    reaction   TEXT        NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (game_id, member),
+
+    CONSTRAINT fk_game_reactions_game
+        FOREIGN KEY (game_id)
+            REFERENCES games (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT ck_game_reactions_reaction
+        CHECK (reaction IN ('love', 'like', 'meh'))
+);
+
+-- GAME REACTION COUNTS
+-- Per-game aggregates kept in step with game_reactions. rating is a smoothed
+-- average (love 1, like 0.75, meh 0.25) with five neutral votes of prior, so
+-- a game with a single reaction does not outrank well-loved ones.
+CREATE TABLE IF NOT EXISTS game_reaction_counts
+(
+    game_id    BIGINT PRIMARY KEY,
+    love_count INT         NOT NULL DEFAULT 0,
+    like_count INT         NOT NULL DEFAULT 0,
+    meh_count  INT         NOT NULL DEFAULT 0,
+    rating     DOUBLE PRECISION GENERATED ALWAYS AS (
+        (love_count + 0.75 * like_count + 0.25 * meh_count + 2.5)
+            / (love_count + like_count + meh_count + 5)
+        ) STORED,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_game_reaction_counts_game
+        FOREIGN KEY (game_id)
+            REFERENCES games (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT ck_game_reaction_counts_non_negative
+        CHECK (love_count >= 0 AND like_count >= 0 AND meh_count >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_game_reaction_counts_rating
+    ON game_reaction_counts (rating DESC, game_id);
+
+-- PROFILE CONTROLS
+-- Parental controls per child profile. Quiet hours are minutes after local
+-- midnight in time_zone and may wrap past midnight. An empty allow list means
+-- no restriction, so the allow-list category FKs are left RESTRICT on
+-- purpose: deleting an age or education category that an allow list still
+-- references returns 400 "in use" instead of silently widening what the
+-- child may play.
+CREATE TABLE IF NOT EXISTS profile_controls
+(
+    profile_id    BIGINT PRIMARY KEY,
+    daily_minutes INT,
+    quiet_start   INT,
+    quiet_end     INT,
+    time_zone     VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_profile_controls_profile
+        FOREIGN KEY (profile_id)
+            REFERENCES players (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT ck_profile_controls_daily_minutes
+        CHECK (daily_minutes IS NULL OR daily_minutes BETWEEN 0 AND 1440),
+
+    CONSTRAINT ck_profile_controls_quiet_hours
+        CHECK (
+            (quiet_start IS NULL AND quiet_end IS NULL)
+            OR (quiet_start BETWEEN 0 AND 1439 AND quiet_end BETWEEN 0 AND 1439 AND quiet_start <> quiet_end)
+        )
+);
+
+CREATE TABLE IF NOT EXISTS profile_allowed_age_categories
+(
+    profile_id      BIGINT NOT NULL,
+    age_category_id BIGINT NOT NULL,
+
+    PRIMARY KEY (profile_id, age_category_id),
+
+    CONSTRAINT fk_paac_profile
+        FOREIGN KEY (profile_id)
+            REFERENCES players (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_paac_age_category
+        FOREIGN KEY (age_category_id)
+            REFERENCES age_categories (id)
+);
+
+CREATE TABLE IF NOT EXISTS profile_allowed_education_categories
+(
+    profile_id            BIGINT NOT NULL,
+    education_category_id BIGINT NOT NULL,
+
+    PRIMARY KEY (profile_id, education_category_id),
+
+    CONSTRAINT fk_paec_profile
+        FOREIGN KEY (profile_id)
+            REFERENCES players (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_paec_education_category
+        FOREIGN KEY (education_category_id)
+            REFERENCES education_categories (id)
+);
+
+CREATE TABLE IF NOT EXISTS profile_blocked_games
+(
+    profile_id BIGINT NOT NULL,
+    game_id    BIGINT NOT NULL,
+
+    PRIMARY KEY (profile_id, game_id),
+
+    CONSTRAINT fk_profile_blocked_games_profile
+        FOREIGN KEY (profile_id)
+            REFERENCES players (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_profile_blocked_games_game
+        FOREIGN KEY (game_id)
+            REFERENCES games (id)
+            ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_profile_blocked_games_game_id
+    ON profile_blocked_games (game_id);
+
+-- WEEKLY REPORT DELIVERIES
+-- One row per emailed weekly report; profile_id NULL is the whole account.
+-- The row is claimed before sending so replicas never send twice.
+CREATE TABLE IF NOT EXISTS weekly_report_deliveries
+(
+    user_id    BIGINT      NOT NULL,
+    profile_id BIGINT,
+    week_start DATE        NOT NULL,
+    sent_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT fk_weekly_report_deliveries_user
+        FOREIGN KEY (user_id)
+            REFERENCES users (id)
+            ON DELETE CASCADE,
+
+    CONSTRAINT fk_weekly_report_deliveries_profile
+        FOREIGN KEY (profile_id)
+            REFERENCES players (id)
+            ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_weekly_report_deliveries_target
+    ON weekly_report_deliveries (user_id, week_start, COALESCE(profile_id, 0));
@@ -7,13 +7,19 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // profile time zones; the runtime image has no zoneinfo
 
 	"github.com/gofiber/fiber/v2"
 
 	"github.com/ZygmaCore/kids_planet/services/api/internal/clients"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/handlers"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/jobs"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/mail"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/middleware"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/storage"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
 )
 
 func main() {
@@ -36,11 +42,17 @@ func main() {
 	defer func() { _ = vk.Close() }()
 	log.Println("valkey connected")
 
-	mo, err := clients.NewMinIO(ctx, cfg.MinIO)
+	store, err := storage.New(ctx, cfg)
 	if err != nil {
-		log.Fatalf("startup failed (minio): %v", err)
+		log.Fatalf("startup failed (storage): %v", err)
 	}
-	log.Println("minio connected")
+	log.Printf("storage ready (backend=%s)", store.Backend())
+
+	mailer, err := mail.New(cfg.Mail)
+	if err != nil {
+		log.Fatalf("startup failed (mail): %v", err)
+	}
+	log.Printf("mail ready (sink=%s)", mailer.Sink())
 
 	app := fiber.New(fiber.Config{
 		AppName:      "game-portal-api",
@@ -55,11 +67,97 @@ func main() {
 	app.Use(middleware.CORS())
 	app.Use(middleware.SizeLimit())
 
-	handlers.Register(app, handlers.Deps{
+	jobSvcs := handlers.Register(app, handlers.Deps{
 		Cfg:    cfg,
 		DB:     db,
 		Valkey: vk,
-		MinIO:  mo,
+		Store:  store,
+		Mailer: mailer,
+	})
+
+	go jobs.RunEvery(ctx, "storage_gc", cfg.StorageGC.Interval, func(ctx context.Context) error {
+		report, err := jobSvcs.StorageGC.Run(ctx, services.StorageGCInput{})
+		if appErr, ok := err.(utils.AppError); ok && appErr.Code == utils.CodeConflict {
+			log.Printf("level=info job=storage_gc msg=%q", "lock held by another run")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		log.Printf("level=info job=storage_gc deleted=%d deleted_bytes=%d", report.DeletedCount, report.DeletedBytes)
+		return nil
+	})
+
+	reconcilePopularity := func(ctx context.Context) error {
+		rows, err := jobSvcs.Popularity.Reconcile(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("level=info job=popularity_reconcile rows=%d", rows)
+		return nil
+	}
+	if cfg.Popularity.ReconcileInterval > 0 {
+		go func() {
+			if err := reconcilePopularity(ctx); err != nil {
+				log.Printf("level=error job=popularity_reconcile err=%v", err)
+			}
+		}()
+	}
+	go jobs.RunEvery(ctx, "popularity_reconcile", cfg.Popularity.ReconcileInterval, reconcilePopularity)
+
+	rebuildRelated := func(ctx context.Context) error {
+		rows, skipped, err := jobSvcs.Related.Rebuild(ctx)
+		if err != nil {
+			return err
+		}
+		if skipped {
+			log.Printf("level=info job=related_games msg=%q", "lock held by another replica")
+			return nil
+		}
+		log.Printf("level=info job=related_games rows=%d", rows)
+		return nil
+	}
+	if cfg.Related.Interval > 0 {
+		go func() {
+			if err := rebuildRelated(ctx); err != nil {
+				log.Printf("level=error job=related_games err=%v", err)
+			}
+		}()
+	}
+	go jobs.RunEvery(ctx, "related_games", cfg.Related.Interval, rebuildRelated)
+
+	go jobs.RunEvery(ctx, "game_schedule", cfg.GameSchedule.Interval, func(ctx context.Context) error {
+		applied, err := jobSvcs.GameSchedule.ApplyDue(ctx)
+		if err != nil {
+			return err
+		}
+		if applied.Skipped {
+			log.Printf("level=info job=game_schedule msg=%q", "lock held by another replica")
+			return nil
+		}
+		log.Printf("level=info job=game_schedule published=%v unpublished=%v", applied.Published, applied.Unpublished)
+		return nil
+	})
+
+	go jobs.RunEvery(ctx, "game_purge", cfg.GameDelete.PurgeInterval, func(ctx context.Context) error {
+		reports, err := jobSvcs.GameDelete.PurgeExpired(ctx)
+		if err != nil {
+			return err
+		}
+		for _, r := range reports {
+			log.Printf("level=info job=game_purge game_id=%d slug=%s rows=%v leaderboard_keys=%d objects=%d bytes=%d",
+				r.GameID, r.Slug, r.Rows, r.LeaderboardKeys, r.StorageObjects, r.StorageBytes)
+		}
+		return nil
+	})
+
+	go jobs.RunEvery(ctx, "weekly_report", cfg.WeeklyReport.Interval, func(ctx context.Context) error {
+		sent, err := jobSvcs.WeeklyReport.SendDue(ctx)
+		if err != nil {
+			return err
+		}
+		log.Printf("level=info job=weekly_report sent=%d", sent)
+		return nil
 	})
 
 	if cfg.Env != "prod" {
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/clients"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/storage"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", true, "report garbage without deleting it")
+	keepUploads := flag.Int("keep-uploads", 0, "upload archives to keep per game (0 uses STORAGE_GC_KEEP_UPLOADS)")
+	flag.Parse()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg := config.MustLoad()
+
+	db, err := clients.NewPostgres(ctx, cfg.Postgres)
+	if err != nil {
+		log.Fatalf("startup failed (postgres): %v", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	store, err := storage.New(ctx, cfg)
+	if err != nil {
+		log.Fatalf("startup failed (storage): %v", err)
+	}
+
+	gcSvc := services.NewStorageGCService(
+		repos.NewGameRepo(db),
+		repos.NewGameBuildRepo(db),
+		store,
+		cfg.StorageGC,
+	)
+
+	report, err := gcSvc.Run(ctx, services.StorageGCInput{
+		DryRun:      *dryRun,
+		KeepUploads: *keepUploads,
+	})
+	if err != nil {
+		log.Fatalf("storage gc failed: %v", err)
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(report); err != nil {
+		log.Fatalf("encode report: %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package clients
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
@@ -14,10 +15,20 @@ import (
 	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
 )
 
+var ErrObjectNotFound = errors.New("object not found")
+
 type MinIO struct {
 	cli *minio.Client
 }
 
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ETag         string
+	ContentType  string
+	LastModified time.Time
+}
+
 func NewMinIO(ctx context.Context, cfg config.MinIOConfig) (*MinIO, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, err
@@ -57,6 +68,98 @@ func (m *MinIO) PutObject(ctx context.Context, bucket, objectKey string, reader
 	return info.ETag, nil
 }
 
+func (m *MinIO) StatObject(ctx context.Context, bucket, objectKey string) (ObjectInfo, error) {
+	info, err := m.cli.StatObject(ctx, bucket, objectKey, minio.StatObjectOptions{})
+	if err != nil {
+		return ObjectInfo{}, mapMinioErr(err)
+	}
+	return toObjectInfo(info), nil
+}
+
+// GetObject returns a seekable reader so callers can serve byte ranges.
+func (m *MinIO) GetObject(ctx context.Context, bucket, objectKey string) (io.ReadSeekCloser, ObjectInfo, error) {
+	obj, err := m.cli.GetObject(ctx, bucket, objectKey, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, ObjectInfo{}, mapMinioErr(err)
+	}
+	info, err := obj.Stat()
+	if err != nil {
+		_ = obj.Close()
+		return nil, ObjectInfo{}, mapMinioErr(err)
+	}
+	return obj, toObjectInfo(info), nil
+}
+
+func (m *MinIO) PresignGetObject(ctx context.Context, bucket, objectKey string, expiry time.Duration) (string, error) {
+	u, err := m.cli.PresignedGetObject(ctx, bucket, objectKey, expiry, nil)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+func (m *MinIO) ListObjects(ctx context.Context, bucket, prefix string, recursive bool) ([]ObjectInfo, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	out := make([]ObjectInfo, 0)
+	for obj := range m.cli.ListObjects(ctx, bucket, minio.ListObjectsOptions{
+		Prefix:    prefix,
+		Recursive: recursive,
+	}) {
+		if obj.Err != nil {
+			return nil, obj.Err
+		}
+		out = append(out, toObjectInfo(obj))
+	}
+	return out, nil
+}
+
+func (m *MinIO) CopyObject(ctx context.Context, bucket, srcKey, dstKey string) error {
+	_, err := m.cli.CopyObject(ctx,
+		minio.CopyDestOptions{Bucket: bucket, Object: dstKey},
+		minio.CopySrcOptions{Bucket: bucket, Object: srcKey},
+	)
+	return err
+}
+
+func (m *MinIO) RemoveObjects(ctx context.Context, bucket string, objectKeys []string) error {
+	if len(objectKeys) == 0 {
+		return nil
+	}
+
+	objects := make(chan minio.ObjectInfo, len(objectKeys))
+	for _, key := range objectKeys {
+		objects <- minio.ObjectInfo{Key: key}
+	}
+	close(objects)
+
+	var firstErr error
+	for res := range m.cli.RemoveObjects(ctx, bucket, objects, minio.RemoveObjectsOptions{}) {
+		if res.Err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("minio remove %q: %w", res.ObjectName, res.Err)
+		}
+	}
+	return firstErr
+}
+
+func toObjectInfo(obj minio.ObjectInfo) ObjectInfo {
+	return ObjectInfo{
+		Key:          obj.Key,
+		Size:         obj.Size,
+		ETag:         obj.ETag,
+		ContentType:  obj.ContentType,
+		LastModified: obj.LastModified,
+	}
+}
+
+func mapMinioErr(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrObjectNotFound
+	}
+	return err
+}
+
 func normalizeMinioEndpoint(raw string) (endpoint string, secure bool, err error) {
 	s := strings.TrimSpace(raw)
 	if s == "" {
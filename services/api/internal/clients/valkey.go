@@ -56,6 +56,73 @@ func (v *Valkey) Close() error {
 	return v.rdb.Close()
 }
 
+func (v *Valkey) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	b, err := v.rdb.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return b, true, nil
+}
+
+// MGet returns one entry per key; missing keys come back as "".
+func (v *Valkey) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	res, err := v.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]string, len(res))
+	for i, val := range res {
+		if s, ok := val.(string); ok {
+			out[i] = s
+		}
+	}
+	return out, nil
+}
+
+func (v *Valkey) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return v.rdb.Set(ctx, key, value, ttl).Err()
+}
+
+func (v *Valkey) Del(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	return v.rdb.Del(ctx, keys...).Err()
+}
+
+// ScanDel deletes every key matching pattern using SCAN, so it never blocks
+// the server the way KEYS would, and returns how many keys were removed.
+func (v *Valkey) ScanDel(ctx context.Context, pattern string) (int64, error) {
+	var (
+		cursor  uint64
+		deleted int64
+	)
+	for {
+		keys, next, err := v.rdb.Scan(ctx, cursor, pattern, 200).Result()
+		if err != nil {
+			return deleted, err
+		}
+		if len(keys) > 0 {
+			n, err := v.rdb.Del(ctx, keys...).Result()
+			if err != nil {
+				return deleted, err
+			}
+			deleted += n
+		}
+		if next == 0 {
+			return deleted, nil
+		}
+		cursor = next
+	}
+}
+
+func (v *Valkey) Incr(ctx context.Context, key string) (int64, error) {
+	return v.rdb.Incr(ctx, key).Result()
+}
+
 func (v *Valkey) ZScore(ctx context.Context, key, member string) (float64, bool, error) {
 	score, err := v.rdb.ZScore(ctx, key, member).Result()
 	if err == redis.Nil {
@@ -168,6 +235,15 @@ func (v *Valkey) IncrWithTTL(ctx context.Context, key string, ttl time.Duration)
 	}
 }
 
+// KeyGamePatterns matches every leaderboard key owned by a single game,
+// public and preview.
+func KeyGamePatterns(gameID int64) []string {
+	return []string{
+		fmt.Sprintf("lb:game:%d:*", gameID),
+		fmt.Sprintf("lb:preview:game:%d:*", gameID),
+	}
+}
+
 func KeyGameDaily(gameID int64, t time.Time) string {
 	return fmt.Sprintf("lb:game:%d:d:%s", gameID, t.UTC().Format("20060102"))
 }
@@ -177,6 +253,17 @@ func KeyGameWeekly(gameID int64, t time.Time) string {
 	return fmt.Sprintf("lb:game:%d:w:%04d%02d", gameID, year, week)
 }
 
+// Preview sessions write to their own per-game keys so draft testing never
+// reaches the public boards.
+func KeyPreviewGameDaily(gameID int64, t time.Time) string {
+	return fmt.Sprintf("lb:preview:game:%d:d:%s", gameID, t.UTC().Format("20060102"))
+}
+
+func KeyPreviewGameWeekly(gameID int64, t time.Time) string {
+	year, week := t.UTC().ISOWeek()
+	return fmt.Sprintf("lb:preview:game:%d:w:%04d%02d", gameID, year, week)
+}
+
 func KeyGlobalDaily(t time.Time) string {
 	return fmt.Sprintf("lb:global:d:%s", t.UTC().Format("20060102"))
 }
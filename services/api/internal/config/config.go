@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/mail"
 	"os"
 	"strconv"
 	"strings"
@@ -12,11 +13,40 @@ type Config struct {
 	Env  string
 	Port string
 
-	Postgres PostgresConfig
-	Valkey   ValkeyConfig
-	MinIO    MinIOConfig
-	Upload   UploadConfig
-	JWT      JWTConfig
+	Postgres     PostgresConfig
+	Valkey       ValkeyConfig
+	Storage      StorageConfig
+	MinIO        MinIOConfig
+	Upload       UploadConfig
+	Media        MediaConfig
+	StorageGC    StorageGCConfig
+	Popularity   PopularityConfig
+	CatalogCache CatalogCacheConfig
+	GameSchedule GameScheduleConfig
+	Preview      PreviewConfig
+	GameDelete   GameDeleteConfig
+	Locale       LocaleConfig
+	Related      RelatedGamesConfig
+	Mail         MailConfig
+	WeeklyReport WeeklyReportConfig
+	JWT          JWTConfig
+}
+
+const (
+	StorageBackendMinIO  = "minio"
+	StorageBackendLocal  = "local"
+	StorageBackendMemory = "memory"
+)
+
+const (
+	MailSinkLog  = "log"
+	MailSinkFile = "file"
+	MailSinkSMTP = "smtp"
+)
+
+type StorageConfig struct {
+	Backend  string
+	LocalDir string
 }
 
 type MinIOConfig struct {
@@ -27,7 +57,91 @@ type MinIOConfig struct {
 }
 
 type UploadConfig struct {
-	ZipMaxBytes int64
+	ZipMaxBytes  int64
+	Concurrency  int
+	MaxRetries   int
+	RetryBackoff time.Duration
+}
+
+type MediaConfig struct {
+	MaxImageBytes  int64
+	MaxPixels      int
+	MaxScreenshots int
+}
+
+type StorageGCConfig struct {
+	Interval    time.Duration
+	KeepUploads int
+	MinAge      time.Duration
+}
+
+// PopularityConfig controls the play-count rollup behind play_count, the
+// popular sort and dashboard top games.
+type PopularityConfig struct {
+	WindowDays        int
+	ReconcileInterval time.Duration
+}
+
+// CatalogCacheConfig: TTL bounds how long Valkey keeps public catalog reads
+// (0 disables), MaxAge is the Cache-Control max-age sent to clients.
+type CatalogCacheConfig struct {
+	TTL    time.Duration
+	MaxAge time.Duration
+}
+
+// GameScheduleConfig sets how often scheduled publish/unpublish times are
+// applied (0 disables the scheduler on this replica).
+type GameScheduleConfig struct {
+	Interval time.Duration
+}
+
+// PreviewConfig: TokenTTL is how long an admin draft preview link stays
+// valid.
+type PreviewConfig struct {
+	TokenTTL time.Duration
+}
+
+// GameDeleteConfig: soft-deleted games are kept for Retention before the
+// purge job (every PurgeInterval, 0 disables) hard-deletes them.
+type GameDeleteConfig struct {
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+// LocaleConfig: Default is the language stored in the base columns
+// (games.title, category names); other Supported locales come from the
+// translation tables.
+type LocaleConfig struct {
+	Default   string
+	Supported []string
+}
+
+// RelatedGamesConfig: the related-games job runs every Interval (0 disables
+// it), counts co-plays from the last WindowDays and stores up to PerGame
+// related games for each game.
+type RelatedGamesConfig struct {
+	Interval   time.Duration
+	WindowDays int
+	PerGame    int
+}
+
+// MailConfig: Sink picks where outgoing mail goes. "log" (subject and size
+// only) and "file" (one .eml per message in Dir) are for local use and are
+// rejected in prod while weekly reports are on; "smtp" relays through
+// SMTPAddr.
+type MailConfig struct {
+	Sink         string
+	Dir          string
+	From         string
+	SMTPAddr     string
+	SMTPUsername string
+	SMTPPassword string
+}
+
+// WeeklyReportConfig: the weekly report job runs every Interval (0 disables
+// it) and mails last week's report for every child profile that played.
+type WeeklyReportConfig struct {
+	Interval time.Duration
 }
 
 type PostgresConfig struct {
@@ -78,6 +192,111 @@ func Load() (Config, error) {
 		return Config{}, fmt.Errorf("invalid ZIP_UPLOAD_MAX_BYTES=%d (must be > 0)", zipMaxBytesInt)
 	}
 
+	uploadConcurrency, err := parseIntEnv("UPLOAD_CONCURRENCY", "8")
+	if err != nil {
+		return Config{}, err
+	}
+
+	uploadMaxRetries, err := parseIntEnv("UPLOAD_MAX_RETRIES", "3")
+	if err != nil {
+		return Config{}, err
+	}
+
+	uploadRetryBackoff, err := parseDurationEnv("UPLOAD_RETRY_BACKOFF", "250ms")
+	if err != nil {
+		return Config{}, err
+	}
+
+	mediaMaxImageBytes, err := parseIntEnv("MEDIA_MAX_IMAGE_BYTES", "5242880")
+	if err != nil {
+		return Config{}, err
+	}
+
+	mediaMaxPixels, err := parseIntEnv("MEDIA_MAX_PIXELS", "40000000")
+	if err != nil {
+		return Config{}, err
+	}
+
+	mediaMaxScreenshots, err := parseIntEnv("MEDIA_MAX_SCREENSHOTS", "8")
+	if err != nil {
+		return Config{}, err
+	}
+
+	gcInterval, err := parseDurationEnv("STORAGE_GC_INTERVAL", "0s")
+	if err != nil {
+		return Config{}, err
+	}
+
+	gcKeepUploads, err := parseIntEnv("STORAGE_GC_KEEP_UPLOADS", "3")
+	if err != nil {
+		return Config{}, err
+	}
+
+	gcMinAge, err := parseDurationEnv("STORAGE_GC_MIN_AGE", "1h")
+	if err != nil {
+		return Config{}, err
+	}
+
+	popularityWindowDays, err := parseIntEnv("POPULARITY_WINDOW_DAYS", "7")
+	if err != nil {
+		return Config{}, err
+	}
+
+	popularityReconcileInterval, err := parseDurationEnv("POPULARITY_RECONCILE_INTERVAL", "1h")
+	if err != nil {
+		return Config{}, err
+	}
+
+	catalogCacheTTL, err := parseDurationEnv("CATALOG_CACHE_TTL", "60s")
+	if err != nil {
+		return Config{}, err
+	}
+
+	catalogCacheMaxAge, err := parseDurationEnv("CATALOG_CACHE_MAX_AGE", "30s")
+	if err != nil {
+		return Config{}, err
+	}
+
+	gameScheduleInterval, err := parseDurationEnv("GAME_SCHEDULE_INTERVAL", "30s")
+	if err != nil {
+		return Config{}, err
+	}
+
+	previewTokenTTL, err := parseDurationEnv("PREVIEW_TOKEN_TTL", "24h")
+	if err != nil {
+		return Config{}, err
+	}
+
+	gameDeleteRetention, err := parseDurationEnv("GAME_DELETE_RETENTION", "720h")
+	if err != nil {
+		return Config{}, err
+	}
+
+	gamePurgeInterval, err := parseDurationEnv("GAME_PURGE_INTERVAL", "1h")
+	if err != nil {
+		return Config{}, err
+	}
+
+	relatedInterval, err := parseDurationEnv("RELATED_GAMES_INTERVAL", "1h")
+	if err != nil {
+		return Config{}, err
+	}
+
+	relatedWindowDays, err := parseIntEnv("RELATED_GAMES_WINDOW_DAYS", "30")
+	if err != nil {
+		return Config{}, err
+	}
+
+	relatedPerGame, err := parseIntEnv("RELATED_GAMES_PER_GAME", "12")
+	if err != nil {
+		return Config{}, err
+	}
+
+	weeklyReportInterval, err := parseDurationEnv("WEEKLY_REPORT_INTERVAL", "0s")
+	if err != nil {
+		return Config{}, err
+	}
+
 	cfg := Config{
 		Env:  getEnv("ENV", "dev"),
 		Port: getEnv("PORT", "8080"),
@@ -97,6 +316,11 @@ func Load() (Config, error) {
 			DB:       valkeyDB,
 		},
 
+		Storage: StorageConfig{
+			Backend:  strings.ToLower(strings.TrimSpace(getEnv("STORAGE_BACKEND", StorageBackendMinIO))),
+			LocalDir: getEnv("STORAGE_LOCAL_DIR", "./data/objects"),
+		},
+
 		MinIO: MinIOConfig{
 			Endpoint:  os.Getenv("MINIO_ENDPOINT"),
 			AccessKey: os.Getenv("MINIO_ACCESS_KEY"),
@@ -105,7 +329,69 @@ func Load() (Config, error) {
 		},
 
 		Upload: UploadConfig{
-			ZipMaxBytes: int64(zipMaxBytesInt),
+			ZipMaxBytes:  int64(zipMaxBytesInt),
+			Concurrency:  uploadConcurrency,
+			MaxRetries:   uploadMaxRetries,
+			RetryBackoff: uploadRetryBackoff,
+		},
+
+		Media: MediaConfig{
+			MaxImageBytes:  int64(mediaMaxImageBytes),
+			MaxPixels:      mediaMaxPixels,
+			MaxScreenshots: mediaMaxScreenshots,
+		},
+
+		StorageGC: StorageGCConfig{
+			Interval:    gcInterval,
+			KeepUploads: gcKeepUploads,
+			MinAge:      gcMinAge,
+		},
+
+		Popularity: PopularityConfig{
+			WindowDays:        popularityWindowDays,
+			ReconcileInterval: popularityReconcileInterval,
+		},
+
+		CatalogCache: CatalogCacheConfig{
+			TTL:    catalogCacheTTL,
+			MaxAge: catalogCacheMaxAge,
+		},
+
+		GameSchedule: GameScheduleConfig{
+			Interval: gameScheduleInterval,
+		},
+
+		Preview: PreviewConfig{
+			TokenTTL: previewTokenTTL,
+		},
+
+		GameDelete: GameDeleteConfig{
+			Retention:     gameDeleteRetention,
+			PurgeInterval: gamePurgeInterval,
+		},
+
+		Locale: LocaleConfig{
+			Default:   strings.ToLower(strings.TrimSpace(getEnv("DEFAULT_LOCALE", "en"))),
+			Supported: parseListEnv("SUPPORTED_LOCALES", "en,id"),
+		},
+
+		Related: RelatedGamesConfig{
+			Interval:   relatedInterval,
+			WindowDays: relatedWindowDays,
+			PerGame:    relatedPerGame,
+		},
+
+		Mail: MailConfig{
+			Sink:         strings.ToLower(strings.TrimSpace(getEnv("MAIL_SINK", MailSinkLog))),
+			Dir:          getEnv("MAIL_DIR", "./data/mail"),
+			From:         getEnv("MAIL_FROM", "Kids Planet <no-reply@kidsplanet.local>"),
+			SMTPAddr:     os.Getenv("SMTP_ADDR"),
+			SMTPUsername: os.Getenv("SMTP_USERNAME"),
+			SMTPPassword: os.Getenv("SMTP_PASSWORD"),
+		},
+
+		WeeklyReport: WeeklyReportConfig{
+			Interval: weeklyReportInterval,
 		},
 
 		JWT: JWTConfig{
@@ -121,12 +407,55 @@ func Load() (Config, error) {
 	if err := cfg.Valkey.Validate(); err != nil {
 		return Config{}, err
 	}
-	if err := cfg.MinIO.Validate(); err != nil {
+	if err := cfg.Storage.Validate(); err != nil {
 		return Config{}, err
 	}
+	if cfg.Storage.Backend == StorageBackendMinIO {
+		if err := cfg.MinIO.Validate(); err != nil {
+			return Config{}, err
+		}
+	}
 	if err := cfg.Upload.Validate(); err != nil {
 		return Config{}, err
 	}
+	if err := cfg.Media.Validate(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.StorageGC.Validate(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Popularity.Validate(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.CatalogCache.Validate(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.GameSchedule.Validate(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Preview.Validate(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.GameDelete.Validate(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Locale.Validate(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Related.Validate(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.Mail.Validate(); err != nil {
+		return Config{}, err
+	}
+	if err := cfg.WeeklyReport.Validate(); err != nil {
+		return Config{}, err
+	}
+	// Weekly reports carry children's play data, so production must deliver
+	// them instead of leaving them in logs or local files.
+	if cfg.Env == "prod" && cfg.WeeklyReport.Interval > 0 && cfg.Mail.Sink != MailSinkSMTP {
+		return Config{}, fmt.Errorf("invalid MAIL_SINK=%q: must be smtp when ENV=prod and WEEKLY_REPORT_INTERVAL > 0", cfg.Mail.Sink)
+	}
 	if err := cfg.JWT.Validate(); err != nil {
 		return Config{}, err
 	}
@@ -171,6 +500,20 @@ func (c ValkeyConfig) Validate() error {
 	return nil
 }
 
+func (c StorageConfig) Validate() error {
+	switch c.Backend {
+	case StorageBackendMinIO, StorageBackendMemory:
+		return nil
+	case StorageBackendLocal:
+		if strings.TrimSpace(c.LocalDir) == "" {
+			return fmt.Errorf("missing required env: [STORAGE_LOCAL_DIR]")
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid STORAGE_BACKEND=%q (must be minio, local or memory)", c.Backend)
+	}
+}
+
 func (c MinIOConfig) Validate() error {
 	var missing []string
 	if strings.TrimSpace(c.Endpoint) == "" {
@@ -195,6 +538,153 @@ func (c UploadConfig) Validate() error {
 	if c.ZipMaxBytes <= 0 {
 		return fmt.Errorf("invalid ZIP_UPLOAD_MAX_BYTES: must be > 0")
 	}
+	if c.Concurrency < 1 || c.Concurrency > 64 {
+		return fmt.Errorf("invalid UPLOAD_CONCURRENCY: must be between 1 and 64")
+	}
+	if c.MaxRetries < 0 || c.MaxRetries > 10 {
+		return fmt.Errorf("invalid UPLOAD_MAX_RETRIES: must be between 0 and 10")
+	}
+	if c.RetryBackoff <= 0 {
+		return fmt.Errorf("invalid UPLOAD_RETRY_BACKOFF: must be > 0")
+	}
+	return nil
+}
+
+func (c MediaConfig) Validate() error {
+	if c.MaxImageBytes <= 0 {
+		return fmt.Errorf("invalid MEDIA_MAX_IMAGE_BYTES: must be > 0")
+	}
+	if c.MaxPixels <= 0 {
+		return fmt.Errorf("invalid MEDIA_MAX_PIXELS: must be > 0")
+	}
+	if c.MaxScreenshots < 1 || c.MaxScreenshots > 50 {
+		return fmt.Errorf("invalid MEDIA_MAX_SCREENSHOTS: must be between 1 and 50")
+	}
+	return nil
+}
+
+func (c StorageGCConfig) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("invalid STORAGE_GC_INTERVAL: must be >= 0")
+	}
+	if c.KeepUploads < 1 {
+		return fmt.Errorf("invalid STORAGE_GC_KEEP_UPLOADS: must be >= 1")
+	}
+	if c.MinAge < 0 {
+		return fmt.Errorf("invalid STORAGE_GC_MIN_AGE: must be >= 0")
+	}
+	return nil
+}
+
+func (c PopularityConfig) Validate() error {
+	if c.WindowDays < 1 || c.WindowDays > 365 {
+		return fmt.Errorf("invalid POPULARITY_WINDOW_DAYS: must be between 1 and 365")
+	}
+	if c.ReconcileInterval < 0 {
+		return fmt.Errorf("invalid POPULARITY_RECONCILE_INTERVAL: must be >= 0")
+	}
+	return nil
+}
+
+func (c CatalogCacheConfig) Validate() error {
+	if c.TTL < 0 {
+		return fmt.Errorf("invalid CATALOG_CACHE_TTL: must be >= 0")
+	}
+	if c.MaxAge < 0 {
+		return fmt.Errorf("invalid CATALOG_CACHE_MAX_AGE: must be >= 0")
+	}
+	return nil
+}
+
+func (c GameScheduleConfig) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("invalid GAME_SCHEDULE_INTERVAL: must be >= 0")
+	}
+	return nil
+}
+
+func (c PreviewConfig) Validate() error {
+	if c.TokenTTL <= 0 || c.TokenTTL > 7*24*time.Hour {
+		return fmt.Errorf("invalid PREVIEW_TOKEN_TTL: must be > 0 and <= 168h")
+	}
+	return nil
+}
+
+func (c GameDeleteConfig) Validate() error {
+	if c.Retention < 0 {
+		return fmt.Errorf("invalid GAME_DELETE_RETENTION: must be >= 0")
+	}
+	if c.PurgeInterval < 0 {
+		return fmt.Errorf("invalid GAME_PURGE_INTERVAL: must be >= 0")
+	}
+	return nil
+}
+
+func (c LocaleConfig) Validate() error {
+	defaultSupported := false
+	for _, l := range c.Supported {
+		if len(l) < 2 || len(l) > 3 || strings.Trim(l, "abcdefghijklmnopqrstuvwxyz") != "" {
+			return fmt.Errorf("invalid SUPPORTED_LOCALES: %q is not a 2-3 letter language code", l)
+		}
+		if l == c.Default {
+			defaultSupported = true
+		}
+	}
+	if !defaultSupported {
+		return fmt.Errorf("invalid DEFAULT_LOCALE: %q must be listed in SUPPORTED_LOCALES", c.Default)
+	}
+	return nil
+}
+
+// IsSupported reports whether locale is one of the configured locales.
+func (c LocaleConfig) IsSupported(locale string) bool {
+	for _, l := range c.Supported {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+func (c RelatedGamesConfig) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("invalid RELATED_GAMES_INTERVAL: must be >= 0")
+	}
+	if c.WindowDays < 1 || c.WindowDays > 365 {
+		return fmt.Errorf("invalid RELATED_GAMES_WINDOW_DAYS: must be between 1 and 365")
+	}
+	if c.PerGame < 1 || c.PerGame > 50 {
+		return fmt.Errorf("invalid RELATED_GAMES_PER_GAME: must be between 1 and 50")
+	}
+	return nil
+}
+
+func (c MailConfig) Validate() error {
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		return fmt.Errorf("invalid MAIL_FROM=%q: %w", c.From, err)
+	}
+	switch c.Sink {
+	case MailSinkLog:
+		return nil
+	case MailSinkFile:
+		if strings.TrimSpace(c.Dir) == "" {
+			return fmt.Errorf("missing required env: [MAIL_DIR]")
+		}
+		return nil
+	case MailSinkSMTP:
+		if strings.TrimSpace(c.SMTPAddr) == "" {
+			return fmt.Errorf("missing required env: [SMTP_ADDR]")
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid MAIL_SINK=%q (must be log, file or smtp)", c.Sink)
+	}
+}
+
+func (c WeeklyReportConfig) Validate() error {
+	if c.Interval < 0 {
+		return fmt.Errorf("invalid WEEKLY_REPORT_INTERVAL: must be >= 0")
+	}
 	return nil
 }
 
@@ -253,6 +743,18 @@ func parseIntEnv(key, def string) (int, error) {
 	return n, nil
 }
 
+// parseListEnv splits a comma-separated value, lowercasing and dropping
+// empty entries.
+func parseListEnv(key, def string) []string {
+	var out []string
+	for _, part := range strings.Split(getEnv(key, def), ",") {
+		if v := strings.ToLower(strings.TrimSpace(part)); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 func urlEscape(s string) string {
 	repl := map[rune]string{
 		'%':  "%25",
@@ -0,0 +1,113 @@
+package admin
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/middleware"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type CatalogHandler struct {
+	catalogSvc *services.CatalogService
+}
+
+func NewCatalogHandler(catalogSvc *services.CatalogService) *CatalogHandler {
+	return &CatalogHandler{catalogSvc: catalogSvc}
+}
+
+func failFromCatalogErr(c *fiber.Ctx, err error) error {
+	if appErr, ok := err.(utils.AppError); ok {
+		return utils.Fail(c, appErr)
+	}
+	return utils.Fail(c, utils.ErrInternal())
+}
+
+func parseBoolQuery(c *fiber.Ctx, name string, def bool) (bool, error) {
+	v := strings.TrimSpace(c.Query(name))
+	if v == "" {
+		return def, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, utils.ErrBadRequest(name + " must be a boolean")
+	}
+	return b, nil
+}
+
+// Export spools the archive to a temp file first so a failure can still be
+// reported as JSON instead of a truncated download.
+func (h *CatalogHandler) Export(c *fiber.Ctx) error {
+	includeBuilds, err := parseBoolQuery(c, "include_builds", false)
+	if err != nil {
+		return failFromCatalogErr(c, err)
+	}
+
+	tmp, err := os.CreateTemp("", "kids-planet-catalog-export-")
+	if err != nil {
+		return utils.Fail(c, utils.ErrInternal())
+	}
+	// Unlinked right away; the open handle keeps the data until the
+	// response stream closes it.
+	_ = os.Remove(tmp.Name())
+
+	if err := h.catalogSvc.Export(c.Context(), tmp, includeBuilds); err != nil {
+		_ = tmp.Close()
+		return failFromCatalogErr(c, err)
+	}
+	size, err := tmp.Seek(0, io.SeekCurrent)
+	if err != nil {
+		_ = tmp.Close()
+		return utils.Fail(c, utils.ErrInternal())
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		_ = tmp.Close()
+		return utils.Fail(c, utils.ErrInternal())
+	}
+
+	filename := fmt.Sprintf("catalog-%s.zip", time.Now().UTC().Format("20060102-150405"))
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, `attachment; filename="`+filename+`"`)
+	return c.SendStream(tmp, int(size))
+}
+
+func (h *CatalogHandler) Import(c *fiber.Ctx) error {
+	dryRun, err := parseBoolQuery(c, "dry_run", true)
+	if err != nil {
+		return failFromCatalogErr(c, err)
+	}
+
+	userID, ok := c.Locals(middleware.LocalUserID).(int64)
+	if !ok || userID <= 0 {
+		return utils.Fail(c, utils.ErrInternal())
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil || fh == nil {
+		return utils.Fail(c, utils.ErrBadRequest("file is required"))
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return utils.Fail(c, utils.ErrInternal())
+	}
+	defer func() { _ = f.Close() }()
+
+	out, err := h.catalogSvc.Import(c.Context(), userID, services.CatalogImportInput{
+		File:       f,
+		Size:       fh.Size,
+		DryRun:     dryRun,
+		OnConflict: c.Query("on_conflict"),
+	})
+	if err != nil {
+		return failFromCatalogErr(c, err)
+	}
+	return utils.Success(c, out)
+}
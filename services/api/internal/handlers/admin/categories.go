@@ -52,14 +52,15 @@ func (h *CategoriesHandler) ListAge(c *fiber.Ctx) error {
 		limit = n
 	}
 
-	items, appErr := h.categorySvc.ListAgeCategories(context.Background(), q, page, limit)
+	items, next, appErr := h.categorySvc.ListAgeCategories(context.Background(), q, page, limit, strings.TrimSpace(c.Query("cursor")))
 	if appErr != nil {
 		return utils.Fail(c, *appErr)
 	}
 	return utils.Success(c, fiber.Map{
-		"items": items,
-		"page":  page,
-		"limit": limit,
+		"items":       items,
+		"page":        page,
+		"limit":       limit,
+		"next_cursor": next,
 	})
 }
 
@@ -149,14 +150,15 @@ func (h *CategoriesHandler) ListEducation(c *fiber.Ctx) error {
 		limit = n
 	}
 
-	items, appErr := h.categorySvc.ListEducationCategories(context.Background(), q, page, limit)
+	items, next, appErr := h.categorySvc.ListEducationCategories(context.Background(), q, page, limit, strings.TrimSpace(c.Query("cursor")))
 	if appErr != nil {
 		return utils.Fail(c, *appErr)
 	}
 	return utils.Success(c, fiber.Map{
-		"items": items,
-		"page":  page,
-		"limit": limit,
+		"items":       items,
+		"page":        page,
+		"limit":       limit,
+		"next_cursor": next,
 	})
 }
 
@@ -0,0 +1,115 @@
+package admin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/middleware"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type CollectionsHandler struct {
+	collectionSvc *services.CollectionService
+}
+
+func NewCollectionsHandler(collectionSvc *services.CollectionService) *CollectionsHandler {
+	return &CollectionsHandler{collectionSvc: collectionSvc}
+}
+
+func failFromCollectionErr(c *fiber.Ctx, err error) error {
+	if appErr, ok := err.(utils.AppError); ok {
+		return utils.Fail(c, appErr)
+	}
+	return utils.Fail(c, utils.ErrInternal())
+}
+
+func (h *CollectionsHandler) List(c *fiber.Ctx) error {
+	out, err := h.collectionSvc.ListCollections(c.Context())
+	if err != nil {
+		return failFromCollectionErr(c, err)
+	}
+	return utils.Success(c, out)
+}
+
+func (h *CollectionsHandler) Get(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	out, err := h.collectionSvc.GetCollection(c.Context(), id)
+	if err != nil {
+		return failFromCollectionErr(c, err)
+	}
+	return utils.Success(c, out)
+}
+
+func (h *CollectionsHandler) Create(c *fiber.Ctx) error {
+	userID, ok := c.Locals(middleware.LocalUserID).(int64)
+	if !ok || userID <= 0 {
+		return utils.Fail(c, utils.ErrInternal())
+	}
+
+	var req models.CollectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("invalid json body (times must be RFC 3339)"))
+	}
+
+	out, err := h.collectionSvc.CreateCollection(c.Context(), userID, req)
+	if err != nil {
+		return failFromCollectionErr(c, err)
+	}
+	return utils.Success(c, out)
+}
+
+func (h *CollectionsHandler) Update(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	var req models.CollectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("invalid json body (times must be RFC 3339)"))
+	}
+
+	out, err := h.collectionSvc.UpdateCollection(c.Context(), id, req)
+	if err != nil {
+		return failFromCollectionErr(c, err)
+	}
+	return utils.Success(c, out)
+}
+
+func (h *CollectionsHandler) Delete(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	if err := h.collectionSvc.DeleteCollection(c.Context(), id); err != nil {
+		return failFromCollectionErr(c, err)
+	}
+	return utils.Success(c, fiber.Map{"deleted": true})
+}
+
+func (h *CollectionsHandler) SetGames(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	var req models.SetCollectionGamesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("invalid json body"))
+	}
+
+	out, err := h.collectionSvc.SetCollectionGames(c.Context(), id, req)
+	if err != nil {
+		return failFromCollectionErr(c, err)
+	}
+	return utils.Success(c, out)
+}
@@ -15,11 +15,12 @@ import (
 )
 
 type GamesHandler struct {
-	gameSvc *services.GameService
+	gameSvc   *services.GameService
+	deleteSvc *services.GameDeleteService
 }
 
-func NewGamesHandler(gameSvc *services.GameService) *GamesHandler {
-	return &GamesHandler{gameSvc: gameSvc}
+func NewGamesHandler(gameSvc *services.GameService, deleteSvc *services.GameDeleteService) *GamesHandler {
+	return &GamesHandler{gameSvc: gameSvc, deleteSvc: deleteSvc}
 }
 
 func (h *GamesHandler) List(c *fiber.Ctx) error {
@@ -49,6 +50,7 @@ func (h *GamesHandler) List(c *fiber.Ctx) error {
 		Q:      q,
 		Page:   page,
 		Limit:  limit,
+		Cursor: strings.TrimSpace(c.Query("cursor")),
 	})
 	if err != nil {
 		if appErr, ok := err.(utils.AppError); ok {
@@ -137,6 +139,55 @@ func (h *GamesHandler) Unpublish(c *fiber.Ctx) error {
 	return utils.Success(c, out)
 }
 
+// Delete soft-deletes the game; with ?hard=true it purges an already
+// soft-deleted game and returns what was removed.
+func (h *GamesHandler) Delete(c *fiber.Ctx) error {
+	idStr := strings.TrimSpace(c.Params("id"))
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	hard := false
+	if v := strings.TrimSpace(c.Query("hard")); v != "" {
+		hard, err = strconv.ParseBool(v)
+		if err != nil {
+			return utils.Fail(c, utils.ErrBadRequest("hard must be a boolean"))
+		}
+	}
+
+	var out any
+	if hard {
+		out, err = h.deleteSvc.HardDelete(c.Context(), id)
+	} else {
+		out, err = h.deleteSvc.SoftDelete(c.Context(), id)
+	}
+	if err != nil {
+		if appErr, ok := err.(utils.AppError); ok {
+			return utils.Fail(c, appErr)
+		}
+		return utils.Fail(c, utils.ErrInternal())
+	}
+	return utils.Success(c, out)
+}
+
+func (h *GamesHandler) Restore(c *fiber.Ctx) error {
+	idStr := strings.TrimSpace(c.Params("id"))
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	out, err := h.deleteSvc.Restore(c.Context(), id)
+	if err != nil {
+		if appErr, ok := err.(utils.AppError); ok {
+			return utils.Fail(c, appErr)
+		}
+		return utils.Fail(c, utils.ErrInternal())
+	}
+	return utils.Success(c, out)
+}
+
 func (h *GamesHandler) Upload(c *fiber.Ctx) error {
 	idStr := strings.TrimSpace(c.Params("id"))
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -164,7 +215,7 @@ func (h *GamesHandler) Upload(c *fiber.Ctx) error {
 	}
 
 	out, upErr := h.gameSvc.UploadAdminGameZip(
-		context.Background(),
+		c.Context(),
 		id,
 		fh.Filename,
 		rs,
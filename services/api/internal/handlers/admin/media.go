@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type MediaHandler struct {
+	mediaSvc *services.GameMediaService
+}
+
+func NewMediaHandler(mediaSvc *services.GameMediaService) *MediaHandler {
+	return &MediaHandler{mediaSvc: mediaSvc}
+}
+
+type mediaUploadFunc func(ctx context.Context, gameID int64, file io.ReadSeeker, size int64) (*services.GameMediaDTO, error)
+
+func (h *MediaHandler) UploadThumbnail(c *fiber.Ctx) error {
+	return h.upload(c, h.mediaSvc.UploadThumbnail)
+}
+
+func (h *MediaHandler) UploadScreenshot(c *fiber.Ctx) error {
+	return h.upload(c, h.mediaSvc.UploadScreenshot)
+}
+
+func (h *MediaHandler) DeleteScreenshot(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+	mediaID, err := strconv.ParseInt(strings.TrimSpace(c.Params("media_id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("media_id must be an integer"))
+	}
+
+	if err := h.mediaSvc.DeleteScreenshot(c.Context(), id, mediaID); err != nil {
+		if appErr, ok := err.(utils.AppError); ok {
+			return utils.Fail(c, appErr)
+		}
+		return utils.Fail(c, utils.ErrInternal())
+	}
+	return utils.Success(c, fiber.Map{"deleted": true})
+}
+
+func (h *MediaHandler) upload(c *fiber.Ctx, fn mediaUploadFunc) error {
+	idStr := strings.TrimSpace(c.Params("id"))
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+	if id < 1 {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer >= 1"))
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil || fh == nil {
+		return utils.Fail(c, utils.ErrBadRequest("file is required"))
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		return utils.Fail(c, utils.ErrInternal())
+	}
+	defer func() { _ = f.Close() }()
+
+	rs, ok := f.(io.ReadSeeker)
+	if !ok {
+		return utils.Fail(c, utils.ErrInternal())
+	}
+
+	out, upErr := fn(c.Context(), id, rs, fh.Size)
+	if upErr != nil {
+		if appErr, ok := upErr.(utils.AppError); ok {
+			return utils.Fail(c, appErr)
+		}
+		return utils.Fail(c, utils.ErrInternal())
+	}
+	return utils.Success(c, out)
+}
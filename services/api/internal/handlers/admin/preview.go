@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/middleware"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type PreviewHandler struct {
+	previewSvc *services.PreviewService
+}
+
+func NewPreviewHandler(previewSvc *services.PreviewService) *PreviewHandler {
+	return &PreviewHandler{previewSvc: previewSvc}
+}
+
+func (h *PreviewHandler) IssueToken(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	adminID, ok := c.Locals(middleware.LocalUserID).(int64)
+	if !ok || adminID <= 0 {
+		return utils.Fail(c, utils.ErrInternal())
+	}
+
+	out, err := h.previewSvc.IssueToken(c.Context(), id, adminID)
+	if err != nil {
+		if appErr, ok := err.(utils.AppError); ok {
+			return utils.Fail(c, appErr)
+		}
+		return utils.Fail(c, utils.ErrInternal())
+	}
+	return utils.Success(c, out)
+}
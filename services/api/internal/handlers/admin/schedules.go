@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type SchedulesHandler struct {
+	scheduleSvc *services.GameScheduleService
+}
+
+func NewSchedulesHandler(scheduleSvc *services.GameScheduleService) *SchedulesHandler {
+	return &SchedulesHandler{scheduleSvc: scheduleSvc}
+}
+
+func failFromScheduleErr(c *fiber.Ctx, err error) error {
+	if appErr, ok := err.(utils.AppError); ok {
+		return utils.Fail(c, appErr)
+	}
+	return utils.Fail(c, utils.ErrInternal())
+}
+
+func (h *SchedulesHandler) List(c *fiber.Ctx) error {
+	out, err := h.scheduleSvc.ListSchedules(c.Context())
+	if err != nil {
+		return failFromScheduleErr(c, err)
+	}
+	return utils.Success(c, out)
+}
+
+func (h *SchedulesHandler) Get(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	out, err := h.scheduleSvc.GetSchedule(c.Context(), id)
+	if err != nil {
+		return failFromScheduleErr(c, err)
+	}
+	return utils.Success(c, out)
+}
+
+func (h *SchedulesHandler) Set(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	var req models.SetGameScheduleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("invalid json body (times must be RFC 3339)"))
+	}
+
+	out, err := h.scheduleSvc.SetSchedule(c.Context(), id, req)
+	if err != nil {
+		return failFromScheduleErr(c, err)
+	}
+	return utils.Success(c, out)
+}
+
+func (h *SchedulesHandler) Cancel(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	out, err := h.scheduleSvc.CancelSchedule(c.Context(), id)
+	if err != nil {
+		return failFromScheduleErr(c, err)
+	}
+	return utils.Success(c, out)
+}
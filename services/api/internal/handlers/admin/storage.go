@@ -0,0 +1,47 @@
+package admin
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type StorageHandler struct {
+	gcSvc *services.StorageGCService
+}
+
+func NewStorageHandler(gcSvc *services.StorageGCService) *StorageHandler {
+	return &StorageHandler{gcSvc: gcSvc}
+}
+
+type StorageGCRequest struct {
+	DryRun      *bool `json:"dry_run,omitempty"`
+	KeepUploads int   `json:"keep_uploads,omitempty"`
+}
+
+func (h *StorageHandler) GC(c *fiber.Ctx) error {
+	var req StorageGCRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return utils.Fail(c, utils.ErrBadRequest("invalid json body"))
+		}
+	}
+
+	dryRun := true
+	if req.DryRun != nil {
+		dryRun = *req.DryRun
+	}
+
+	out, err := h.gcSvc.Run(c.Context(), services.StorageGCInput{
+		DryRun:      dryRun,
+		KeepUploads: req.KeepUploads,
+	})
+	if err != nil {
+		if appErr, ok := err.(utils.AppError); ok {
+			return utils.Fail(c, appErr)
+		}
+		return utils.Fail(c, utils.ErrInternal())
+	}
+	return utils.Success(c, out)
+}
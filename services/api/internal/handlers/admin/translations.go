@@ -0,0 +1,122 @@
+package admin
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type TranslationsHandler struct {
+	translationSvc *services.TranslationService
+}
+
+func NewTranslationsHandler(translationSvc *services.TranslationService) *TranslationsHandler {
+	return &TranslationsHandler{translationSvc: translationSvc}
+}
+
+func failFromTranslationErr(c *fiber.Ctx, err error) error {
+	if appErr, ok := err.(utils.AppError); ok {
+		return utils.Fail(c, appErr)
+	}
+	return utils.Fail(c, utils.ErrInternal())
+}
+
+func (h *TranslationsHandler) ListGame(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	out, err := h.translationSvc.ListGameTranslations(c.Context(), id)
+	if err != nil {
+		return failFromTranslationErr(c, err)
+	}
+	return utils.Success(c, out)
+}
+
+func (h *TranslationsHandler) UpsertGame(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	var req models.UpsertGameTranslationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("invalid json body"))
+	}
+
+	out, err := h.translationSvc.UpsertGameTranslation(c.Context(), id, c.Params("locale"), req)
+	if err != nil {
+		return failFromTranslationErr(c, err)
+	}
+	return utils.Success(c, out)
+}
+
+func (h *TranslationsHandler) DeleteGame(c *fiber.Ctx) error {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+	}
+
+	if err := h.translationSvc.DeleteGameTranslation(c.Context(), id, c.Params("locale")); err != nil {
+		return failFromTranslationErr(c, err)
+	}
+	return utils.Success(c, fiber.Map{"deleted": true})
+}
+
+// ListCategory, UpsertCategory and DeleteCategory serve both category kinds;
+// the route picks the kind.
+func (h *TranslationsHandler) ListCategory(kind repos.CategoryKind) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+		if err != nil {
+			return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+		}
+
+		out, err := h.translationSvc.ListCategoryTranslations(c.Context(), kind, id)
+		if err != nil {
+			return failFromTranslationErr(c, err)
+		}
+		return utils.Success(c, out)
+	}
+}
+
+func (h *TranslationsHandler) UpsertCategory(kind repos.CategoryKind) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+		if err != nil {
+			return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+		}
+
+		var req models.UpsertCategoryTranslationRequest
+		if err := c.BodyParser(&req); err != nil {
+			return utils.Fail(c, utils.ErrBadRequest("invalid json body"))
+		}
+
+		out, err := h.translationSvc.UpsertCategoryTranslation(c.Context(), kind, id, c.Params("locale"), req)
+		if err != nil {
+			return failFromTranslationErr(c, err)
+		}
+		return utils.Success(c, out)
+	}
+}
+
+func (h *TranslationsHandler) DeleteCategory(kind repos.CategoryKind) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id")), 10, 64)
+		if err != nil {
+			return utils.Fail(c, utils.ErrBadRequest("id must be an integer"))
+		}
+
+		if err := h.translationSvc.DeleteCategoryTranslation(c.Context(), kind, id, c.Params("locale")); err != nil {
+			return failFromTranslationErr(c, err)
+		}
+		return utils.Success(c, fiber.Map{"deleted": true})
+	}
+}
@@ -74,7 +74,7 @@ func (h *AnalyticsHandler) TrackEvent(c *fiber.Ctx) error {
 	}
 
 	playerID := getPlayerIDFromClaims(claims)
-	dataStr, err := composeAnalyticsData(req.Data, playerID)
+	dataStr, err := composeAnalyticsData(req.Data, playerID, claims.ProfileID)
 	if err != nil {
 		return utils.Fail(c, utils.ErrBadRequest("data must be valid json"))
 	}
@@ -87,6 +87,7 @@ func (h *AnalyticsHandler) TrackEvent(c *fiber.Ctx) error {
 		dataStr,
 		c.IP(),
 		c.Get("User-Agent"),
+		claims.Preview,
 	); err != nil {
 		return utils.Fail(c, utils.ErrInternal())
 	}
@@ -109,16 +110,35 @@ func getPlayerIDFromClaims(claims *analyticsPlayClaims) string {
 	return playerID
 }
 
-func composeAnalyticsData(raw json.RawMessage, playerID string) (*string, error) {
+// composeAnalyticsData stamps the token's player and profile into the event
+// data so history can be filtered on them. Whatever the game sent for those
+// keys is always dropped first, so a guest token cannot claim a player.
+func composeAnalyticsData(raw json.RawMessage, playerID string, profileID int64) (*string, error) {
 	playerID = strings.TrimSpace(playerID)
+	if playerID == "" {
+		profileID = 0
+	}
 	hasRaw := len(raw) > 0
 
 	if !hasRaw && playerID == "" {
 		return nil, nil
 	}
 
-	if !hasRaw && playerID != "" {
-		encoded, err := json.Marshal(map[string]string{"player_id": playerID})
+	stamp := func(obj map[string]any) {
+		delete(obj, "player_id")
+		delete(obj, "profile_id")
+		if playerID != "" {
+			obj["player_id"] = playerID
+		}
+		if profileID > 0 {
+			obj["profile_id"] = profileID
+		}
+	}
+
+	if !hasRaw {
+		obj := map[string]any{}
+		stamp(obj)
+		encoded, err := json.Marshal(obj)
 		if err != nil {
 			return nil, err
 		}
@@ -126,18 +146,21 @@ func composeAnalyticsData(raw json.RawMessage, playerID string) (*string, error)
 		return &v, nil
 	}
 
-	if playerID == "" {
-		v := string(raw)
-		return &v, nil
-	}
-
 	var parsed any
 	if err := json.Unmarshal(raw, &parsed); err != nil {
 		return nil, err
 	}
 
 	if obj, ok := parsed.(map[string]any); ok {
-		obj["player_id"] = playerID
+		if playerID == "" {
+			_, hasPlayer := obj["player_id"]
+			_, hasProfile := obj["profile_id"]
+			if !hasPlayer && !hasProfile {
+				v := string(raw)
+				return &v, nil
+			}
+		}
+		stamp(obj)
 		encoded, err := json.Marshal(obj)
 		if err != nil {
 			return nil, err
@@ -146,10 +169,14 @@ func composeAnalyticsData(raw json.RawMessage, playerID string) (*string, error)
 		return &v, nil
 	}
 
-	encoded, err := json.Marshal(map[string]any{
-		"player_id": playerID,
-		"payload":   parsed,
-	})
+	if playerID == "" {
+		v := string(raw)
+		return &v, nil
+	}
+
+	obj := map[string]any{"payload": parsed}
+	stamp(obj)
+	encoded, err := json.Marshal(obj)
 	if err != nil {
 		return nil, err
 	}
@@ -204,6 +231,8 @@ type analyticsPlayClaims struct {
 	GameID    int64  `json:"game_id"`
 	SessionID string `json:"session_id"`
 	Typ       string `json:"typ"`
+	Preview   bool   `json:"preview,omitempty"`
+	ProfileID int64  `json:"profile_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -0,0 +1,158 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type AssetsHandler struct {
+	assetSvc *services.GameAssetService
+}
+
+func NewAssetsHandler(assetSvc *services.GameAssetService) *AssetsHandler {
+	return &AssetsHandler{assetSvc: assetSvc}
+}
+
+// streamBody lets fasthttp close the object once the limited body is sent.
+type streamBody struct {
+	io.Reader
+	io.Closer
+}
+
+func (h *AssetsHandler) GetCurrent(c *fiber.Ctx) error {
+	return h.serve(c, h.assetSvc.OpenCurrent)
+}
+
+func (h *AssetsHandler) GetBuild(c *fiber.Ctx) error {
+	buildID, err := strconv.ParseInt(c.Params("build", ""), 10, 64)
+	if err != nil || buildID < 1 {
+		return utils.Fail(c, utils.ErrNotFound("asset not found"))
+	}
+	return h.serve(c, func(ctx context.Context, gameID int64, assetPath string) (*services.GameAsset, error) {
+		return h.assetSvc.OpenBuild(ctx, gameID, buildID, assetPath)
+	})
+}
+
+func (h *AssetsHandler) GetMedia(c *fiber.Ctx) error {
+	return h.serve(c, h.assetSvc.OpenMedia)
+}
+
+type assetOpener func(ctx context.Context, gameID int64, assetPath string) (*services.GameAsset, error)
+
+func (h *AssetsHandler) serve(c *fiber.Ctx, open assetOpener) error {
+	idStr := strings.TrimSpace(c.Params("id", ""))
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id < 1 {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer >= 1"))
+	}
+
+	assetPath, err := url.PathUnescape(c.Params("*", ""))
+	if err != nil {
+		return utils.Fail(c, utils.ErrNotFound("asset not found"))
+	}
+
+	asset, svcErr := open(c.Context(), id, assetPath)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+
+	c.Set(fiber.HeaderAcceptRanges, "bytes")
+	c.Set(fiber.HeaderCacheControl, asset.CacheControl)
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	if asset.ETag != "" {
+		c.Set(fiber.HeaderETag, asset.ETag)
+	}
+	if asset.LastModified != "" {
+		c.Set(fiber.HeaderLastModified, asset.LastModified)
+	}
+
+	if asset.ETag != "" && utils.ETagMatches(c.Get(fiber.HeaderIfNoneMatch), asset.ETag) {
+		_ = asset.Body.Close()
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, asset.ContentType)
+
+	rangeHeader := strings.TrimSpace(c.Get(fiber.HeaderRange))
+	if ifRange := strings.TrimSpace(c.Get(fiber.HeaderIfRange)); ifRange != "" && ifRange != asset.ETag && ifRange != asset.LastModified {
+		rangeHeader = ""
+	}
+	if rangeHeader == "" {
+		return c.SendStream(asset.Body, int(asset.Size))
+	}
+
+	start, end, ok := parseByteRange(rangeHeader, asset.Size)
+	if !ok {
+		_ = asset.Body.Close()
+		c.Set(fiber.HeaderContentType, fiber.MIMETextPlainCharsetUTF8)
+		c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes */%d", asset.Size))
+		return c.SendStatus(fiber.StatusRequestedRangeNotSatisfiable)
+	}
+	if start == 0 && end == asset.Size-1 {
+		return c.SendStream(asset.Body, int(asset.Size))
+	}
+
+	if _, err := asset.Body.Seek(start, io.SeekStart); err != nil {
+		_ = asset.Body.Close()
+		return utils.Fail(c, utils.ErrInternal())
+	}
+	length := end - start + 1
+	c.Set(fiber.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", start, end, asset.Size))
+	c.Status(fiber.StatusPartialContent)
+	return c.SendStream(streamBody{Reader: io.LimitReader(asset.Body, length), Closer: asset.Body}, int(length))
+}
+
+// parseByteRange handles a single "bytes=" range. Multi-range requests are
+// served as the full object, which RFC 9110 allows.
+func parseByteRange(header string, size int64) (int64, int64, bool) {
+	spec, ok := strings.CutPrefix(header, "bytes=")
+	if !ok || size <= 0 {
+		return 0, 0, false
+	}
+	if strings.Contains(spec, ",") {
+		return 0, size - 1, true
+	}
+
+	startStr, endStr, ok := strings.Cut(strings.TrimSpace(spec), "-")
+	if !ok {
+		return 0, 0, false
+	}
+	startStr = strings.TrimSpace(startStr)
+	endStr = strings.TrimSpace(endStr)
+
+	if startStr == "" {
+		suffix, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || suffix <= 0 {
+			return 0, 0, false
+		}
+		if suffix > size {
+			suffix = size
+		}
+		return size - suffix, size - 1, true
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return 0, 0, false
+	}
+	end := size - 1
+	if endStr != "" {
+		e, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || e < start {
+			return 0, 0, false
+		}
+		if e < end {
+			end = e
+		}
+	}
+	return start, end, true
+}
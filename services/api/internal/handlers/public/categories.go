@@ -3,31 +3,22 @@ package public
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/ZygmaCore/kids_planet/services/api/internal/middleware"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
 )
 
 type CategoriesHandler struct {
 	categorySvc *services.CategoryService
+	maxAge      time.Duration
 }
 
-func NewCategoriesHandler(categorySvc *services.CategoryService) *CategoriesHandler {
-	return &CategoriesHandler{categorySvc: categorySvc}
-}
-
-type publicAgeCategoryDTO struct {
-	ID     int64  `json:"id"`
-	Label  string `json:"label"`
-	MinAge int    `json:"min_age"`
-	MaxAge int    `json:"max_age"`
-}
-
-type publicEducationCategoryDTO struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+func NewCategoriesHandler(categorySvc *services.CategoryService, maxAge time.Duration) *CategoriesHandler {
+	return &CategoriesHandler{categorySvc: categorySvc, maxAge: maxAge}
 }
 
 func (h *CategoriesHandler) List(c *fiber.Ctx) error {
@@ -43,42 +34,22 @@ func (h *CategoriesHandler) List(c *fiber.Ctx) error {
 		return utils.Fail(c, utils.ErrBadRequest("type must be one of: age, education"))
 	}
 
-	ages := make([]publicAgeCategoryDTO, 0)
-	if categoryType == "" || categoryType == "age" {
-		ageItems, ageErr := h.categorySvc.ListAgeCategories(ctx, "", 1, 500)
-		if ageErr != nil {
-			return utils.Fail(c, *ageErr)
-		}
-
-		ages = make([]publicAgeCategoryDTO, 0, len(ageItems))
-		for _, item := range ageItems {
-			ages = append(ages, publicAgeCategoryDTO{
-				ID:     item.ID,
-				Label:  item.Label,
-				MinAge: item.MinAge,
-				MaxAge: item.MaxAge,
-			})
-		}
+	all, svcErr := h.categorySvc.ListPublicCategories(ctx, middleware.LocalLocaleOr(c, ""))
+	if svcErr != nil {
+		return utils.Fail(c, *svcErr)
 	}
 
-	education := make([]publicEducationCategoryDTO, 0)
+	out := services.PublicCategoriesDTO{
+		AgeCategories:       []services.PublicAgeCategoryDTO{},
+		EducationCategories: []services.PublicEducationCategoryDTO{},
+		Locale:              all.Locale,
+	}
+	if categoryType == "" || categoryType == "age" {
+		out.AgeCategories = all.AgeCategories
+	}
 	if categoryType == "" || categoryType == "education" {
-		educationItems, eduErr := h.categorySvc.ListEducationCategories(ctx, "", 1, 500)
-		if eduErr != nil {
-			return utils.Fail(c, *eduErr)
-		}
-
-		education = make([]publicEducationCategoryDTO, 0, len(educationItems))
-		for _, item := range educationItems {
-			education = append(education, publicEducationCategoryDTO{
-				ID:   item.ID,
-				Name: item.Name,
-			})
-		}
+		out.EducationCategories = all.EducationCategories
 	}
 
-	return utils.Success(c, fiber.Map{
-		"age_categories":       ages,
-		"education_categories": education,
-	})
+	return utils.SuccessCacheable(c, out, h.maxAge)
 }
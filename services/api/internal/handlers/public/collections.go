@@ -0,0 +1,47 @@
+package public
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/middleware"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type CollectionsHandler struct {
+	collectionSvc *services.CollectionService
+	maxAge        time.Duration
+}
+
+func NewCollectionsHandler(collectionSvc *services.CollectionService, maxAge time.Duration) *CollectionsHandler {
+	return &CollectionsHandler{collectionSvc: collectionSvc, maxAge: maxAge}
+}
+
+func (h *CollectionsHandler) List(c *fiber.Ctx) error {
+	gamesLimit := 0
+	if v := strings.TrimSpace(c.Query("games_limit", "")); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return utils.Fail(c, utils.ErrBadRequest("games_limit must be an integer"))
+		}
+		gamesLimit = n
+	}
+
+	dto, svcErr := h.collectionSvc.ListPublicCollections(c.Context(), gamesLimit, middleware.LocalLocaleOr(c, ""))
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.SuccessCacheable(c, dto, h.maxAge)
+}
+
+func (h *CollectionsHandler) Get(c *fiber.Ctx) error {
+	dto, svcErr := h.collectionSvc.GetPublicCollection(c.Context(), c.Params("slug", ""), middleware.LocalLocaleOr(c, ""))
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.SuccessCacheable(c, dto, h.maxAge)
+}
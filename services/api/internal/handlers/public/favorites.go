@@ -0,0 +1,79 @@
+package public
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type FavoritesHandler struct {
+	favoriteSvc *services.FavoriteService
+}
+
+func NewFavoritesHandler(favoriteSvc *services.FavoriteService) *FavoritesHandler {
+	return &FavoritesHandler{favoriteSvc: favoriteSvc}
+}
+
+func (h *FavoritesHandler) List(c *fiber.Ctx) error {
+	page, err := strconv.Atoi(strings.TrimSpace(c.Query("page", "1")))
+	if err != nil || page < 1 {
+		return utils.Fail(c, utils.ErrBadRequest("page must be an integer >= 1"))
+	}
+
+	limit, err := strconv.Atoi(strings.TrimSpace(c.Query("limit", "24")))
+	if err != nil || limit < 1 || limit > 100 {
+		return utils.Fail(c, utils.ErrBadRequest("limit must be an integer between 1 and 100"))
+	}
+
+	playerID, ok := getPlayerID(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrUnauthorized())
+	}
+
+	resp, svcErr := h.favoriteSvc.ListFavorites(c.Context(), playerID, page, limit)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *FavoritesHandler) Add(c *fiber.Ctx) error {
+	gameID, err := strconv.ParseInt(strings.TrimSpace(c.Params("game_id", "")), 10, 64)
+	if err != nil || gameID < 1 {
+		return utils.Fail(c, utils.ErrBadRequest("game_id must be an integer >= 1"))
+	}
+
+	playerID, ok := getPlayerID(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrUnauthorized())
+	}
+
+	dto, svcErr := h.favoriteSvc.Favorite(c.Context(), playerID, gameID)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.Success(c, dto)
+}
+
+func (h *FavoritesHandler) Remove(c *fiber.Ctx) error {
+	gameID, err := strconv.ParseInt(strings.TrimSpace(c.Params("game_id", "")), 10, 64)
+	if err != nil || gameID < 1 {
+		return utils.Fail(c, utils.ErrBadRequest("game_id must be an integer >= 1"))
+	}
+
+	playerID, ok := getPlayerID(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrUnauthorized())
+	}
+
+	dto, svcErr := h.favoriteSvc.Unfavorite(c.Context(), playerID, gameID)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.Success(c, dto)
+}
@@ -1,21 +1,28 @@
 package public
 
 import (
+	"fmt"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/ZygmaCore/kids_planet/services/api/internal/middleware"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
 )
 
 type GamesHandler struct {
-	gameSvc *services.GameService
+	gameSvc    *services.GameService
+	relatedSvc *services.RelatedGamesService
+	maxAge     time.Duration
 }
 
-func NewGamesHandler(gameSvc *services.GameService) *GamesHandler {
-	return &GamesHandler{gameSvc: gameSvc}
+// maxAge is the Cache-Control max-age for catalog responses.
+func NewGamesHandler(gameSvc *services.GameService, relatedSvc *services.RelatedGamesService, maxAge time.Duration) *GamesHandler {
+	return &GamesHandler{gameSvc: gameSvc, relatedSvc: relatedSvc, maxAge: maxAge}
 }
 
 func failFromServiceErr(c *fiber.Ctx, err error) error {
@@ -33,9 +40,8 @@ func failFromServiceErr(c *fiber.Ctx, err error) error {
 }
 
 func (h *GamesHandler) List(c *fiber.Ctx) error {
-	ageStr := strings.TrimSpace(c.Query("age_category_id", ""))
-	eduStr := strings.TrimSpace(c.Query("education_category_id", ""))
-	sort := strings.TrimSpace(c.Query("sort", "newest"))
+	q := strings.TrimSpace(c.Query("q", ""))
+	sort := strings.TrimSpace(c.Query("sort", ""))
 	pageStr := strings.TrimSpace(c.Query("page", "1"))
 	limitStr := strings.TrimSpace(c.Query("limit", "24"))
 
@@ -49,35 +55,97 @@ func (h *GamesHandler) List(c *fiber.Ctx) error {
 		return utils.Fail(c, utils.ErrBadRequest("limit must be an integer between 1 and 100"))
 	}
 
-	var ageID *int64
-	if ageStr != "" {
-		v, err := strconv.ParseInt(ageStr, 10, 64)
-		if err != nil || v < 1 {
-			return utils.Fail(c, utils.ErrBadRequest("age_category_id must be an integer >= 1"))
+	ageIDs, err := queryInt64List(c, "age_category_id")
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("age_category_id must be an integer >= 1"))
+	}
+
+	eduIDs, err := queryInt64List(c, "education_category_id")
+	if err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("education_category_id must be an integer >= 1"))
+	}
+
+	var free *bool
+	if freeStr := strings.TrimSpace(c.Query("free", "")); freeStr != "" {
+		v, err := strconv.ParseBool(freeStr)
+		if err != nil {
+			return utils.Fail(c, utils.ErrBadRequest("free must be true or false"))
 		}
-		ageID = &v
+		free = &v
 	}
 
-	var eduID *int64
-	if eduStr != "" {
-		v, err := strconv.ParseInt(eduStr, 10, 64)
-		if err != nil || v < 1 {
-			return utils.Fail(c, utils.ErrBadRequest("education_category_id must be an integer >= 1"))
+	var age *int
+	if ageStr := strings.TrimSpace(c.Query("age", "")); ageStr != "" {
+		v, err := strconv.Atoi(ageStr)
+		if err != nil || v < 0 {
+			return utils.Fail(c, utils.ErrBadRequest("age must be an integer >= 0"))
 		}
-		eduID = &v
+		age = &v
 	}
 
 	dto, svcErr := h.gameSvc.ListPublicGames(c.Context(), services.ListPublicGamesInput{
-		AgeCategoryID:       ageID,
-		EducationCategoryID: eduID,
-		Sort:                strings.ToLower(sort),
-		Page:                page,
-		Limit:               limit,
+		AgeCategoryIDs:       ageIDs,
+		EducationCategoryIDs: eduIDs,
+		Difficulties:         queryStringList(c, "difficulty"),
+		Free:                 free,
+		Age:                  age,
+		Q:                    q,
+		Sort:                 strings.ToLower(sort),
+		Page:                 page,
+		Limit:                limit,
+		Cursor:               strings.TrimSpace(c.Query("cursor", "")),
+		Locale:               middleware.LocalLocaleOr(c, ""),
 	})
 	if svcErr != nil {
 		return failFromServiceErr(c, svcErr)
 	}
 
+	return utils.SuccessCacheable(c, dto, h.maxAge)
+}
+
+// queryStringList accepts both repeated params (?k=a&k=b) and comma lists
+// (?k=a,b).
+func queryStringList(c *fiber.Ctx, key string) []string {
+	var out []string
+	for _, raw := range c.Context().QueryArgs().PeekMulti(key) {
+		for _, part := range strings.Split(string(raw), ",") {
+			if v := strings.TrimSpace(part); v != "" {
+				out = append(out, v)
+			}
+		}
+	}
+	return out
+}
+
+func queryInt64List(c *fiber.Ctx, key string) ([]int64, error) {
+	values := queryStringList(c, key)
+	out := make([]int64, 0, len(values))
+	for _, v := range values {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id < 1 {
+			return nil, fmt.Errorf("invalid %s", key)
+		}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+func (h *GamesHandler) Suggest(c *fiber.Ctx) error {
+	q := strings.TrimSpace(c.Query("q", ""))
+	limit := 0
+	if limitStr := strings.TrimSpace(c.Query("limit", "")); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return utils.Fail(c, utils.ErrBadRequest("limit must be an integer"))
+		}
+		limit = v
+	}
+
+	dto, svcErr := h.gameSvc.SuggestGames(c.Context(), q, limit, middleware.LocalLocaleOr(c, ""))
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+
 	return utils.Success(c, dto)
 }
 
@@ -88,10 +156,85 @@ func (h *GamesHandler) Get(c *fiber.Ctx) error {
 		return utils.Fail(c, utils.ErrBadRequest("id must be an integer >= 1"))
 	}
 
-	dto, svcErr := h.gameSvc.GetPublicGameByID(c.Context(), id)
+	dto, svcErr := h.gameSvc.GetPublicGameByID(c.Context(), id, middleware.LocalLocaleOr(c, ""))
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+
+	setCanonicalLink(c, dto.Slug)
+	return utils.SuccessCacheable(c, dto, h.maxAge)
+}
+
+// GetBySlug answers retired slugs with a 301 to the current one.
+func (h *GamesHandler) GetBySlug(c *fiber.Ctx) error {
+	res, svcErr := h.gameSvc.GetPublicGameBySlug(c.Context(), c.Params("slug", ""), middleware.LocalLocaleOr(c, ""))
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+
+	if res.RedirectSlug != "" {
+		location := gameSlugPath(res.RedirectSlug)
+		if qs := string(c.Request().URI().QueryString()); qs != "" {
+			location += "?" + qs
+		}
+		return c.Redirect(location, fiber.StatusMovedPermanently)
+	}
+
+	setCanonicalLink(c, res.Game.Slug)
+	return utils.SuccessCacheable(c, res.Game, h.maxAge)
+}
+
+func gameSlugPath(slug string) string {
+	return "/api/games/by-slug/" + url.PathEscape(slug)
+}
+
+func setCanonicalLink(c *fiber.Ctx, slug string) {
+	c.Set(fiber.HeaderLink, "<"+gameSlugPath(slug)+`>; rel="canonical"`)
+}
+
+func (h *GamesHandler) OfflineManifest(c *fiber.Ctx) error {
+	idStr := strings.TrimSpace(c.Params("id", ""))
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id < 1 {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer >= 1"))
+	}
+
+	format := strings.ToLower(strings.TrimSpace(c.Query("format", services.OfflineManifestFormatDefault)))
+	if format != services.OfflineManifestFormatDefault && format != services.OfflineManifestFormatWorkbox {
+		return utils.Fail(c, utils.ErrBadRequest("format must be one of: default, workbox"))
+	}
+
+	dto, svcErr := h.gameSvc.GetOfflineManifest(c.Context(), id)
 	if svcErr != nil {
 		return failFromServiceErr(c, svcErr)
 	}
 
+	if format == services.OfflineManifestFormatWorkbox {
+		return utils.Success(c, dto.ToPrecacheEntries())
+	}
 	return utils.Success(c, dto)
 }
+
+func (h *GamesHandler) Related(c *fiber.Ctx) error {
+	idStr := strings.TrimSpace(c.Params("id", ""))
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id < 1 {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer >= 1"))
+	}
+
+	limit := 0
+	if limitStr := strings.TrimSpace(c.Query("limit", "")); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return utils.Fail(c, utils.ErrBadRequest("limit must be an integer"))
+		}
+		limit = v
+	}
+
+	dto, svcErr := h.relatedSvc.ListRelated(c.Context(), id, limit, middleware.LocalLocaleOr(c, ""))
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+
+	return utils.SuccessCacheable(c, dto, h.maxAge)
+}
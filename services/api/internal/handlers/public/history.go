@@ -38,7 +38,30 @@ func (h *HistoryHandler) List(c *fiber.Ctx) error {
 		return utils.Fail(c, utils.ErrUnauthorized())
 	}
 
-	resp, svcErr := h.historySvc.ListPlayerHistory(c.Context(), playerID, page, limit)
+	resp, svcErr := h.historySvc.ListPlayerHistory(c.Context(), playerID, getPlayerProfileID(c), page, limit)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(resp)
+}
+
+func (h *HistoryHandler) RecentlyPlayed(c *fiber.Ctx) error {
+	limit := 0
+	if limitStr := strings.TrimSpace(c.Query("limit", "")); limitStr != "" {
+		v, err := strconv.Atoi(limitStr)
+		if err != nil {
+			return utils.Fail(c, utils.ErrBadRequest("limit must be an integer"))
+		}
+		limit = v
+	}
+
+	playerID, ok := getPlayerID(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrUnauthorized())
+	}
+
+	resp, svcErr := h.historySvc.ListRecentlyPlayed(c.Context(), playerID, getPlayerProfileID(c), limit)
 	if svcErr != nil {
 		return failFromServiceErr(c, svcErr)
 	}
@@ -37,6 +37,7 @@ func (h *LeaderboardHandler) Submit(c *fiber.Ctx) error {
 	}
 
 	tokenPlayerID := strings.TrimSpace(getTokenPlayerID(c))
+	tokenProfileID := getTokenProfileID(c)
 	guestID := strings.TrimSpace(c.Get("X-Guest-Id"))
 	sessionID := strings.TrimSpace(getTokenSessionID(c))
 
@@ -44,11 +45,13 @@ func (h *LeaderboardHandler) Submit(c *fiber.Ctx) error {
 		c.Context(),
 		tokenGameID,
 		tokenPlayerID,
+		tokenProfileID,
 		guestID,
 		req,
 		sessionID,
 		"",
 		"",
+		getTokenPreview(c),
 	)
 	if appErr != nil {
 		return utils.Fail(c, *appErr)
@@ -71,6 +74,11 @@ func getTokenGameID(c *fiber.Ctx) (int64, bool) {
 	}
 }
 
+func getTokenPreview(c *fiber.Ctx) bool {
+	v, _ := c.Locals(middleware.LocalPlayPreview).(bool)
+	return v
+}
+
 func getTokenSessionID(c *fiber.Ctx) string {
 	v := c.Locals(middleware.LocalPlaySessionID)
 	switch t := v.(type) {
@@ -83,6 +91,11 @@ func getTokenSessionID(c *fiber.Ctx) string {
 	}
 }
 
+func getTokenProfileID(c *fiber.Ctx) int64 {
+	v, _ := c.Locals(middleware.LocalPlayProfileID).(int64)
+	return v
+}
+
 func getTokenPlayerID(c *fiber.Ctx) string {
 	v := c.Locals(middleware.LocalPlaySubject)
 	switch t := v.(type) {
@@ -115,7 +128,27 @@ func (h *LeaderboardHandler) GetTop(c *fiber.Ctx) error {
 		limit = v
 	}
 
-	items, svcErr := h.svc.GetTop(c.Context(), gameID, period, scope, limit)
+	// The preview sandbox is only readable with a preview play token for
+	// the same game.
+	preview := false
+	if previewStr := strings.TrimSpace(c.Query("preview", "")); previewStr != "" {
+		v, err := strconv.ParseBool(previewStr)
+		if err != nil {
+			return utils.Fail(c, utils.ErrBadRequest("preview must be true or false"))
+		}
+		preview = v
+	}
+	if preview {
+		claims, err := parseSelfPlayToken(extractBearerToken(c.Get("Authorization")), h.cfg)
+		if err != nil || !claims.Preview {
+			return utils.Fail(c, utils.ErrUnauthorized())
+		}
+		if claims.GameID != gameID {
+			return utils.Fail(c, utils.ErrForbidden())
+		}
+	}
+
+	items, svcErr := h.svc.GetTop(c.Context(), gameID, period, scope, limit, preview)
 	if svcErr != nil {
 		return failFromServiceErr(c, svcErr)
 	}
@@ -151,7 +184,7 @@ func (h *LeaderboardHandler) GetSelf(c *fiber.Ctx) error {
 	period := strings.TrimSpace(c.Query("period", ""))
 	scope := strings.TrimSpace(c.Query("scope", ""))
 
-	member, fromPlayToken, tokenGameID, authErr := h.resolveSelfMember(c)
+	member, fromPlayToken, tokenGameID, preview, authErr := h.resolveSelfMember(c)
 	if authErr != nil {
 		return utils.Fail(c, *authErr)
 	}
@@ -164,7 +197,7 @@ func (h *LeaderboardHandler) GetSelf(c *fiber.Ctx) error {
 		return utils.Fail(c, utils.ErrForbidden())
 	}
 
-	dto, svcErr := h.svc.GetSelf(c.Context(), gameID, period, scope, member)
+	dto, svcErr := h.svc.GetSelf(c.Context(), gameID, period, scope, member, preview)
 	if svcErr != nil {
 		return failFromServiceErr(c, svcErr)
 	}
@@ -172,43 +205,45 @@ func (h *LeaderboardHandler) GetSelf(c *fiber.Ctx) error {
 	return utils.Success(c, dto)
 }
 
-func (h *LeaderboardHandler) resolveSelfMember(c *fiber.Ctx) (member string, fromPlayToken bool, tokenGameID int64, appErr *utils.AppError) {
+// resolveSelfMember reports preview=true for preview play tokens, whose
+// scores live in the sandbox keys.
+func (h *LeaderboardHandler) resolveSelfMember(c *fiber.Ctx) (member string, fromPlayToken bool, tokenGameID int64, preview bool, appErr *utils.AppError) {
 	auth := strings.TrimSpace(c.Get("Authorization"))
 	if auth == "" {
 		e := utils.ErrUnauthorized()
-		return "", false, 0, &e
+		return "", false, 0, false, &e
 	}
 
 	tokenStr := extractBearerToken(auth)
 	if tokenStr == "" {
 		e := utils.ErrUnauthorized()
-		return "", false, 0, &e
+		return "", false, 0, false, &e
 	}
 
 	if parsed, err := utils.ParsePlayerToken(h.cfg.JWT, tokenStr); err == nil {
-		return "p:" + parsed.PlayerID, false, 0, nil
+		return services.PlayerMember(parsed.PlayerID, parsed.ProfileID), false, 0, false, nil
 	}
 
 	playClaims, err := parseSelfPlayToken(tokenStr, h.cfg)
 	if err != nil {
 		e := utils.ErrUnauthorized()
-		return "", false, 0, &e
+		return "", false, 0, false, &e
 	}
 
 	playerID := strings.TrimSpace(playClaims.Subject)
 	if playerID != "" {
 		if _, parseErr := uuid.Parse(playerID); parseErr == nil {
-			return "p:" + playerID, true, playClaims.GameID, nil
+			return services.PlayerMember(playerID, playClaims.ProfileID), true, playClaims.GameID, playClaims.Preview, nil
 		}
 	}
 
 	sessionID := strings.TrimSpace(playClaims.SessionID)
 	if sessionID != "" {
-		return "s:" + sessionID, true, playClaims.GameID, nil
+		return "s:" + sessionID, true, playClaims.GameID, playClaims.Preview, nil
 	}
 
 	e := utils.ErrUnauthorized()
-	return "", false, 0, &e
+	return "", false, 0, false, &e
 }
 
 func extractBearerToken(auth string) string {
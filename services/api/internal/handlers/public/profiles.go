@@ -0,0 +1,168 @@
+package public
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/middleware"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type ProfilesHandler struct {
+	profileSvc *services.ProfileService
+	controlSvc *services.ProfileControlService
+}
+
+func NewProfilesHandler(profileSvc *services.ProfileService, controlSvc *services.ProfileControlService) *ProfilesHandler {
+	return &ProfilesHandler{profileSvc: profileSvc, controlSvc: controlSvc}
+}
+
+func (h *ProfilesHandler) List(c *fiber.Ctx) error {
+	playerID, ok := getPlayerID(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrUnauthorized())
+	}
+
+	dto, svcErr := h.profileSvc.List(c.Context(), playerID)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.Success(c, dto)
+}
+
+func (h *ProfilesHandler) Create(c *fiber.Ctx) error {
+	var req models.ProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("invalid json body"))
+	}
+
+	playerID, ok := getPlayerID(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrUnauthorized())
+	}
+
+	dto, svcErr := h.profileSvc.Create(c.Context(), playerID, req)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.Success(c, dto)
+}
+
+func (h *ProfilesHandler) Update(c *fiber.Ctx) error {
+	profileID, ok := parseProfileIDParam(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer >= 1"))
+	}
+
+	var req models.ProfileRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("invalid json body"))
+	}
+
+	playerID, ok := getPlayerID(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrUnauthorized())
+	}
+
+	dto, svcErr := h.profileSvc.Update(c.Context(), playerID, profileID, req)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.Success(c, dto)
+}
+
+func (h *ProfilesHandler) Delete(c *fiber.Ctx) error {
+	profileID, ok := parseProfileIDParam(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer >= 1"))
+	}
+
+	playerID, ok := getPlayerID(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrUnauthorized())
+	}
+
+	if svcErr := h.profileSvc.Delete(c.Context(), playerID, profileID); svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.Success(c, fiber.Map{"deleted": true})
+}
+
+// Select trades the parent's token for one scoped to the profile.
+func (h *ProfilesHandler) Select(c *fiber.Ctx) error {
+	profileID, ok := parseProfileIDParam(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer >= 1"))
+	}
+
+	playerID, ok := getPlayerID(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrUnauthorized())
+	}
+
+	dto, svcErr := h.profileSvc.Select(c.Context(), playerID, profileID)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.Success(c, dto)
+}
+
+func (h *ProfilesHandler) Controls(c *fiber.Ctx) error {
+	profileID, ok := parseProfileIDParam(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer >= 1"))
+	}
+
+	playerID, ok := getPlayerID(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrUnauthorized())
+	}
+
+	dto, svcErr := h.controlSvc.Get(c.Context(), playerID, profileID)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.Success(c, dto)
+}
+
+func (h *ProfilesHandler) SetControls(c *fiber.Ctx) error {
+	profileID, ok := parseProfileIDParam(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrBadRequest("id must be an integer >= 1"))
+	}
+
+	var req models.ProfileControlsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("invalid json body"))
+	}
+
+	playerID, ok := getPlayerID(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrUnauthorized())
+	}
+
+	dto, svcErr := h.controlSvc.Replace(c.Context(), playerID, profileID, req)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.Success(c, dto)
+}
+
+func parseProfileIDParam(c *fiber.Ctx) (int64, bool) {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id", "")), 10, 64)
+	if err != nil || id < 1 {
+		return 0, false
+	}
+	return id, true
+}
+
+// getPlayerProfileID returns the active profile of a player token, or 0 when
+// the token acts for the parent account.
+func getPlayerProfileID(c *fiber.Ctx) int64 {
+	v, _ := c.Locals(middleware.LocalPlayerProfileID).(int64)
+	return v
+}
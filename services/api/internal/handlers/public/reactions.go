@@ -0,0 +1,85 @@
+package public
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type ReactionsHandler struct {
+	reactionSvc *services.ReactionService
+}
+
+func NewReactionsHandler(reactionSvc *services.ReactionService) *ReactionsHandler {
+	return &ReactionsHandler{reactionSvc: reactionSvc}
+}
+
+func (h *ReactionsHandler) Get(c *fiber.Ctx) error {
+	id, actor, appErr := reactionTarget(c)
+	if appErr != nil {
+		return utils.Fail(c, *appErr)
+	}
+
+	dto, svcErr := h.reactionSvc.GetReaction(c.Context(), id, actor)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.Success(c, dto)
+}
+
+func (h *ReactionsHandler) Set(c *fiber.Ctx) error {
+	id, actor, appErr := reactionTarget(c)
+	if appErr != nil {
+		return utils.Fail(c, *appErr)
+	}
+
+	var req models.SetReactionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return utils.Fail(c, utils.ErrBadRequest("invalid json body"))
+	}
+
+	dto, svcErr := h.reactionSvc.SetReaction(c.Context(), id, actor, req.Reaction)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.Success(c, dto)
+}
+
+func (h *ReactionsHandler) Clear(c *fiber.Ctx) error {
+	id, actor, appErr := reactionTarget(c)
+	if appErr != nil {
+		return utils.Fail(c, *appErr)
+	}
+
+	dto, svcErr := h.reactionSvc.ClearReaction(c.Context(), id, actor)
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+	return utils.Success(c, dto)
+}
+
+func reactionTarget(c *fiber.Ctx) (int64, services.ReactionActor, *utils.AppError) {
+	id, err := strconv.ParseInt(strings.TrimSpace(c.Params("id", "")), 10, 64)
+	if err != nil || id < 1 {
+		e := utils.ErrBadRequest("id must be an integer >= 1")
+		return 0, services.ReactionActor{}, &e
+	}
+
+	tokenGameID, ok := getTokenGameID(c)
+	if !ok {
+		e := utils.ErrUnauthorized()
+		return 0, services.ReactionActor{}, &e
+	}
+
+	return id, services.ReactionActor{
+		TokenGameID: tokenGameID,
+		PlayerID:    getTokenPlayerID(c),
+		ProfileID:   getTokenProfileID(c),
+		Preview:     getTokenPreview(c),
+	}, nil
+}
@@ -0,0 +1,60 @@
+package public
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type ReportsHandler struct {
+	reportSvc *services.ReportService
+}
+
+func NewReportsHandler(reportSvc *services.ReportService) *ReportsHandler {
+	return &ReportsHandler{reportSvc: reportSvc}
+}
+
+// Weekly returns the weekly report as JSON, or with format=html as the
+// email body the weekly mail would carry.
+func (h *ReportsHandler) Weekly(c *fiber.Ctx) error {
+	var profileID int64
+	if raw := strings.TrimSpace(c.Query("profile_id", "")); raw != "" {
+		v, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || v < 1 {
+			return utils.Fail(c, utils.ErrBadRequest("profile_id must be an integer >= 1"))
+		}
+		profileID = v
+	}
+
+	format := strings.ToLower(strings.TrimSpace(c.Query("format", "json")))
+	if format != "json" && format != "html" {
+		return utils.Fail(c, utils.ErrBadRequest("format must be json or html"))
+	}
+
+	playerID, ok := getPlayerID(c)
+	if !ok {
+		return utils.Fail(c, utils.ErrUnauthorized())
+	}
+
+	dto, svcErr := h.reportSvc.Weekly(c.Context(), playerID, profileID, strings.TrimSpace(c.Query("week", "")))
+	if svcErr != nil {
+		return failFromServiceErr(c, svcErr)
+	}
+
+	if format == "html" {
+		_, body, err := services.RenderWeeklyReport(dto)
+		if err != nil {
+			return utils.Fail(c, utils.ErrInternal())
+		}
+		// Nicknames and titles are escaped; the policy keeps the page inert
+		// even so.
+		c.Set(fiber.HeaderContentSecurityPolicy, "default-src 'none'; style-src 'unsafe-inline'")
+		c.Set(fiber.HeaderContentType, fiber.MIMETextHTMLCharsetUTF8)
+		return c.SendString(body)
+	}
+	return utils.Success(c, dto)
+}
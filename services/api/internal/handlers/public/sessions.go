@@ -30,12 +30,12 @@ func (h *SessionsHandler) Start(c *fiber.Ctx) error {
 		return utils.Fail(c, utils.ErrBadRequest("game_id is required"))
 	}
 
-	sub, appErr := parseOptionalPlayerSub(c, h.cfg)
+	sub, profileID, appErr := parseOptionalPlayerSub(c, h.cfg)
 	if appErr != nil {
 		return utils.Fail(c, *appErr)
 	}
 
-	resp, appErr := h.sessionSvc.StartSession(c.Context(), req.GameID, sub)
+	resp, appErr := h.sessionSvc.StartSession(c.Context(), req.GameID, sub, profileID, strings.TrimSpace(req.PreviewToken), strings.TrimSpace(req.GuestID))
 	if appErr != nil {
 		return utils.Fail(c, *appErr)
 	}
@@ -43,32 +43,34 @@ func (h *SessionsHandler) Start(c *fiber.Ctx) error {
 	return utils.Success(c, resp)
 }
 
-func parseOptionalPlayerSub(c *fiber.Ctx, cfg config.Config) (string, *utils.AppError) {
+// parseOptionalPlayerSub returns the player and active profile (0 for none)
+// of an optional player token.
+func parseOptionalPlayerSub(c *fiber.Ctx, cfg config.Config) (string, int64, *utils.AppError) {
 	auth := strings.TrimSpace(c.Get("Authorization"))
 	if auth == "" {
-		return "", nil
+		return "", 0, nil
 	}
 
 	parts := strings.SplitN(auth, " ", 2)
 	if len(parts) != 2 || strings.ToLower(strings.TrimSpace(parts[0])) != "bearer" {
 		e := utils.ErrUnauthorized()
-		return "", &e
+		return "", 0, &e
 	}
 
 	tokenStr := strings.TrimSpace(parts[1])
 	if tokenStr == "" {
 		e := utils.ErrUnauthorized()
-		return "", &e
+		return "", 0, &e
 	}
 
 	parsed, err := utils.ParsePlayerToken(cfg.JWT, tokenStr)
 	if err != nil {
 		if appErr, ok := err.(utils.AppError); ok {
-			return "", &appErr
+			return "", 0, &appErr
 		}
 		e := utils.ErrUnauthorized()
-		return "", &e
+		return "", 0, &e
 	}
 
-	return parsed.PlayerID, nil
+	return parsed.PlayerID, parsed.ProfileID, nil
 }
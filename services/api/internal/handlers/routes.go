@@ -9,25 +9,41 @@ import (
 	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
 	admin "github.com/ZygmaCore/kids_planet/services/api/internal/handlers/admin"
 	public "github.com/ZygmaCore/kids_planet/services/api/internal/handlers/public"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/mail"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/middleware"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/services"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/storage"
 )
 
 type Deps struct {
 	Cfg    config.Config
 	DB     *sql.DB
 	Valkey *clients.Valkey
-	MinIO  *clients.MinIO
+	Store  storage.ObjectStore
+	Mailer mail.Sender
 }
 
-func Register(app *fiber.App, deps Deps) {
+// Jobs are the services cmd/api runs in the background. Register builds them
+// with the same repos, catalog cache and localizer as the handlers.
+type Jobs struct {
+	StorageGC    *services.StorageGCService
+	Popularity   *services.PopularityService
+	Related      *services.RelatedGamesService
+	GameSchedule *services.GameScheduleService
+	GameDelete   *services.GameDeleteService
+	WeeklyReport *services.WeeklyReportMailer
+}
+
+func Register(app *fiber.App, deps Deps) Jobs {
 	api := app.Group("/api")
 
 	healthHandler := NewHealthHandler(deps.Cfg)
 	api.Get("/health", healthHandler.Get)
 
-	gameRepo := repos.NewGameRepo(deps.DB)
+	gameRepo := repos.NewGameRepo(deps.DB).WithPopularityWindow(deps.Cfg.Popularity.WindowDays)
+	gameBuildRepo := repos.NewGameBuildRepo(deps.DB)
+	gameMediaRepo := repos.NewGameMediaRepo(deps.DB)
 	userRepo := repos.NewUserRepo(deps.DB)
 	submissionRepo := repos.NewSubmissionRepo(deps.DB)
 	analyticsRepo := repos.NewAnalyticsRepo(deps.DB)
@@ -38,26 +54,97 @@ func Register(app *fiber.App, deps Deps) {
 	ageCategoryRepo := repos.NewAgeCategoryRepo(deps.DB)
 	educationCategoryRepo := repos.NewEducationCategoryRepo(deps.DB)
 
+	translationRepo := repos.NewTranslationRepo(deps.DB)
+
+	catalogCache := services.NewCatalogCache(deps.Valkey, deps.Cfg.CatalogCache).WithLocales(deps.Cfg.Locale.Supported)
+	localizer := services.NewLocalizer(translationRepo, deps.Cfg.Locale)
+
 	gameSvc := services.NewGameService(
 		gameRepo,
-		deps.MinIO,
-		deps.Cfg.MinIO.Bucket,
-		deps.Cfg.Upload.ZipMaxBytes,
+		gameBuildRepo,
+		gameMediaRepo,
+		deps.Store,
+		deps.Cfg.Upload,
+		catalogCache,
+		localizer,
 	)
 
-	sessionSvc := services.NewSessionService(deps.Cfg, gameRepo, sessionRepo)
+	relatedSvc := services.NewRelatedGamesService(gameRepo, deps.Cfg.Related, catalogCache, localizer)
+	collectionSvc := services.NewCollectionService(repos.NewCollectionRepo(deps.DB), gameRepo, catalogCache, localizer)
+	gameAssetSvc := services.NewGameAssetService(gameBuildRepo, deps.Store)
+	gameScheduleSvc := services.NewGameScheduleService(gameRepo, catalogCache)
+	gameDeleteSvc := services.NewGameDeleteService(gameRepo, deps.Store, deps.Valkey, deps.Cfg.GameDelete, catalogCache)
+	gameMediaSvc := services.NewGameMediaService(gameRepo, gameMediaRepo, deps.Store, deps.Cfg.Media, catalogCache)
+
+	profileRepo := repos.NewProfileRepo(deps.DB)
+	profileControlsRepo := repos.NewProfileControlsRepo(deps.DB)
+	profileControlSvc := services.NewProfileControlService(profileRepo, profileControlsRepo, sessionRepo)
+	sessionSvc := services.NewSessionService(deps.Cfg, gameRepo, sessionRepo, profileRepo, profileControlSvc)
+	previewSvc := services.NewPreviewService(deps.Cfg, gameRepo)
 	leaderboardSvc := services.NewLeaderboardService(deps.Valkey, submissionRepo)
 
-	categorySvc := services.NewCategoryService(ageCategoryRepo, educationCategoryRepo)
-	dashboardSvc := services.NewDashboardService(dashboardRepo)
+	categorySvc := services.NewCategoryService(ageCategoryRepo, educationCategoryRepo, catalogCache, localizer)
+	translationSvc := services.NewTranslationService(translationRepo, gameRepo, deps.Cfg.Locale, catalogCache)
+	catalogSvc := services.NewCatalogService(
+		repos.NewCatalogRepo(deps.DB),
+		gameSvc,
+		categorySvc,
+		deps.Store,
+		deps.Cfg.Upload,
+	)
+	dashboardSvc := services.NewDashboardService(dashboardRepo, deps.Cfg.Popularity)
 	historySvc := services.NewHistoryService(playerHistoryRepo)
+	favoriteSvc := services.NewFavoriteService(repos.NewFavoriteRepo(deps.DB), gameRepo)
+	profileSvc := services.NewProfileService(deps.Cfg, profileRepo, userRepo)
+	reportRepo := repos.NewReportRepo(deps.DB)
+	reportSvc := services.NewReportService(reportRepo, profileRepo, profileControlsRepo)
+	reactionSvc := services.NewReactionService(repos.NewReactionRepo(deps.DB), gameRepo)
+	storageGCSvc := services.NewStorageGCService(
+		gameRepo,
+		gameBuildRepo,
+		deps.Store,
+		deps.Cfg.StorageGC,
+	)
+
+	locale := middleware.Locale(deps.Cfg.Locale)
 
-	gamesHandler := public.NewGamesHandler(gameSvc)
-	api.Get("/games", gamesHandler.List)
-	api.Get("/games/:id", gamesHandler.Get)
+	gamesHandler := public.NewGamesHandler(gameSvc, relatedSvc, deps.Cfg.CatalogCache.MaxAge)
+	api.Get("/games", locale, gamesHandler.List)
+	api.Get("/games/suggest", locale, gamesHandler.Suggest)
+	api.Get("/games/by-slug/:slug", locale, gamesHandler.GetBySlug)
+	api.Get("/games/:id", locale, gamesHandler.Get)
+	api.Get("/games/:id<int>/offline-manifest", gamesHandler.OfflineManifest)
+	api.Get("/games/:id<int>/related", locale, gamesHandler.Related)
+
+	reactionsHandler := public.NewReactionsHandler(reactionSvc)
+	api.Get("/games/:id<int>/reaction", middleware.PlayToken(deps.Cfg), reactionsHandler.Get)
+	api.Put(
+		"/games/:id<int>/reaction",
+		middleware.PlayToken(deps.Cfg),
+		middleware.RateLimitReactions(deps.Valkey),
+		reactionsHandler.Set,
+	)
+	api.Delete(
+		"/games/:id<int>/reaction",
+		middleware.PlayToken(deps.Cfg),
+		middleware.RateLimitReactions(deps.Valkey),
+		reactionsHandler.Clear,
+	)
 
-	categoriesHandler := public.NewCategoriesHandler(categorySvc)
-	api.Get("/categories", categoriesHandler.List)
+	// Same paths nginx proxies to MinIO, so game_url works when the API serves
+	// assets itself (e.g. with the local storage backend). current/ follows
+	// the game's current build.
+	assetsHandler := public.NewAssetsHandler(gameAssetSvc)
+	app.Get("/games/:id<int>/builds/:build<int>/*", assetsHandler.GetBuild)
+	app.Get("/games/:id<int>/current/*", assetsHandler.GetCurrent)
+	app.Get("/games/:id<int>/media/*", assetsHandler.GetMedia)
+
+	categoriesHandler := public.NewCategoriesHandler(categorySvc, deps.Cfg.CatalogCache.MaxAge)
+	api.Get("/categories", locale, categoriesHandler.List)
+
+	collectionsHandler := public.NewCollectionsHandler(collectionSvc, deps.Cfg.CatalogCache.MaxAge)
+	api.Get("/collections", locale, collectionsHandler.List)
+	api.Get("/collections/:slug", locale, collectionsHandler.Get)
 
 	sessionsHandler := public.NewSessionsHandler(deps.Cfg, sessionSvc)
 	api.Post("/sessions/start", sessionsHandler.Start)
@@ -83,8 +170,30 @@ func Register(app *fiber.App, deps Deps) {
 	api.Post("/auth/player/login", playerAuthHandler.Login)
 	api.Post("/auth/player/logout", playerAuthHandler.Logout)
 
+	playerAuth := middleware.AuthPlayerJWT(deps.Cfg)
+
 	historyHandler := public.NewHistoryHandler(historySvc)
-	api.Get("/player/history", middleware.AuthPlayerJWT(deps.Cfg), historyHandler.List)
+	api.Get("/player/history", playerAuth, historyHandler.List)
+	api.Get("/player/recently-played", playerAuth, historyHandler.RecentlyPlayed)
+
+	favoritesHandler := public.NewFavoritesHandler(favoriteSvc)
+	api.Get("/player/favorites", playerAuth, favoritesHandler.List)
+	api.Put("/player/favorites/:game_id<int>", playerAuth, favoritesHandler.Add)
+	api.Delete("/player/favorites/:game_id<int>", playerAuth, favoritesHandler.Remove)
+
+	// Profile management needs the parent's own token, not a profile's.
+	profilesHandler := public.NewProfilesHandler(profileSvc, profileControlSvc)
+	parentOnly := middleware.RequireParentAccount()
+	api.Get("/player/profiles", playerAuth, parentOnly, profilesHandler.List)
+	api.Post("/player/profiles", playerAuth, parentOnly, profilesHandler.Create)
+	api.Put("/player/profiles/:id<int>", playerAuth, parentOnly, profilesHandler.Update)
+	api.Delete("/player/profiles/:id<int>", playerAuth, parentOnly, profilesHandler.Delete)
+	api.Post("/player/profiles/:id<int>/select", playerAuth, parentOnly, profilesHandler.Select)
+	api.Get("/player/profiles/:id<int>/controls", playerAuth, parentOnly, profilesHandler.Controls)
+	api.Put("/player/profiles/:id<int>/controls", playerAuth, parentOnly, profilesHandler.SetControls)
+
+	reportsHandler := public.NewReportsHandler(reportSvc)
+	api.Get("/player/reports/weekly", playerAuth, parentOnly, reportsHandler.Weekly)
 
 	adminGroup := api.Group(
 		"/admin",
@@ -101,14 +210,37 @@ func Register(app *fiber.App, deps Deps) {
 	adminDashboard := admin.NewDashboardHandler(dashboardSvc)
 	adminGroup.Get("/dashboard/overview", adminDashboard.Overview)
 
-	adminGames := admin.NewGamesHandler(gameSvc)
+	adminGames := admin.NewGamesHandler(gameSvc, gameDeleteSvc)
 	adminGroup.Get("/games", adminGames.List)
 	adminGroup.Post("/games", adminGames.Create)
 	adminGroup.Put("/games/:id<int>", adminGames.Update)
+	adminGroup.Delete("/games/:id<int>", adminGames.Delete)
+	adminGroup.Post("/games/:id<int>/restore", adminGames.Restore)
 	adminGroup.Post("/games/:id<int>/publish", adminGames.Publish)
 	adminGroup.Post("/games/:id<int>/unpublish", adminGames.Unpublish)
 	adminGroup.Post("/games/:id<int>/upload", adminGames.Upload)
 
+	adminPreview := admin.NewPreviewHandler(previewSvc)
+	adminGroup.Post("/games/:id<int>/preview-token", adminPreview.IssueToken)
+
+	adminSchedules := admin.NewSchedulesHandler(gameScheduleSvc)
+	adminGroup.Get("/games/schedules", adminSchedules.List)
+	adminGroup.Get("/games/:id<int>/schedule", adminSchedules.Get)
+	adminGroup.Put("/games/:id<int>/schedule", adminSchedules.Set)
+	adminGroup.Delete("/games/:id<int>/schedule", adminSchedules.Cancel)
+
+	adminMedia := admin.NewMediaHandler(gameMediaSvc)
+	adminGroup.Post("/games/:id<int>/thumbnail", adminMedia.UploadThumbnail)
+	adminGroup.Post("/games/:id<int>/screenshots", adminMedia.UploadScreenshot)
+	adminGroup.Delete("/games/:id<int>/screenshots/:media_id<int>", adminMedia.DeleteScreenshot)
+
+	adminStorage := admin.NewStorageHandler(storageGCSvc)
+	adminGroup.Post("/storage/gc", adminStorage.GC)
+
+	adminCatalog := admin.NewCatalogHandler(catalogSvc)
+	adminGroup.Get("/catalog/export", adminCatalog.Export)
+	adminGroup.Post("/catalog/import", adminCatalog.Import)
+
 	adminCategories := admin.NewCategoriesHandler(categorySvc)
 
 	adminGroup.Get("/age-categories", adminCategories.ListAge)
@@ -121,4 +253,31 @@ func Register(app *fiber.App, deps Deps) {
 	adminGroup.Put("/education-categories/:id<int>", adminCategories.UpdateEducation)
 	adminGroup.Delete("/education-categories/:id<int>", adminCategories.DeleteEducation)
 
+	adminCollections := admin.NewCollectionsHandler(collectionSvc)
+	adminGroup.Get("/collections", adminCollections.List)
+	adminGroup.Post("/collections", adminCollections.Create)
+	adminGroup.Get("/collections/:id<int>", adminCollections.Get)
+	adminGroup.Put("/collections/:id<int>", adminCollections.Update)
+	adminGroup.Delete("/collections/:id<int>", adminCollections.Delete)
+	adminGroup.Put("/collections/:id<int>/games", adminCollections.SetGames)
+
+	adminTranslations := admin.NewTranslationsHandler(translationSvc)
+	adminGroup.Get("/games/:id<int>/translations", adminTranslations.ListGame)
+	adminGroup.Put("/games/:id<int>/translations/:locale", adminTranslations.UpsertGame)
+	adminGroup.Delete("/games/:id<int>/translations/:locale", adminTranslations.DeleteGame)
+	adminGroup.Get("/age-categories/:id<int>/translations", adminTranslations.ListCategory(repos.CategoryKindAge))
+	adminGroup.Put("/age-categories/:id<int>/translations/:locale", adminTranslations.UpsertCategory(repos.CategoryKindAge))
+	adminGroup.Delete("/age-categories/:id<int>/translations/:locale", adminTranslations.DeleteCategory(repos.CategoryKindAge))
+	adminGroup.Get("/education-categories/:id<int>/translations", adminTranslations.ListCategory(repos.CategoryKindEducation))
+	adminGroup.Put("/education-categories/:id<int>/translations/:locale", adminTranslations.UpsertCategory(repos.CategoryKindEducation))
+	adminGroup.Delete("/education-categories/:id<int>/translations/:locale", adminTranslations.DeleteCategory(repos.CategoryKindEducation))
+
+	return Jobs{
+		StorageGC:    storageGCSvc,
+		Popularity:   services.NewPopularityService(repos.NewPopularityRepo(deps.DB), deps.Cfg.Popularity),
+		Related:      relatedSvc,
+		GameSchedule: gameScheduleSvc,
+		GameDelete:   gameDeleteSvc,
+		WeeklyReport: services.NewWeeklyReportMailer(reportSvc, reportRepo, deps.Mailer),
+	}
 }
@@ -0,0 +1,34 @@
+package jobs
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// RunEvery calls fn on every tick until ctx is cancelled. A non-positive
+// interval disables the job.
+func RunEvery(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("level=info job=%s interval=%s msg=%q", name, interval, "job scheduled")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			start := time.Now()
+			if err := fn(ctx); err != nil {
+				log.Printf("level=error job=%s duration_ms=%d err=%v", name, time.Since(start).Milliseconds(), err)
+				continue
+			}
+			log.Printf("level=info job=%s duration_ms=%d msg=%q", name, time.Since(start).Milliseconds(), "job finished")
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// FileSender writes each message as an .eml file in dir, which any mail
+// client or browser-based viewer can open.
+type FileSender struct {
+	dir  string
+	from string
+	seq  atomic.Uint64
+}
+
+func NewFileSender(dir, from string) (*FileSender, error) {
+	dir = strings.TrimSpace(dir)
+	if dir == "" {
+		return nil, fmt.Errorf("mail dir is empty")
+	}
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("mail dir: %w", err)
+	}
+	if err := os.MkdirAll(abs, 0o755); err != nil {
+		return nil, fmt.Errorf("mail dir mkdir: %w", err)
+	}
+	return &FileSender{dir: abs, from: from}, nil
+}
+
+func (s *FileSender) Sink() string {
+	return "file"
+}
+
+func (s *FileSender) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	raw, err := build(s.from, msg, now)
+	if err != nil {
+		return err
+	}
+
+	// Timestamp first so a directory listing reads in send order; the
+	// sequence keeps messages sent in the same nanosecond apart.
+	name := fmt.Sprintf("%s-%06d.eml", now.Format("20060102T150405.000000000Z"), s.seq.Add(1))
+	if err := os.WriteFile(filepath.Join(s.dir, name), raw, 0o644); err != nil {
+		return fmt.Errorf("mail write: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package mail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+)
+
+var ErrInvalidRecipient = errors.New("mail: invalid recipient")
+
+// Message is a single HTML email to one recipient.
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	// Ref names the message in logs without personal data, such as a
+	// delivery id. It is not sent.
+	Ref string
+}
+
+// Sender delivers messages. Implementations are safe for concurrent use.
+type Sender interface {
+	Send(ctx context.Context, msg Message) error
+	Sink() string
+}
+
+func New(cfg config.MailConfig) (Sender, error) {
+	switch cfg.Sink {
+	case config.MailSinkLog:
+		return NewLogSender(), nil
+	case config.MailSinkFile:
+		return NewFileSender(cfg.Dir, cfg.From)
+	case config.MailSinkSMTP:
+		return NewSMTPSender(cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From)
+	default:
+		return nil, fmt.Errorf("unknown mail sink %q", cfg.Sink)
+	}
+}
+
+// recipient returns the bare address of msg.To, rejecting anything that
+// could smuggle extra headers or recipients.
+func recipient(msg Message) (string, error) {
+	if strings.ContainsAny(msg.To, "\r\n") {
+		return "", ErrInvalidRecipient
+	}
+	addr, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return "", ErrInvalidRecipient
+	}
+	return addr.Address, nil
+}
+
+// build renders msg as an RFC 5322 message with a quoted-printable HTML
+// body, the form both the file and SMTP senders emit.
+func build(from string, msg Message, now time.Time) ([]byte, error) {
+	to, err := recipient(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", to)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", now.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+	buf.WriteString("\r\n")
+
+	qp := quotedprintable.NewWriter(&buf)
+	if _, err := qp.Write([]byte(msg.HTML)); err != nil {
+		return nil, fmt.Errorf("mail: encode body: %w", err)
+	}
+	if err := qp.Close(); err != nil {
+		return nil, fmt.Errorf("mail: encode body: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+)
+
+const LocalLocale = "locale"
+
+// Locale resolves the response language from ?lang=, then Accept-Language,
+// then the configured default. Unsupported values fall through rather than
+// failing the request.
+func Locale(localeCfg config.LocaleConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Vary(fiber.HeaderAcceptLanguage)
+		c.Locals(LocalLocale, ResolveLocale(localeCfg, c.Query("lang"), c.Get(fiber.HeaderAcceptLanguage)))
+		return c.Next()
+	}
+}
+
+func ResolveLocale(localeCfg config.LocaleConfig, lang, acceptLanguage string) string {
+	if l := primaryTag(lang); localeCfg.IsSupported(l) {
+		return l
+	}
+	for _, l := range parseAcceptLanguage(acceptLanguage) {
+		if localeCfg.IsSupported(l) {
+			return l
+		}
+	}
+	return localeCfg.Default
+}
+
+// LocalLocaleOr returns the locale set by Locale, or def when the route is
+// not behind it.
+func LocalLocaleOr(c *fiber.Ctx, def string) string {
+	if l, ok := c.Locals(LocalLocale).(string); ok && l != "" {
+		return l
+	}
+	return def
+}
+
+func primaryTag(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+// parseAcceptLanguage returns primary language tags ordered by q-value,
+// keeping header order for ties and dropping q=0.
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := primaryTag(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		q := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if v, ok := strings.CutPrefix(f, "q="); ok {
+				if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: tag, q: q})
+	}
+	sort.SliceStable(tags, func(i, j int) bool { return tags[i].q > tags[j].q })
+
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, t.tag)
+	}
+	return out
+}
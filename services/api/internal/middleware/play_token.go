@@ -17,12 +17,16 @@ const (
 	LocalPlayExp       = "play_exp"
 	LocalPlaySessionID = "play_session_id"
 	LocalPlaySubject   = "play_sub"
+	LocalPlayPreview   = "play_preview"
+	LocalPlayProfileID = "play_profile_id"
 )
 
 type PlayClaims struct {
 	GameID    int64  `json:"game_id"`
 	SessionID string `json:"session_id"`
 	Typ       string `json:"typ"`
+	Preview   bool   `json:"preview,omitempty"`
+	ProfileID int64  `json:"profile_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
@@ -81,6 +85,8 @@ func PlayToken(cfg config.Config) fiber.Handler {
 		c.Locals(LocalPlayExp, exp)
 		c.Locals(LocalPlaySessionID, strings.TrimSpace(claims.SessionID))
 		c.Locals(LocalPlaySubject, strings.TrimSpace(claims.Subject))
+		c.Locals(LocalPlayPreview, claims.Preview)
+		c.Locals(LocalPlayProfileID, claims.ProfileID)
 
 		return c.Next()
 	}
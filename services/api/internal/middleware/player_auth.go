@@ -9,7 +9,10 @@ import (
 	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
 )
 
-const LocalPlayerID = "player_id"
+const (
+	LocalPlayerID        = "player_id"
+	LocalPlayerProfileID = "player_profile_id"
+)
 
 func AuthPlayerJWT(cfg config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -41,6 +44,19 @@ func AuthPlayerJWT(cfg config.Config) fiber.Handler {
 		}
 
 		c.Locals(LocalPlayerID, parsed.PlayerID)
+		c.Locals(LocalPlayerProfileID, parsed.ProfileID)
+		return c.Next()
+	}
+}
+
+// RequireParentAccount rejects player tokens scoped to a child profile, so
+// only the parent can manage profiles.
+func RequireParentAccount() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		profileID, _ := c.Locals(LocalPlayerProfileID).(int64)
+		if profileID != 0 {
+			return utils.Fail(c, utils.ErrForbidden())
+		}
 		return c.Next()
 	}
 }
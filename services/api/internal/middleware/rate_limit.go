@@ -15,25 +15,54 @@ import (
 const (
 	leaderboardSubmitRateLimit  = 30
 	leaderboardSubmitRateWindow = time.Minute
+
+	reactionRateLimit  = 20
+	reactionRateWindow = time.Minute
+
+	reactionIPRateLimit  = 60
+	reactionIPRateWindow = time.Minute
 )
 
 func RateLimitLeaderboardSubmit(valkey *clients.Valkey) fiber.Handler {
+	return playRateLimit(valkey, "leaderboard:submit", leaderboardSubmitRateLimit, leaderboardSubmitRateWindow)
+}
+
+// RateLimitReactions caps reaction changes per play session and per client
+// IP, since a player can start new sessions to get a fresh session budget.
+func RateLimitReactions(valkey *clients.Valkey) fiber.Handler {
+	perSession := playRateLimit(valkey, "reactions", reactionRateLimit, reactionRateWindow)
+	return func(c *fiber.Ctx) error {
+		if valkey != nil {
+			if ip := strings.TrimSpace(c.IP()); ip != "" {
+				count, err := valkey.IncrWithTTL(c.Context(), "rl:reactions:ip:"+hashString(ip), reactionIPRateWindow)
+				if err == nil && count > int64(reactionIPRateLimit) {
+					return utils.Fail(c, utils.ErrRateLimited("rate limit exceeded"))
+				}
+			}
+		}
+		return perSession(c)
+	}
+}
+
+// playRateLimit counts requests per play session (falling back to the bearer
+// token, then the client IP) in fixed windows. Valkey errors fail open.
+func playRateLimit(valkey *clients.Valkey, scope string, limit int, window time.Duration) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		if valkey == nil {
 			return c.Next()
 		}
 
-		key := leaderboardSubmitRateKey(c)
+		key := playRateKey(c, scope)
 		if key == "" {
 			return c.Next()
 		}
 
-		count, err := valkey.IncrWithTTL(c.Context(), key, leaderboardSubmitRateWindow)
+		count, err := valkey.IncrWithTTL(c.Context(), key, window)
 		if err != nil {
 			return c.Next()
 		}
 
-		if count > int64(leaderboardSubmitRateLimit) {
+		if count > int64(limit) {
 			return utils.Fail(c, utils.ErrRateLimited("rate limit exceeded"))
 		}
 
@@ -41,20 +70,20 @@ func RateLimitLeaderboardSubmit(valkey *clients.Valkey) fiber.Handler {
 	}
 }
 
-func leaderboardSubmitRateKey(c *fiber.Ctx) string {
+func playRateKey(c *fiber.Ctx, scope string) string {
 	sessionID := strings.TrimSpace(localString(c, LocalPlaySessionID))
 	if sessionID != "" {
-		return "rl:leaderboard:submit:session:" + sessionID
+		return "rl:" + scope + ":session:" + sessionID
 	}
 
 	token := strings.TrimSpace(bearerToken(c.Get("Authorization")))
 	if token != "" {
-		return "rl:leaderboard:submit:token:" + hashString(token)
+		return "rl:" + scope + ":token:" + hashString(token)
 	}
 
 	ip := strings.TrimSpace(c.IP())
 	if ip != "" {
-		return "rl:leaderboard:submit:ip:" + hashString(ip)
+		return "rl:" + scope + ":ip:" + hashString(ip)
 	}
 
 	return ""
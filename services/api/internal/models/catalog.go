@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+const CatalogFormatVersion = 1
+
+// CatalogManifest is catalog.json inside an export archive. Relations use
+// natural keys (age category label, education category name, game slug) so
+// an archive can be imported into a database with different ids.
+type CatalogManifest struct {
+	Version             int                        `json:"version"`
+	ExportedAt          time.Time                  `json:"exported_at"`
+	AgeCategories       []CatalogAgeCategory       `json:"age_categories"`
+	EducationCategories []CatalogEducationCategory `json:"education_categories"`
+	Games               []CatalogGame              `json:"games"`
+}
+
+type CatalogAgeCategory struct {
+	Label  string `json:"label"`
+	MinAge int    `json:"min_age"`
+	MaxAge int    `json:"max_age"`
+}
+
+type CatalogEducationCategory struct {
+	Name  string  `json:"name"`
+	Icon  *string `json:"icon,omitempty"`
+	Color *string `json:"color,omitempty"`
+}
+
+type CatalogGame struct {
+	Slug                string     `json:"slug"`
+	Title               string     `json:"title"`
+	Description         string     `json:"description,omitempty"`
+	Difficulty          string     `json:"difficulty,omitempty"`
+	Free                bool       `json:"free"`
+	Status              GameStatus `json:"status"`
+	AgeCategory         string     `json:"age_category"`
+	EducationCategories []string   `json:"education_categories"`
+	// Build is the archive path of the game's current upload ZIP, set only
+	// when builds were exported.
+	Build string `json:"build,omitempty"`
+}
+
+const (
+	CatalogItemAgeCategory       = "age_category"
+	CatalogItemEducationCategory = "education_category"
+	CatalogItemGame              = "game"
+
+	CatalogActionCreate = "create"
+	CatalogActionUpdate = "update"
+	CatalogActionSkip   = "skip"
+	CatalogActionError  = "error"
+)
+
+type CatalogImportItem struct {
+	Kind   string `json:"kind"`
+	Key    string `json:"key"`
+	Action string `json:"action"`
+	ID     int64  `json:"id,omitempty"`
+	// Build is "uploaded" (or "would_upload" on a dry run) when the archive
+	// carried a build for the game, or the upload error.
+	Build     string `json:"build,omitempty"`
+	Published bool   `json:"published,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+type CatalogImportReport struct {
+	DryRun     bool                `json:"dry_run"`
+	OnConflict string              `json:"on_conflict"`
+	Created    int                 `json:"created"`
+	Updated    int                 `json:"updated"`
+	Skipped    int                 `json:"skipped"`
+	Failed     int                 `json:"failed"`
+	Items      []CatalogImportItem `json:"items"`
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// CollectionRequest replaces every editable field of a collection. Active
+// defaults to true; a null or missing time leaves that side of the window
+// open.
+type CollectionRequest struct {
+	Slug         string     `json:"slug"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	HeroImageURL string     `json:"hero_image_url"`
+	Position     int        `json:"position"`
+	Active       *bool      `json:"active"`
+	StartsAt     *time.Time `json:"starts_at"`
+	EndsAt       *time.Time `json:"ends_at"`
+}
+
+// SetCollectionGamesRequest lists the member games in shelf order.
+type SetCollectionGamesRequest struct {
+	GameIDs []int64 `json:"game_ids"`
+}
+
+type CollectionGameDTO struct {
+	GameID   int64      `json:"game_id"`
+	Title    string     `json:"title"`
+	Slug     string     `json:"slug"`
+	Status   GameStatus `json:"status"`
+	Position int        `json:"position"`
+}
+
+type AdminCollectionDTO struct {
+	ID           int64               `json:"id"`
+	Slug         string              `json:"slug"`
+	Title        string              `json:"title"`
+	Description  *string             `json:"description"`
+	HeroImageURL *string             `json:"hero_image_url"`
+	Position     int                 `json:"position"`
+	Active       bool                `json:"active"`
+	StartsAt     *time.Time          `json:"starts_at"`
+	EndsAt       *time.Time          `json:"ends_at"`
+	Live         bool                `json:"live"`
+	GameCount    int                 `json:"game_count"`
+	Games        []CollectionGameDTO `json:"games,omitempty"`
+	CreatedAt    time.Time           `json:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at"`
+}
@@ -0,0 +1,19 @@
+package models
+
+type PlayerFavoriteItem struct {
+	GameID      int64   `json:"game_id"`
+	Title       string  `json:"title"`
+	Slug        string  `json:"slug"`
+	Thumbnail   *string `json:"thumbnail"`
+	FavoritedAt string  `json:"favorited_at"`
+}
+
+type PlayerFavoritesResponse struct {
+	Data       []PlayerFavoriteItem    `json:"data"`
+	Pagination PlayerHistoryPagination `json:"pagination"`
+}
+
+type PlayerFavoriteStatus struct {
+	GameID    int64 `json:"game_id"`
+	Favorited bool  `json:"favorited"`
+}
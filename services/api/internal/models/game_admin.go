@@ -10,6 +10,14 @@ const (
 	GameStatusArchived GameStatus = "archived"
 )
 
+type GameDifficulty string
+
+const (
+	GameDifficultyEasy   GameDifficulty = "easy"
+	GameDifficultyMedium GameDifficulty = "medium"
+	GameDifficultyHard   GameDifficulty = "hard"
+)
+
 type AdminEducationCategoryDTO struct {
 	ID   int64  `json:"id"`
 	Name string `json:"name"`
@@ -20,12 +28,15 @@ type AdminGameDTO struct {
 	Title                string                      `json:"title"`
 	Slug                 string                      `json:"slug"`
 	Status               GameStatus                  `json:"status"`
+	Description          string                      `json:"description,omitempty"`
+	Difficulty           GameDifficulty              `json:"difficulty,omitempty"`
 	Thumbnail            string                      `json:"thumbnail,omitempty"`
 	GameURL              string                      `json:"game_url,omitempty"`
 	AgeCategoryID        int64                       `json:"age_category_id"`
 	EducationCategoryIDs []int64                     `json:"education_category_ids,omitempty"`
 	EducationCategories  []AdminEducationCategoryDTO `json:"education_categories,omitempty"`
 	Free                 bool                        `json:"free"`
+	FavoriteCount        int                         `json:"favorite_count"`
 	CreatedAt            time.Time                   `json:"created_at"`
 	UpdatedAt            time.Time                   `json:"updated_at"`
 }
@@ -33,6 +44,8 @@ type AdminGameDTO struct {
 type CreateGameRequest struct {
 	Title                string  `json:"title"`
 	Slug                 string  `json:"slug"`
+	Description          string  `json:"description,omitempty"`
+	Difficulty           string  `json:"difficulty,omitempty"`
 	Thumbnail            string  `json:"thumbnail,omitempty"`
 	GameURL              string  `json:"game_url,omitempty"`
 	AgeCategoryID        int64   `json:"age_category_id"`
@@ -43,6 +56,8 @@ type CreateGameRequest struct {
 type UpdateGameRequest struct {
 	Title                *string  `json:"title,omitempty"`
 	Slug                 *string  `json:"slug,omitempty"`
+	Description          *string  `json:"description,omitempty"`
+	Difficulty           *string  `json:"difficulty,omitempty"`
 	Thumbnail            *string  `json:"thumbnail,omitempty"`
 	GameURL              *string  `json:"game_url,omitempty"`
 	AgeCategoryID        *int64   `json:"age_category_id,omitempty"`
@@ -53,3 +68,48 @@ type UpdateGameRequest struct {
 type SetGameStatusRequest struct {
 	Status GameStatus `json:"status"`
 }
+
+// SetGameScheduleRequest replaces the whole schedule; a null or missing time
+// cancels that side.
+type SetGameScheduleRequest struct {
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+}
+
+type GameScheduleDTO struct {
+	GameID      int64      `json:"game_id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Status      GameStatus `json:"status"`
+	PublishAt   *time.Time `json:"publish_at"`
+	UnpublishAt *time.Time `json:"unpublish_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type PreviewTokenResponse struct {
+	GameID       int64     `json:"game_id"`
+	PreviewToken string    `json:"preview_token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+type GameDeletionDTO struct {
+	GameID     int64      `json:"game_id"`
+	Title      string     `json:"title"`
+	Slug       string     `json:"slug"`
+	Status     GameStatus `json:"status"`
+	DeletedAt  *time.Time `json:"deleted_at"`
+	PurgeAfter *time.Time `json:"purge_after"`
+}
+
+// GameDeleteReport describes what a hard delete removed. Rows is keyed by
+// table name.
+type GameDeleteReport struct {
+	GameID          int64            `json:"game_id"`
+	Slug            string           `json:"slug"`
+	Rows            map[string]int64 `json:"rows"`
+	LeaderboardKeys int64            `json:"leaderboard_keys"`
+	StorageObjects  int              `json:"storage_objects"`
+	StorageBytes    int64            `json:"storage_bytes"`
+	DeletedAt       time.Time        `json:"deleted_at"`
+	Notes           []string         `json:"notes,omitempty"`
+}
@@ -18,3 +18,18 @@ type PlayerHistoryResponse struct {
 	Data       []PlayerHistoryItem     `json:"data"`
 	Pagination PlayerHistoryPagination `json:"pagination"`
 }
+
+// PlayerRecentGameItem is one game in the "play again" list; Sessions counts
+// the player's sessions of it.
+type PlayerRecentGameItem struct {
+	GameID       int64   `json:"game_id"`
+	Title        string  `json:"title"`
+	Slug         string  `json:"slug"`
+	Thumbnail    *string `json:"thumbnail"`
+	LastPlayedAt string  `json:"last_played_at"`
+	Sessions     int     `json:"sessions"`
+}
+
+type PlayerRecentGamesResponse struct {
+	Data []PlayerRecentGameItem `json:"data"`
+}
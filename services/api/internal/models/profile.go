@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// ProfileAvatars are the avatar keys a child profile may pick. Avatars are
+// drawn by the web app; uploads are not accepted for children.
+var ProfileAvatars = []string{
+	"bear", "cat", "dog", "fox", "lion", "owl", "panda", "penguin", "rabbit", "robot", "rocket", "star",
+}
+
+// ProfileRequest replaces every editable field of a child profile. At least
+// one of birth_year and age_category_id is required.
+type ProfileRequest struct {
+	Nickname      string `json:"nickname"`
+	BirthYear     *int   `json:"birth_year"`
+	AgeCategoryID *int64 `json:"age_category_id"`
+	Avatar        string `json:"avatar"`
+}
+
+type ProfileDTO struct {
+	ID            int64     `json:"id"`
+	Nickname      string    `json:"nickname"`
+	BirthYear     *int64    `json:"birth_year"`
+	AgeCategoryID *int64    `json:"age_category_id"`
+	AgeLabel      *string   `json:"age_label"`
+	Avatar        string    `json:"avatar"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ProfileSessionResponse is a player token scoped to the selected profile.
+type ProfileSessionResponse struct {
+	Token     string     `json:"token"`
+	ExpiresIn int64      `json:"expires_in"`
+	Profile   ProfileDTO `json:"profile"`
+}
+
+// QuietHours are "HH:MM" local times; an end before the start wraps past
+// midnight.
+type QuietHours struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// ProfileControlsRequest replaces every parental control of a profile.
+// Empty lists and null limits mean no restriction.
+type ProfileControlsRequest struct {
+	AllowedAgeCategoryIDs       []int64     `json:"allowed_age_category_ids"`
+	AllowedEducationCategoryIDs []int64     `json:"allowed_education_category_ids"`
+	BlockedGameIDs              []int64     `json:"blocked_game_ids"`
+	DailyMinutes                *int        `json:"daily_minutes"`
+	QuietHours                  *QuietHours `json:"quiet_hours"`
+	TimeZone                    string      `json:"time_zone"`
+}
+
+type ProfileControlsDTO struct {
+	ProfileID                   int64       `json:"profile_id"`
+	AllowedAgeCategoryIDs       []int64     `json:"allowed_age_category_ids"`
+	AllowedEducationCategoryIDs []int64     `json:"allowed_education_category_ids"`
+	BlockedGameIDs              []int64     `json:"blocked_game_ids"`
+	DailyMinutes                *int        `json:"daily_minutes"`
+	QuietHours                  *QuietHours `json:"quiet_hours"`
+	TimeZone                    string      `json:"time_zone"`
+	UsedMinutesToday            int         `json:"used_minutes_today"`
+	UpdatedAt                   *time.Time  `json:"updated_at"`
+}
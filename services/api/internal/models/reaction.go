@@ -0,0 +1,13 @@
+package models
+
+type GameReaction string
+
+const (
+	GameReactionLove GameReaction = "love"
+	GameReactionLike GameReaction = "like"
+	GameReactionMeh  GameReaction = "meh"
+)
+
+type SetReactionRequest struct {
+	Reaction GameReaction `json:"reaction"`
+}
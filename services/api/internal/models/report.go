@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// WeeklyReportDTO summarises one Monday-to-Sunday week of play for a whole
+// account, or for one child profile when Profile is set. Dates are local to
+// TimeZone.
+type WeeklyReportDTO struct {
+	WeekStart         string                 `json:"week_start"`
+	WeekEnd           string                 `json:"week_end"`
+	TimeZone          string                 `json:"time_zone"`
+	Complete          bool                   `json:"complete"`
+	Profile           *WeeklyReportProfile   `json:"profile"`
+	TotalMinutes      int                    `json:"total_minutes"`
+	SessionCount      int                    `json:"session_count"`
+	DaysPlayed        int                    `json:"days_played"`
+	StreakDays        int                    `json:"streak_days"`
+	LongestStreakDays int                    `json:"longest_streak_days"`
+	Categories        []WeeklyReportCategory `json:"categories"`
+	Games             []WeeklyReportGame     `json:"games"`
+	GeneratedAt       time.Time              `json:"generated_at"`
+}
+
+type WeeklyReportProfile struct {
+	ID       int64  `json:"id"`
+	Nickname string `json:"nickname"`
+	Avatar   string `json:"avatar"`
+}
+
+// WeeklyReportCategory is time spent on games of one education category. A
+// game in several categories counts toward each; ID is null for games with
+// none.
+type WeeklyReportCategory struct {
+	ID      *int64 `json:"id"`
+	Name    string `json:"name"`
+	Minutes int    `json:"minutes"`
+}
+
+type WeeklyReportGame struct {
+	GameID    int64  `json:"game_id"`
+	Title     string `json:"title"`
+	Sessions  int    `json:"sessions"`
+	Minutes   int    `json:"minutes"`
+	BestScore *int64 `json:"best_score"`
+}
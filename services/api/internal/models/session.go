@@ -3,10 +3,13 @@ package models
 import "time"
 
 type StartSessionRequest struct {
-	GameID int64 `json:"game_id"`
+	GameID       int64  `json:"game_id"`
+	PreviewToken string `json:"preview_token,omitempty"`
+	GuestID      string `json:"guest_id,omitempty"`
 }
 
 type StartSessionResponse struct {
 	PlayToken string    `json:"play_token"`
 	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	Preview   bool      `json:"preview,omitempty"`
 }
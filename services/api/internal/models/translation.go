@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+type UpsertGameTranslationRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type GameTranslationDTO struct {
+	GameID      int64     `json:"game_id"`
+	Locale      string    `json:"locale"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// UpsertCategoryTranslationRequest carries the translated age label or
+// education category name.
+type UpsertCategoryTranslationRequest struct {
+	Text string `json:"text"`
+}
+
+type CategoryTranslationDTO struct {
+	CategoryID int64     `json:"category_id"`
+	Locale     string    `json:"locale"`
+	Text       string    `json:"text"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -49,6 +49,14 @@ type AgeCategoryListFilter struct {
 	Q     sql.NullString
 	Page  int
 	Limit int
+	// After switches to keyset pagination; Page is ignored when set.
+	After *AgeCategoryCursor
+}
+
+type AgeCategoryCursor struct {
+	MinAge int
+	MaxAge int
+	ID     int64
 }
 
 func (f *AgeCategoryListFilter) normalize() {
@@ -63,10 +71,11 @@ func (f *AgeCategoryListFilter) normalize() {
 	}
 }
 
-func (r *AgeCategoryRepo) List(ctx context.Context, q string, page, limit int) ([]AgeCategory, error) {
+func (r *AgeCategoryRepo) List(ctx context.Context, q string, page, limit int, after *AgeCategoryCursor) ([]AgeCategory, error) {
 	filter := AgeCategoryListFilter{
 		Page:  page,
 		Limit: limit,
+		After: after,
 	}
 	if q != "" {
 		filter.Q = sql.NullString{String: q, Valid: true}
@@ -75,14 +84,23 @@ func (r *AgeCategoryRepo) List(ctx context.Context, q string, page, limit int) (
 
 	offset := (filter.Page - 1) * filter.Limit
 
+	var afterMin, afterMax, afterID sql.NullInt64
+	if filter.After != nil {
+		offset = 0
+		afterMin = sql.NullInt64{Int64: int64(filter.After.MinAge), Valid: true}
+		afterMax = sql.NullInt64{Int64: int64(filter.After.MaxAge), Valid: true}
+		afterID = sql.NullInt64{Int64: filter.After.ID, Valid: true}
+	}
+
 	const query = `
 SELECT id, label, min_age, max_age, created_at
 FROM age_categories ac
 WHERE ($1::text IS NULL OR ac.label ILIKE '%'||$1||'%')
+  AND ($4::int IS NULL OR (ac.min_age, ac.max_age, ac.id) > ($4::int, $5::int, $6::bigint))
 ORDER BY ac.min_age ASC, ac.max_age ASC, ac.id ASC
 LIMIT $2 OFFSET $3;
 `
-	rows, err := r.db.QueryContext(ctx, query, filter.Q, filter.Limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, filter.Q, filter.Limit, offset, afterMin, afterMax, afterID)
 	if err != nil {
 		return nil, fmt.Errorf("age_categories.list: %w", err)
 	}
@@ -23,12 +23,34 @@ func (r *AnalyticsRepo) InsertAnalyticsEvent(
 	eventData *string,
 	ip string,
 	userAgent string,
+	preview bool,
 ) error {
+	// game_start also bumps the daily play rollup in the same statement;
+	// preview plays are kept out of popularity. The first game_end records
+	// when the session ended, which stops it counting against play budgets.
 	const q = `
-INSERT INTO analytics_events
-  (session_id, game_id, event_name, event_data, ip, user_agent)
-VALUES
-  ($1, $2, $3, $4, $5, $6);
+WITH ev AS (
+  INSERT INTO analytics_events
+    (session_id, game_id, event_name, event_data, ip, user_agent, preview)
+  VALUES
+    ($1, $2, $3, $4, $5, $6, $7)
+  RETURNING session_id, game_id, event_name, created_at, preview
+),
+ended AS (
+  UPDATE sessions s
+  SET ended_at = ev.created_at
+  FROM ev
+  WHERE s.id = ev.session_id
+    AND ev.event_name = 'game_end'
+    AND s.ended_at IS NULL
+)
+INSERT INTO game_play_daily (game_id, day, plays)
+SELECT ev.game_id, (ev.created_at AT TIME ZONE 'UTC')::date, 1
+FROM ev
+WHERE ev.event_name = 'game_start'
+  AND NOT ev.preview
+ON CONFLICT (game_id, day) DO UPDATE
+SET plays = game_play_daily.plays + 1;
 `
 
 	dataVal := nullString(eventData)
@@ -42,6 +64,7 @@ VALUES
 		dataVal,
 		ipVal,
 		uaVal,
+		preview,
 	); err != nil {
 		return fmt.Errorf("analytics_events.insert: %w", err)
 	}
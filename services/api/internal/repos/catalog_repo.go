@@ -0,0 +1,197 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+)
+
+// CatalogRepo backs bulk export/import: whole-catalog reads and the
+// natural-key lookups (label, name, slug) used to match imported items.
+type CatalogRepo struct {
+	db *sql.DB
+}
+
+func NewCatalogRepo(db *sql.DB) *CatalogRepo {
+	return &CatalogRepo{db: db}
+}
+
+type CatalogGame struct {
+	Slug                   string
+	Title                  string
+	Description            sql.NullString
+	Difficulty             sql.NullString
+	Free                   bool
+	Status                 string
+	AgeCategoryLabel       string
+	EducationCategoryNames []string
+	BuildObjectKey         sql.NullString
+}
+
+func (r *CatalogRepo) ListAgeCategories(ctx context.Context) ([]AgeCategory, error) {
+	const q = `
+SELECT id, label, min_age, max_age, created_at
+FROM age_categories
+ORDER BY min_age ASC, max_age ASC, id ASC;`
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("catalog.age_categories: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]AgeCategory, 0)
+	for rows.Next() {
+		var it AgeCategory
+		if err := rows.Scan(&it.ID, &it.Label, &it.MinAge, &it.MaxAge, &it.CreatedAt); err != nil {
+			return nil, fmt.Errorf("catalog.age_categories.scan: %w", err)
+		}
+		out = append(out, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("catalog.age_categories.rows: %w", err)
+	}
+	return out, nil
+}
+
+func (r *CatalogRepo) ListEducationCategories(ctx context.Context) ([]EducationCategory, error) {
+	const q = `
+SELECT id, name, icon, color, created_at
+FROM education_categories
+ORDER BY name ASC, id ASC;`
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("catalog.education_categories: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]EducationCategory, 0)
+	for rows.Next() {
+		var it EducationCategory
+		if err := rows.Scan(&it.ID, &it.Name, &it.Icon, &it.Color, &it.CreatedAt); err != nil {
+			return nil, fmt.Errorf("catalog.education_categories.scan: %w", err)
+		}
+		out = append(out, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("catalog.education_categories.rows: %w", err)
+	}
+	return out, nil
+}
+
+// ListGames returns every game that is not soft-deleted, with its relations
+// resolved to natural keys and the upload ZIP of its current build.
+func (r *CatalogRepo) ListGames(ctx context.Context) ([]CatalogGame, error) {
+	const q = `
+SELECT g.slug, g.title, g.description, g.difficulty, g.free, g.status,
+       ac.label,
+       COALESCE(edu.names, '[]'::jsonb) AS education_category_names,
+       gb.upload_object_key
+FROM games g
+JOIN age_categories ac ON ac.id = g.age_category_id
+LEFT JOIN LATERAL (
+  SELECT jsonb_agg(ec.name ORDER BY ec.name) AS names
+  FROM game_education_categories gec
+  JOIN education_categories ec ON ec.id = gec.education_category_id
+  WHERE gec.game_id = g.id
+) edu ON TRUE
+LEFT JOIN game_builds gb ON gb.game_id = g.id AND gb.is_current
+WHERE g.deleted_at IS NULL
+ORDER BY g.id ASC;`
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("catalog.games: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]CatalogGame, 0)
+	for rows.Next() {
+		var (
+			it       CatalogGame
+			eduNames []byte
+		)
+		if err := rows.Scan(
+			&it.Slug,
+			&it.Title,
+			&it.Description,
+			&it.Difficulty,
+			&it.Free,
+			&it.Status,
+			&it.AgeCategoryLabel,
+			&eduNames,
+			&it.BuildObjectKey,
+		); err != nil {
+			return nil, fmt.Errorf("catalog.games.scan: %w", err)
+		}
+		if err := json.Unmarshal(eduNames, &it.EducationCategoryNames); err != nil {
+			return nil, fmt.Errorf("catalog.games.education_categories: %w", err)
+		}
+		out = append(out, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("catalog.games.rows: %w", err)
+	}
+	return out, nil
+}
+
+// CatalogGameRef is what import needs to know about an existing slug.
+type CatalogGameRef struct {
+	ID      int64
+	Deleted bool
+}
+
+func (r *CatalogRepo) AgeCategoryIDsByLabel(ctx context.Context) (map[string]int64, error) {
+	return r.idsByKey(ctx, "catalog.age_category_ids", `SELECT label, id FROM age_categories;`)
+}
+
+func (r *CatalogRepo) EducationCategoryIDsByName(ctx context.Context) (map[string]int64, error) {
+	return r.idsByKey(ctx, "catalog.education_category_ids", `SELECT name, id FROM education_categories;`)
+}
+
+func (r *CatalogRepo) GameRefsBySlug(ctx context.Context) (map[string]CatalogGameRef, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT slug, id, deleted_at IS NOT NULL FROM games;`)
+	if err != nil {
+		return nil, fmt.Errorf("catalog.game_refs: %w", err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]CatalogGameRef)
+	for rows.Next() {
+		var (
+			slug string
+			ref  CatalogGameRef
+		)
+		if err := rows.Scan(&slug, &ref.ID, &ref.Deleted); err != nil {
+			return nil, fmt.Errorf("catalog.game_refs.scan: %w", err)
+		}
+		out[slug] = ref
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("catalog.game_refs.rows: %w", err)
+	}
+	return out, nil
+}
+
+func (r *CatalogRepo) idsByKey(ctx context.Context, op, q string) (map[string]int64, error) {
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	out := make(map[string]int64)
+	for rows.Next() {
+		var (
+			key string
+			id  int64
+		)
+		if err := rows.Scan(&key, &id); err != nil {
+			return nil, fmt.Errorf("%s.scan: %w", op, err)
+		}
+		out[key] = id
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s.rows: %w", op, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,317 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Collection struct {
+	ID           int64
+	Slug         string
+	Title        string
+	Description  sql.NullString
+	HeroImageURL sql.NullString
+	Position     int
+	Active       bool
+	StartsAt     sql.NullTime
+	EndsAt       sql.NullTime
+	GameCount    int
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// CollectionGameRef is a member game as admins see it, in shelf order and
+// whatever its status.
+type CollectionGameRef struct {
+	GameID   int64
+	Title    string
+	Slug     string
+	Status   string
+	Position int
+}
+
+type CollectionRepo struct {
+	db *sql.DB
+}
+
+func NewCollectionRepo(db *sql.DB) *CollectionRepo {
+	return &CollectionRepo{db: db}
+}
+
+const collectionColumns = `
+  c.id, c.slug, c.title, c.description, c.hero_image_url, c.position, c.active,
+  c.starts_at, c.ends_at,
+  (SELECT COUNT(*) FROM collection_games cg WHERE cg.collection_id = c.id) AS game_count,
+  c.created_at, c.updated_at`
+
+func scanCollection(row interface{ Scan(...any) error }, c *Collection) error {
+	return row.Scan(
+		&c.ID, &c.Slug, &c.Title, &c.Description, &c.HeroImageURL, &c.Position, &c.Active,
+		&c.StartsAt, &c.EndsAt, &c.GameCount, &c.CreatedAt, &c.UpdatedAt,
+	)
+}
+
+// collectionLiveWhere matches collections visible to the public at $1.
+const collectionLiveWhere = `
+c.active
+  AND (c.starts_at IS NULL OR c.starts_at <= $1)
+  AND (c.ends_at IS NULL OR c.ends_at > $1)`
+
+func (r *CollectionRepo) queryCollections(ctx context.Context, op, q string, args ...any) ([]Collection, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("collections.%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	out := make([]Collection, 0)
+	for rows.Next() {
+		var c Collection
+		if err := scanCollection(rows, &c); err != nil {
+			return nil, fmt.Errorf("collections.%s.scan: %w", op, err)
+		}
+		out = append(out, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("collections.%s.rows: %w", op, err)
+	}
+	return out, nil
+}
+
+func (r *CollectionRepo) List(ctx context.Context) ([]Collection, error) {
+	q := `SELECT` + collectionColumns + `
+FROM collections c
+ORDER BY c.position ASC, c.id ASC;`
+	return r.queryCollections(ctx, "list", q)
+}
+
+// ListLive returns the collections visible at now, in shelf order.
+func (r *CollectionRepo) ListLive(ctx context.Context, now time.Time) ([]Collection, error) {
+	q := `SELECT` + collectionColumns + `
+FROM collections c
+WHERE` + collectionLiveWhere + `
+ORDER BY c.position ASC, c.id ASC;`
+	return r.queryCollections(ctx, "list_live", q, now)
+}
+
+func (r *CollectionRepo) GetByID(ctx context.Context, id int64) (*Collection, error) {
+	q := `SELECT` + collectionColumns + `
+FROM collections c
+WHERE c.id = $1;`
+	var c Collection
+	if err := scanCollection(r.db.QueryRowContext(ctx, q, id), &c); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("collections.get: %w", err)
+	}
+	return &c, nil
+}
+
+func (r *CollectionRepo) GetLiveBySlug(ctx context.Context, slug string, now time.Time) (*Collection, error) {
+	q := `SELECT` + collectionColumns + `
+FROM collections c
+WHERE c.slug = $2
+  AND` + collectionLiveWhere + `;`
+	var c Collection
+	if err := scanCollection(r.db.QueryRowContext(ctx, q, now, slug), &c); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("collections.get_live: %w", err)
+	}
+	return &c, nil
+}
+
+func (r *CollectionRepo) SlugExists(ctx context.Context, slug string, excludeID *int64) (bool, error) {
+	var exists bool
+	if err := r.db.QueryRowContext(ctx,
+		`SELECT EXISTS (SELECT 1 FROM collections WHERE slug = $1 AND ($2::bigint IS NULL OR id <> $2));`,
+		slug, excludeID,
+	).Scan(&exists); err != nil {
+		return false, fmt.Errorf("collections.slug_exists: %w", err)
+	}
+	return exists, nil
+}
+
+// CollectionInput holds every editable field; updates replace them all.
+type CollectionInput struct {
+	Slug         string
+	Title        string
+	Description  sql.NullString
+	HeroImageURL sql.NullString
+	Position     int
+	Active       bool
+	StartsAt     sql.NullTime
+	EndsAt       sql.NullTime
+}
+
+func (r *CollectionRepo) Create(ctx context.Context, createdBy int64, in CollectionInput) (int64, error) {
+	const q = `
+INSERT INTO collections (slug, title, description, hero_image_url, position, active, starts_at, ends_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING id;`
+	var id int64
+	if err := r.db.QueryRowContext(ctx, q,
+		in.Slug, in.Title, in.Description, in.HeroImageURL, in.Position, in.Active, in.StartsAt, in.EndsAt, createdBy,
+	).Scan(&id); err != nil {
+		return 0, fmt.Errorf("collections.create: %w", err)
+	}
+	return id, nil
+}
+
+func (r *CollectionRepo) Update(ctx context.Context, id int64, in CollectionInput) error {
+	const q = `
+UPDATE collections
+SET slug = $2,
+    title = $3,
+    description = $4,
+    hero_image_url = $5,
+    position = $6,
+    active = $7,
+    starts_at = $8,
+    ends_at = $9,
+    updated_at = NOW()
+WHERE id = $1;`
+	res, err := r.db.ExecContext(ctx, q,
+		id, in.Slug, in.Title, in.Description, in.HeroImageURL, in.Position, in.Active, in.StartsAt, in.EndsAt,
+	)
+	if err != nil {
+		return fmt.Errorf("collections.update: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("collections.update.rows: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *CollectionRepo) Delete(ctx context.Context, id int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM collections WHERE id = $1;`, id)
+	if err != nil {
+		return fmt.Errorf("collections.delete: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("collections.delete.rows: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *CollectionRepo) ListGameRefs(ctx context.Context, collectionID int64) ([]CollectionGameRef, error) {
+	const q = `
+SELECT g.id, g.title, g.slug, g.status, cg.position
+FROM collection_games cg
+JOIN games g ON g.id = cg.game_id
+WHERE cg.collection_id = $1
+ORDER BY cg.position ASC, g.id ASC;`
+	rows, err := r.db.QueryContext(ctx, q, collectionID)
+	if err != nil {
+		return nil, fmt.Errorf("collection_games.list: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]CollectionGameRef, 0)
+	for rows.Next() {
+		var g CollectionGameRef
+		if err := rows.Scan(&g.GameID, &g.Title, &g.Slug, &g.Status, &g.Position); err != nil {
+			return nil, fmt.Errorf("collection_games.list.scan: %w", err)
+		}
+		out = append(out, g)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("collection_games.list.rows: %w", err)
+	}
+	return out, nil
+}
+
+// ReplaceGames sets the collection's members to gameIDs, in that order.
+// The collection row is locked so concurrent replaces do not interleave.
+func (r *CollectionRepo) ReplaceGames(ctx context.Context, collectionID int64, gameIDs []int64) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("collection_games.replace.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM collections WHERE id = $1 FOR UPDATE;`, collectionID).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("collection_games.replace.lock: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM collection_games WHERE collection_id = $1;`, collectionID); err != nil {
+		return fmt.Errorf("collection_games.replace.delete: %w", err)
+	}
+
+	if len(gameIDs) > 0 {
+		args := make([]any, 0, len(gameIDs)+1)
+		args = append(args, collectionID)
+		valueParts := make([]string, 0, len(gameIDs))
+		for i, gameID := range gameIDs {
+			args = append(args, gameID)
+			valueParts = append(valueParts, fmt.Sprintf("($1, $%d, %d)", i+2, i))
+		}
+		q := `INSERT INTO collection_games (collection_id, game_id, position) VALUES ` + strings.Join(valueParts, ", ") + `;`
+		if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+			return fmt.Errorf("collection_games.replace.insert: %w", err)
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE collections SET updated_at = NOW() WHERE id = $1;`, collectionID); err != nil {
+		return fmt.Errorf("collection_games.replace.touch: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("collection_games.replace.commit: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+// ListCollectionGamesPublic returns the active member games of a collection
+// in shelf order.
+func (r *GameRepo) ListCollectionGamesPublic(ctx context.Context, collectionID int64, limit int) ([]GameListItem, error) {
+	q := r.publicGameSelect() + `
+JOIN collection_games cg ON cg.game_id = g.id
+WHERE cg.collection_id = $1
+  AND g.status = 'active'
+ORDER BY cg.position ASC, g.id ASC
+LIMIT $2;
+`
+	rows, err := r.db.QueryContext(ctx, q, collectionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("collection_games.public: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]GameListItem, 0)
+	for rows.Next() {
+		var it GameListItem
+		if err := scanPublicGame(rows, &it); err != nil {
+			return nil, fmt.Errorf("collection_games.public.scan: %w", err)
+		}
+		out = append(out, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("collection_games.public.rows: %w", err)
+	}
+	return out, nil
+}
@@ -24,7 +24,8 @@ func (r *DashboardRepo) CountSessionsTodayUTC(ctx context.Context) (int, error)
 	const q = `
 SELECT COUNT(*)
 FROM sessions
-WHERE started_at >= ((now() AT TIME ZONE 'utc')::date);
+WHERE started_at >= ((now() AT TIME ZONE 'utc')::date)
+  AND NOT preview;
 `
 	var total int
 	if err := r.db.QueryRowContext(ctx, q).Scan(&total); err != nil {
@@ -33,19 +34,19 @@ WHERE started_at >= ((now() AT TIME ZONE 'utc')::date);
 	return total, nil
 }
 
-func (r *DashboardRepo) ListTopGames(ctx context.Context, limit int) ([]TopGameRow, error) {
+// ListTopGames ranks active games by plays over the last windowDays.
+func (r *DashboardRepo) ListTopGames(ctx context.Context, limit int, windowDays int) ([]TopGameRow, error) {
 	if limit <= 0 {
 		limit = 5
 	}
 
-	const q = `
-SELECT s.game_id, g.title, COUNT(*) AS plays
-FROM sessions s
+	q := `
+SELECT pop.game_id, g.title, pop.popularity AS plays
+FROM ` + popularitySubquery(windowDays) + ` pop
 JOIN games g
-  ON g.id = s.game_id
+  ON g.id = pop.game_id
  AND g.status = 'active'
-GROUP BY s.game_id, g.title
-ORDER BY plays DESC, s.game_id ASC
+ORDER BY plays DESC, pop.game_id ASC
 LIMIT $1;
 `
 	rows, err := r.db.QueryContext(ctx, q, limit)
@@ -92,3 +93,45 @@ FROM players;
 	}
 	return total, nil
 }
+
+type TopFavoritedGameRow struct {
+	GameID    int64
+	Title     string
+	Favorites int
+}
+
+// ListTopFavoritedGames ranks active games by how many players favorited them.
+func (r *DashboardRepo) ListTopFavoritedGames(ctx context.Context, limit int) ([]TopFavoritedGameRow, error) {
+	if limit <= 0 {
+		limit = 5
+	}
+
+	const q = `
+SELECT pf.game_id, g.title, COUNT(*) AS favorites
+FROM player_favorites pf
+JOIN games g
+  ON g.id = pf.game_id
+ AND g.status = 'active'
+GROUP BY pf.game_id, g.title
+ORDER BY favorites DESC, pf.game_id ASC
+LIMIT $1;
+`
+	rows, err := r.db.QueryContext(ctx, q, limit)
+	if err != nil {
+		return nil, fmt.Errorf("dashboard.top_favorited: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]TopFavoritedGameRow, 0, limit)
+	for rows.Next() {
+		var it TopFavoritedGameRow
+		if err := rows.Scan(&it.GameID, &it.Title, &it.Favorites); err != nil {
+			return nil, fmt.Errorf("dashboard.top_favorited.scan: %w", err)
+		}
+		out = append(out, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("dashboard.top_favorited.rows: %w", err)
+	}
+	return out, nil
+}
@@ -28,6 +28,13 @@ type EducationCategoryListFilter struct {
 	Q     sql.NullString
 	Page  int
 	Limit int
+	// After switches to keyset pagination; Page is ignored when set.
+	After *EducationCategoryCursor
+}
+
+type EducationCategoryCursor struct {
+	Name string
+	ID   int64
 }
 
 func (f *EducationCategoryListFilter) normalize() {
@@ -42,10 +49,11 @@ func (f *EducationCategoryListFilter) normalize() {
 	}
 }
 
-func (r *EducationCategoryRepo) List(ctx context.Context, q string, page, limit int) ([]EducationCategory, error) {
+func (r *EducationCategoryRepo) List(ctx context.Context, q string, page, limit int, after *EducationCategoryCursor) ([]EducationCategory, error) {
 	filter := EducationCategoryListFilter{
 		Page:  page,
 		Limit: limit,
+		After: after,
 	}
 	if q != "" {
 		filter.Q = sql.NullString{String: q, Valid: true}
@@ -54,14 +62,23 @@ func (r *EducationCategoryRepo) List(ctx context.Context, q string, page, limit
 
 	offset := (filter.Page - 1) * filter.Limit
 
+	var afterName sql.NullString
+	var afterID sql.NullInt64
+	if filter.After != nil {
+		offset = 0
+		afterName = sql.NullString{String: filter.After.Name, Valid: true}
+		afterID = sql.NullInt64{Int64: filter.After.ID, Valid: true}
+	}
+
 	const query = `
 SELECT id, name, icon, color, created_at
 FROM education_categories ec
 WHERE ($1::text IS NULL OR ec.name ILIKE '%'||$1||'%')
+  AND ($4::text IS NULL OR (ec.name, ec.id) > ($4::text, $5::bigint))
 ORDER BY ec.name ASC, ec.id ASC
 LIMIT $2 OFFSET $3;
 `
-	rows, err := r.db.QueryContext(ctx, query, filter.Q, filter.Limit, offset)
+	rows, err := r.db.QueryContext(ctx, query, filter.Q, filter.Limit, offset, afterName, afterID)
 	if err != nil {
 		return nil, fmt.Errorf("education_categories.list: %w", err)
 	}
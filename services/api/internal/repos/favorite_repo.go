@@ -0,0 +1,93 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type PlayerFavoriteRow struct {
+	GameID      int64
+	Title       string
+	Slug        string
+	Thumbnail   sql.NullString
+	FavoritedAt time.Time
+}
+
+type FavoriteRepo struct {
+	db *sql.DB
+}
+
+func NewFavoriteRepo(db *sql.DB) *FavoriteRepo {
+	return &FavoriteRepo{db: db}
+}
+
+// Add is idempotent; favoriting a game twice keeps the first time.
+func (r *FavoriteRepo) Add(ctx context.Context, playerID string, gameID int64) error {
+	const q = `
+INSERT INTO player_favorites (player_id, game_id)
+VALUES ($1, $2)
+ON CONFLICT (player_id, game_id) DO NOTHING;`
+	if _, err := r.db.ExecContext(ctx, q, playerID, gameID); err != nil {
+		return fmt.Errorf("player_favorites.add: %w", err)
+	}
+	return nil
+}
+
+func (r *FavoriteRepo) Remove(ctx context.Context, playerID string, gameID int64) error {
+	if _, err := r.db.ExecContext(ctx,
+		`DELETE FROM player_favorites WHERE player_id = $1 AND game_id = $2;`,
+		playerID, gameID,
+	); err != nil {
+		return fmt.Errorf("player_favorites.remove: %w", err)
+	}
+	return nil
+}
+
+// ListByPlayerID returns the player's favorited active games, newest first.
+// Favorites of games that are no longer active are kept but not listed.
+func (r *FavoriteRepo) ListByPlayerID(ctx context.Context, playerID string, page, limit int) ([]PlayerFavoriteRow, int, error) {
+	offset := (page - 1) * limit
+
+	const countQ = `
+SELECT COUNT(*)
+FROM player_favorites pf
+JOIN games g ON g.id = pf.game_id
+WHERE pf.player_id = $1
+  AND g.status = 'active';`
+	var total int
+	if err := r.db.QueryRowContext(ctx, countQ, playerID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("player_favorites.count: %w", err)
+	}
+	if total == 0 {
+		return []PlayerFavoriteRow{}, 0, nil
+	}
+
+	const listQ = `
+SELECT g.id, g.title, g.slug, g.thumbnail, pf.created_at
+FROM player_favorites pf
+JOIN games g ON g.id = pf.game_id
+WHERE pf.player_id = $1
+  AND g.status = 'active'
+ORDER BY pf.created_at DESC, g.id DESC
+LIMIT $2 OFFSET $3;`
+	rows, err := r.db.QueryContext(ctx, listQ, playerID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("player_favorites.list: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]PlayerFavoriteRow, 0, limit)
+	for rows.Next() {
+		var row PlayerFavoriteRow
+		if err := rows.Scan(&row.GameID, &row.Title, &row.Slug, &row.Thumbnail, &row.FavoritedAt); err != nil {
+			return nil, 0, fmt.Errorf("player_favorites.list.scan: %w", err)
+		}
+		out = append(out, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("player_favorites.list.rows: %w", err)
+	}
+	return out, total, nil
+}
@@ -0,0 +1,375 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// storageGCLockKey keeps storage GC runs (the API job, the admin endpoint and
+// the CLI, on any replica) from deleting objects at the same time.
+const storageGCLockKey int64 = 0x6b705f737467635f // "kp_stgc_"
+
+type GameBuild struct {
+	ID              int64
+	GameID          int64
+	UploadObjectKey string
+	FileCount       int
+	TotalBytes      int64
+	IsCurrent       bool
+	CacheVersion    int
+	CreatedAt       time.Time
+	PromotedAt      sql.NullTime
+}
+
+type GameBuildFile struct {
+	Path        string
+	SizeBytes   int64
+	ContentType sql.NullString
+	SHA256      sql.NullString
+}
+
+type GameBuildRepo struct {
+	db *sql.DB
+}
+
+func NewGameBuildRepo(db *sql.DB) *GameBuildRepo {
+	return &GameBuildRepo{db: db}
+}
+
+type CreateGameBuildInput struct {
+	GameID          int64
+	UploadObjectKey string
+	Files           []GameBuildFile
+}
+
+const gameBuildFilesInsertChunk = 500
+
+func (r *GameBuildRepo) Create(ctx context.Context, in CreateGameBuildInput) (*GameBuild, error) {
+	if in.GameID <= 0 {
+		return nil, errors.New("game id is required")
+	}
+
+	var totalBytes int64
+	for _, f := range in.Files {
+		totalBytes += f.SizeBytes
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("game_builds.create.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	const q = `
+INSERT INTO game_builds (game_id, upload_object_key, file_count, total_bytes)
+VALUES ($1, $2, $3, $4)
+RETURNING id, game_id, upload_object_key, file_count, total_bytes, is_current, cache_version, created_at, promoted_at;
+`
+	var b GameBuild
+	if err := tx.QueryRowContext(ctx, q,
+		in.GameID,
+		in.UploadObjectKey,
+		len(in.Files),
+		totalBytes,
+	).Scan(
+		&b.ID,
+		&b.GameID,
+		&b.UploadObjectKey,
+		&b.FileCount,
+		&b.TotalBytes,
+		&b.IsCurrent,
+		&b.CacheVersion,
+		&b.CreatedAt,
+		&b.PromotedAt,
+	); err != nil {
+		return nil, fmt.Errorf("game_builds.create: %w", err)
+	}
+
+	for start := 0; start < len(in.Files); start += gameBuildFilesInsertChunk {
+		end := start + gameBuildFilesInsertChunk
+		if end > len(in.Files) {
+			end = len(in.Files)
+		}
+		chunk := in.Files[start:end]
+
+		args := make([]any, 0, len(chunk)*4+1)
+		args = append(args, b.ID)
+		valueParts := make([]string, 0, len(chunk))
+		for i, f := range chunk {
+			base := i*4 + 2
+			args = append(args, f.Path, f.SizeBytes, f.ContentType, f.SHA256)
+			valueParts = append(valueParts, fmt.Sprintf("($1, $%d, $%d, $%d, $%d)", base, base+1, base+2, base+3))
+		}
+
+		query := fmt.Sprintf(
+			`INSERT INTO game_build_files (build_id, path, size_bytes, content_type, sha256) VALUES %s;`,
+			strings.Join(valueParts, ", "),
+		)
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("game_build_files.create: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("game_builds.create.commit: %w", err)
+	}
+	committed = true
+	return &b, nil
+}
+
+// MarkCurrent makes buildID the game's current build and points game_url at
+// it in the same transaction, so players never see one without the other.
+func (r *GameBuildRepo) MarkCurrent(ctx context.Context, gameID int64, buildID int64, gameURL string) error {
+	if gameID <= 0 || buildID <= 0 {
+		return errors.New("game id and build id are required")
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("game_builds.mark_current.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE game_builds SET is_current = FALSE WHERE game_id = $1 AND is_current;`,
+		gameID,
+	); err != nil {
+		return fmt.Errorf("game_builds.mark_current.reset: %w", err)
+	}
+
+	// cache_version only moves forward so offline clients always see a new
+	// version after a promotion, even when an older build is re-promoted.
+	res, err := tx.ExecContext(ctx, `
+UPDATE game_builds
+SET is_current = TRUE,
+    promoted_at = NOW(),
+    cache_version = (SELECT COALESCE(MAX(cache_version), 0) + 1 FROM game_builds WHERE game_id = $2)
+WHERE id = $1
+  AND game_id = $2;
+`,
+		buildID,
+		gameID,
+	)
+	if err != nil {
+		return fmt.Errorf("game_builds.mark_current: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("game_builds.mark_current.rows_affected: %w", err)
+	}
+	if ra == 0 {
+		return ErrNotFound
+	}
+
+	res, err = tx.ExecContext(ctx,
+		`UPDATE games SET game_url = $2, updated_at = NOW() WHERE id = $1;`,
+		gameID,
+		gameURL,
+	)
+	if err != nil {
+		return fmt.Errorf("game_builds.mark_current.game_url: %w", err)
+	}
+	ra, err = res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("game_builds.mark_current.game_url.rows_affected: %w", err)
+	}
+	if ra == 0 {
+		return ErrNotFound
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("game_builds.mark_current.commit: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+func (r *GameBuildRepo) GetCurrent(ctx context.Context, gameID int64) (*GameBuild, error) {
+	const q = `
+SELECT id, game_id, upload_object_key, file_count, total_bytes, is_current, cache_version, created_at, promoted_at
+FROM game_builds
+WHERE game_id = $1
+  AND is_current
+LIMIT 1;
+`
+	var b GameBuild
+	err := r.db.QueryRowContext(ctx, q, gameID).Scan(
+		&b.ID,
+		&b.GameID,
+		&b.UploadObjectKey,
+		&b.FileCount,
+		&b.TotalBytes,
+		&b.IsCurrent,
+		&b.CacheVersion,
+		&b.CreatedAt,
+		&b.PromotedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("game_builds.get_current: %w", err)
+	}
+	return &b, nil
+}
+
+// GetFile returns one file of a build of gameID, or ErrNotFound.
+func (r *GameBuildRepo) GetFile(ctx context.Context, gameID int64, buildID int64, filePath string) (*GameBuildFile, error) {
+	const q = `
+SELECT f.path, f.size_bytes, f.content_type, f.sha256
+FROM game_build_files f
+JOIN game_builds b ON b.id = f.build_id
+WHERE b.id = $2
+  AND b.game_id = $1
+  AND f.path = $3;
+`
+	var f GameBuildFile
+	err := r.db.QueryRowContext(ctx, q, gameID, buildID, filePath).Scan(&f.Path, &f.SizeBytes, &f.ContentType, &f.SHA256)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("game_build_files.get: %w", err)
+	}
+	return &f, nil
+}
+
+func (r *GameBuildRepo) ListFiles(ctx context.Context, buildID int64) ([]GameBuildFile, error) {
+	const q = `
+SELECT path, size_bytes, content_type, sha256
+FROM game_build_files
+WHERE build_id = $1
+ORDER BY path;
+`
+	rows, err := r.db.QueryContext(ctx, q, buildID)
+	if err != nil {
+		return nil, fmt.Errorf("game_build_files.list: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]GameBuildFile, 0)
+	for rows.Next() {
+		var f GameBuildFile
+		if err := rows.Scan(&f.Path, &f.SizeBytes, &f.ContentType, &f.SHA256); err != nil {
+			return nil, fmt.Errorf("game_build_files.list.scan: %w", err)
+		}
+		out = append(out, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("game_build_files.list.rows: %w", err)
+	}
+	return out, nil
+}
+
+// Delete removes a build that never became current, together with its file
+// rows. It returns ErrNotFound when the build is gone or is current.
+func (r *GameBuildRepo) Delete(ctx context.Context, buildID int64) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM game_builds WHERE id = $1 AND NOT is_current;`, buildID)
+	if err != nil {
+		return fmt.Errorf("game_builds.delete: %w", err)
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("game_builds.delete.rows_affected: %w", err)
+	}
+	if ra == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ListLiveIDs returns the current build plus any newer build, since a newer
+// build may still be uploading. Without a current build every build is live.
+func (r *GameBuildRepo) ListLiveIDs(ctx context.Context, gameID int64) ([]int64, error) {
+	const q = `
+SELECT id
+FROM game_builds
+WHERE game_id = $1
+  AND id >= COALESCE((
+    SELECT cur.id FROM game_builds cur WHERE cur.game_id = $1 AND cur.is_current
+  ), 0)
+ORDER BY id;
+`
+	rows, err := r.db.QueryContext(ctx, q, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("game_builds.list_live: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("game_builds.list_live.scan: %w", err)
+		}
+		out = append(out, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("game_builds.list_live.rows: %w", err)
+	}
+	return out, nil
+}
+
+func (r *GameBuildRepo) ListUploadObjectKeys(ctx context.Context, gameID int64) ([]string, error) {
+	const q = `
+SELECT upload_object_key
+FROM game_builds
+WHERE game_id = $1
+  AND (is_current OR id > COALESCE((
+    SELECT cur.id FROM game_builds cur WHERE cur.game_id = $1 AND cur.is_current
+  ), 0))
+ORDER BY id DESC;
+`
+	rows, err := r.db.QueryContext(ctx, q, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("game_builds.list_upload_keys: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]string, 0)
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("game_builds.list_upload_keys.scan: %w", err)
+		}
+		out = append(out, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("game_builds.list_upload_keys.rows: %w", err)
+	}
+	return out, nil
+}
+
+// TryLockStorageGC takes the storage GC lock in a transaction that is held
+// until release is called. ok is false when another run holds the lock.
+func (r *GameBuildRepo) TryLockStorageGC(ctx context.Context) (release func(), ok bool, err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("storage_gc.lock.begin: %w", err)
+	}
+
+	var locked bool
+	if err := tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1);`, storageGCLockKey).Scan(&locked); err != nil {
+		_ = tx.Rollback()
+		return nil, false, fmt.Errorf("storage_gc.lock: %w", err)
+	}
+	if !locked {
+		_ = tx.Rollback()
+		return nil, false, nil
+	}
+	return func() { _ = tx.Rollback() }, true, nil
+}
@@ -0,0 +1,189 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrGameNotDeleted = errors.New("game not deleted")
+
+type GameDeletion struct {
+	GameID    int64
+	Title     string
+	Slug      string
+	Status    string
+	DeletedAt sql.NullTime
+}
+
+const gameDeletionColumns = `id, title, slug, status, deleted_at`
+
+func scanGameDeletion(row interface{ Scan(...any) error }, d *GameDeletion) error {
+	return row.Scan(&d.GameID, &d.Title, &d.Slug, &d.Status, &d.DeletedAt)
+}
+
+func (r *GameRepo) GetDeletion(ctx context.Context, gameID int64) (*GameDeletion, error) {
+	q := `SELECT ` + gameDeletionColumns + ` FROM games WHERE id = $1;`
+	var d GameDeletion
+	if err := scanGameDeletion(r.db.QueryRowContext(ctx, q, gameID), &d); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("games.deletion.get: %w", err)
+	}
+	return &d, nil
+}
+
+// SoftDelete archives the game, drops any pending schedule and stamps
+// deleted_at. Deleting an already deleted game keeps the original time.
+func (r *GameRepo) SoftDelete(ctx context.Context, gameID int64) (*GameDeletion, error) {
+	q := `
+UPDATE games
+SET status = 'archived',
+    publish_at = NULL,
+    unpublish_at = NULL,
+    deleted_at = COALESCE(deleted_at, NOW()),
+    updated_at = NOW()
+WHERE id = $1
+RETURNING ` + gameDeletionColumns + `;`
+	var d GameDeletion
+	if err := scanGameDeletion(r.db.QueryRowContext(ctx, q, gameID), &d); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("games.deletion.soft: %w", err)
+	}
+	return &d, nil
+}
+
+// Restore brings a soft-deleted game back as a draft.
+func (r *GameRepo) Restore(ctx context.Context, gameID int64) (*GameDeletion, error) {
+	q := `
+UPDATE games
+SET status = 'draft',
+    deleted_at = NULL,
+    updated_at = NOW()
+WHERE id = $1
+  AND deleted_at IS NOT NULL
+RETURNING ` + gameDeletionColumns + `;`
+	var d GameDeletion
+	if err := scanGameDeletion(r.db.QueryRowContext(ctx, q, gameID), &d); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("games.deletion.restore: %w", err)
+	}
+	return &d, nil
+}
+
+// ListPurgeable returns soft-deleted games whose deletion is at or before
+// cutoff, oldest first.
+func (r *GameRepo) ListPurgeable(ctx context.Context, cutoff time.Time, limit int) ([]int64, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+
+	const q = `
+SELECT id
+FROM games
+WHERE deleted_at IS NOT NULL
+  AND deleted_at <= $1
+ORDER BY deleted_at ASC, id ASC
+LIMIT $2;`
+	rows, err := r.db.QueryContext(ctx, q, cutoff, limit)
+	if err != nil {
+		return nil, fmt.Errorf("games.deletion.purgeable: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("games.deletion.purgeable.scan: %w", err)
+		}
+		out = append(out, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("games.deletion.purgeable.rows: %w", err)
+	}
+	return out, nil
+}
+
+// gameDeleteCounts lists every table that cascades from games, keyed by the
+// name used in the deletion report.
+var gameDeleteCounts = []struct {
+	table string
+	query string
+}{
+	{"game_education_categories", `SELECT COUNT(*) FROM game_education_categories WHERE game_id = $1`},
+	{"sessions", `SELECT COUNT(*) FROM sessions WHERE game_id = $1`},
+	{"leaderboard_submissions", `SELECT COUNT(*) FROM leaderboard_submissions WHERE game_id = $1`},
+	{"analytics_events", `SELECT COUNT(*) FROM analytics_events WHERE game_id = $1`},
+	{"game_builds", `SELECT COUNT(*) FROM game_builds WHERE game_id = $1`},
+	{"game_build_files", `SELECT COUNT(*) FROM game_build_files f JOIN game_builds b ON b.id = f.build_id WHERE b.game_id = $1`},
+	{"game_media", `SELECT COUNT(*) FROM game_media WHERE game_id = $1`},
+	{"game_slug_history", `SELECT COUNT(*) FROM game_slug_history WHERE game_id = $1`},
+	{"game_play_daily", `SELECT COUNT(*) FROM game_play_daily WHERE game_id = $1`},
+	{"game_translations", `SELECT COUNT(*) FROM game_translations WHERE game_id = $1`},
+	{"game_related", `SELECT COUNT(*) FROM game_related WHERE game_id = $1 OR related_game_id = $1`},
+	{"collection_games", `SELECT COUNT(*) FROM collection_games WHERE game_id = $1`},
+	{"player_favorites", `SELECT COUNT(*) FROM player_favorites WHERE game_id = $1`},
+	{"game_reactions", `SELECT COUNT(*) FROM game_reactions WHERE game_id = $1`},
+	{"game_reaction_counts", `SELECT COUNT(*) FROM game_reaction_counts WHERE game_id = $1`},
+	{"profile_blocked_games", `SELECT COUNT(*) FROM profile_blocked_games WHERE game_id = $1`},
+}
+
+// HardDelete removes a soft-deleted game and, through ON DELETE CASCADE,
+// every dependent row. It returns the number of rows removed per table,
+// including the games row itself.
+func (r *GameRepo) HardDelete(ctx context.Context, gameID int64) (map[string]int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("games.deletion.hard.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var deletedAt sql.NullTime
+	if err := tx.QueryRowContext(ctx, `SELECT deleted_at FROM games WHERE id = $1 FOR UPDATE;`, gameID).Scan(&deletedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("games.deletion.hard.lock: %w", err)
+	}
+	if !deletedAt.Valid {
+		return nil, ErrGameNotDeleted
+	}
+
+	counts := make(map[string]int64, len(gameDeleteCounts)+1)
+	for _, c := range gameDeleteCounts {
+		var n int64
+		if err := tx.QueryRowContext(ctx, c.query, gameID).Scan(&n); err != nil {
+			return nil, fmt.Errorf("games.deletion.hard.count %s: %w", c.table, err)
+		}
+		counts[c.table] = n
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM games WHERE id = $1;`, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("games.deletion.hard.delete: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("games.deletion.hard.rows: %w", err)
+	}
+	counts["games"] = n
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("games.deletion.hard.commit: %w", err)
+	}
+	committed = true
+	return counts, nil
+}
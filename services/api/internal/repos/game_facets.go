@@ -0,0 +1,125 @@
+package repos
+
+import (
+	"context"
+	"fmt"
+)
+
+type GameFacetCount struct {
+	ID    int64
+	Label string
+	Count int
+}
+
+type GameValueFacetCount struct {
+	Value string
+	Count int
+}
+
+type GameFacets struct {
+	AgeCategories       []GameFacetCount
+	EducationCategories []GameFacetCount
+	Difficulties        []GameValueFacetCount
+	Free                []GameValueFacetCount
+}
+
+// FacetsPublic counts matching games per filter value. Each facet ignores its
+// own filter so picking "Math" still shows how many "Reading" games exist.
+func (r *GameRepo) FacetsPublic(ctx context.Context, filter GameListFilter) (*GameFacets, error) {
+	filter.normalize()
+	out := &GameFacets{}
+
+	ageFilter := filter
+	ageFilter.AgeCategoryIDs = nil
+	w := buildPublicGameWhere(ageFilter)
+	ages, err := r.queryFacetCounts(ctx, `
+SELECT ac.id, ac.label, COUNT(*)`+publicGameCountFrom+w.sql()+`
+GROUP BY ac.id, ac.label, ac.min_age
+ORDER BY ac.min_age, ac.id;
+`, w.args)
+	if err != nil {
+		return nil, fmt.Errorf("games.facets.age: %w", err)
+	}
+	out.AgeCategories = ages
+
+	eduFilter := filter
+	eduFilter.EducationCategoryIDs = nil
+	w = buildPublicGameWhere(eduFilter)
+	edu, err := r.queryFacetCounts(ctx, `
+SELECT ec.id, ec.name, COUNT(*)`+publicGameCountFrom+`
+JOIN game_education_categories fgec ON fgec.game_id = g.id
+JOIN education_categories ec ON ec.id = fgec.education_category_id
+`+w.sql()+`
+GROUP BY ec.id, ec.name
+ORDER BY ec.id;
+`, w.args)
+	if err != nil {
+		return nil, fmt.Errorf("games.facets.education: %w", err)
+	}
+	out.EducationCategories = edu
+
+	diffFilter := filter
+	diffFilter.Difficulties = nil
+	w = buildPublicGameWhere(diffFilter)
+	diffs, err := r.queryValueFacetCounts(ctx, `
+SELECT g.difficulty::text, COUNT(*)`+publicGameCountFrom+w.sql()+`
+  AND g.difficulty IS NOT NULL
+GROUP BY g.difficulty
+ORDER BY g.difficulty;
+`, w.args)
+	if err != nil {
+		return nil, fmt.Errorf("games.facets.difficulty: %w", err)
+	}
+	out.Difficulties = diffs
+
+	freeFilter := filter
+	freeFilter.Free.Valid = false
+	w = buildPublicGameWhere(freeFilter)
+	free, err := r.queryValueFacetCounts(ctx, `
+SELECT g.free::text, COUNT(*)`+publicGameCountFrom+w.sql()+`
+GROUP BY g.free
+ORDER BY g.free DESC;
+`, w.args)
+	if err != nil {
+		return nil, fmt.Errorf("games.facets.free: %w", err)
+	}
+	out.Free = free
+
+	return out, nil
+}
+
+func (r *GameRepo) queryFacetCounts(ctx context.Context, q string, args []any) ([]GameFacetCount, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]GameFacetCount, 0)
+	for rows.Next() {
+		var it GameFacetCount
+		if err := rows.Scan(&it.ID, &it.Label, &it.Count); err != nil {
+			return nil, err
+		}
+		out = append(out, it)
+	}
+	return out, rows.Err()
+}
+
+func (r *GameRepo) queryValueFacetCounts(ctx context.Context, q string, args []any) ([]GameValueFacetCount, error) {
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]GameValueFacetCount, 0)
+	for rows.Next() {
+		var it GameValueFacetCount
+		if err := rows.Scan(&it.Value, &it.Count); err != nil {
+			return nil, err
+		}
+		out = append(out, it)
+	}
+	return out, rows.Err()
+}
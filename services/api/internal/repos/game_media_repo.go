@@ -0,0 +1,212 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	GameMediaKindThumbnail  = "thumbnail"
+	GameMediaKindScreenshot = "screenshot"
+)
+
+var ErrMediaLimitReached = errors.New("media limit reached")
+
+type GameMedia struct {
+	ID           int64
+	GameID       int64
+	Kind         string
+	Position     int
+	ObjectPrefix string
+	Width        int
+	Height       int
+	VariantsJSON []byte
+	CreatedAt    time.Time
+}
+
+type GameMediaRepo struct {
+	db *sql.DB
+}
+
+func NewGameMediaRepo(db *sql.DB) *GameMediaRepo {
+	return &GameMediaRepo{db: db}
+}
+
+type CreateGameMediaInput struct {
+	GameID       int64
+	ObjectPrefix string
+	Width        int
+	Height       int
+	VariantsJSON []byte
+}
+
+const gameMediaColumns = `id, game_id, kind, position, object_prefix, width, height, variants, created_at`
+
+func scanGameMedia(row interface{ Scan(...any) error }, m *GameMedia) error {
+	return row.Scan(
+		&m.ID,
+		&m.GameID,
+		&m.Kind,
+		&m.Position,
+		&m.ObjectPrefix,
+		&m.Width,
+		&m.Height,
+		&m.VariantsJSON,
+		&m.CreatedAt,
+	)
+}
+
+// ReplaceThumbnail stores the new thumbnail and returns the previous one (if
+// any) so the caller can delete its objects.
+func (r *GameMediaRepo) ReplaceThumbnail(ctx context.Context, in CreateGameMediaInput) (*GameMedia, *GameMedia, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("game_media.replace_thumbnail.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var old *GameMedia
+	var prev GameMedia
+	err = scanGameMedia(tx.QueryRowContext(ctx,
+		`DELETE FROM game_media WHERE game_id = $1 AND kind = 'thumbnail' RETURNING `+gameMediaColumns+`;`,
+		in.GameID,
+	), &prev)
+	switch {
+	case err == nil:
+		old = &prev
+	case errors.Is(err, sql.ErrNoRows):
+	default:
+		return nil, nil, fmt.Errorf("game_media.replace_thumbnail.delete: %w", err)
+	}
+
+	var m GameMedia
+	if err := scanGameMedia(tx.QueryRowContext(ctx, `
+INSERT INTO game_media (game_id, kind, position, object_prefix, width, height, variants)
+VALUES ($1, 'thumbnail', 0, $2, $3, $4, $5)
+RETURNING `+gameMediaColumns+`;
+`,
+		in.GameID,
+		in.ObjectPrefix,
+		in.Width,
+		in.Height,
+		in.VariantsJSON,
+	), &m); err != nil {
+		return nil, nil, fmt.Errorf("game_media.replace_thumbnail.insert: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, nil, fmt.Errorf("game_media.replace_thumbnail.commit: %w", err)
+	}
+	committed = true
+	return &m, old, nil
+}
+
+// AddScreenshot locks the game row so concurrent uploads cannot exceed max.
+func (r *GameMediaRepo) AddScreenshot(ctx context.Context, in CreateGameMediaInput, max int) (*GameMedia, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("game_media.add_screenshot.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var gameID int64
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM games WHERE id = $1 FOR UPDATE;`, in.GameID).Scan(&gameID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("game_media.add_screenshot.lock: %w", err)
+	}
+
+	var count, maxPos int
+	if err := tx.QueryRowContext(ctx, `
+SELECT COUNT(*), COALESCE(MAX(position), 0)
+FROM game_media
+WHERE game_id = $1
+  AND kind = 'screenshot';
+`, in.GameID).Scan(&count, &maxPos); err != nil {
+		return nil, fmt.Errorf("game_media.add_screenshot.count: %w", err)
+	}
+	if count >= max {
+		return nil, ErrMediaLimitReached
+	}
+
+	var m GameMedia
+	if err := scanGameMedia(tx.QueryRowContext(ctx, `
+INSERT INTO game_media (game_id, kind, position, object_prefix, width, height, variants)
+VALUES ($1, 'screenshot', $2, $3, $4, $5, $6)
+RETURNING `+gameMediaColumns+`;
+`,
+		in.GameID,
+		maxPos+1,
+		in.ObjectPrefix,
+		in.Width,
+		in.Height,
+		in.VariantsJSON,
+	), &m); err != nil {
+		return nil, fmt.Errorf("game_media.add_screenshot.insert: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("game_media.add_screenshot.commit: %w", err)
+	}
+	committed = true
+	return &m, nil
+}
+
+func (r *GameMediaRepo) ListByGame(ctx context.Context, gameID int64, kind string) ([]GameMedia, error) {
+	rows, err := r.db.QueryContext(ctx, `
+SELECT `+gameMediaColumns+`
+FROM game_media
+WHERE game_id = $1
+  AND kind = $2
+ORDER BY position, id;
+`, gameID, kind)
+	if err != nil {
+		return nil, fmt.Errorf("game_media.list: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]GameMedia, 0)
+	for rows.Next() {
+		var m GameMedia
+		if err := scanGameMedia(rows, &m); err != nil {
+			return nil, fmt.Errorf("game_media.list.scan: %w", err)
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("game_media.list.rows: %w", err)
+	}
+	return out, nil
+}
+
+func (r *GameMediaRepo) Delete(ctx context.Context, gameID int64, mediaID int64, kind string) (*GameMedia, error) {
+	var m GameMedia
+	err := scanGameMedia(r.db.QueryRowContext(ctx, `
+DELETE FROM game_media
+WHERE id = $1
+  AND game_id = $2
+  AND kind = $3
+RETURNING `+gameMediaColumns+`;
+`, mediaID, gameID, kind), &m)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("game_media.delete: %w", err)
+	}
+	return &m, nil
+}
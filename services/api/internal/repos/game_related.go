@@ -0,0 +1,158 @@
+package repos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// relatedGamesLockKey keeps replicas from rebuilding game_related at the
+// same time.
+const relatedGamesLockKey int64 = 0x6b705f72656c6174 // "kp_relat"
+
+// ErrRelatedGamesLocked is returned when another replica holds the rebuild
+// lock.
+var ErrRelatedGamesLocked = errors.New("related games rebuild already running")
+
+// rebuildRelatedQuery scores every ordered pair of active games:
+//
+//	score = 2 * shared education categories
+//	      + age overlap (share of the game's age range the other covers, 0..1)
+//	      + 3 * ln(1 + co-plays)
+//
+// Co-plays are distinct players with a game_start on both games since $1.
+// A player is keyed by the player id the play token stamps into event_data
+// and a guest by the guest id of the event's session, since each session
+// belongs to a single game. Pairs with no signal at all are dropped and each
+// game keeps its top $2.
+const rebuildRelatedQuery = `
+WITH active AS (
+  SELECT g.id, ac.min_age, ac.max_age
+  FROM games g
+  JOIN age_categories ac ON ac.id = g.age_category_id
+  WHERE g.status = 'active'
+),
+plays AS (
+  SELECT DISTINCT ae.game_id,
+         COALESCE('p:' || (ae.event_data->>'player_id'), 'g:' || s.guest_id) AS player_key
+  FROM analytics_events ae
+  JOIN active a ON a.id = ae.game_id
+  LEFT JOIN sessions s ON s.id = ae.session_id
+  WHERE ae.event_name = 'game_start'
+    AND NOT ae.preview
+    AND ae.created_at >= $1
+    AND COALESCE(ae.event_data->>'player_id', s.guest_id) IS NOT NULL
+),
+co AS (
+  SELECT p1.game_id, p2.game_id AS related_game_id, COUNT(*)::int AS co_plays
+  FROM plays p1
+  JOIN plays p2 ON p2.player_key = p1.player_key AND p2.game_id <> p1.game_id
+  GROUP BY 1, 2
+),
+shared AS (
+  SELECT e1.game_id, e2.game_id AS related_game_id, COUNT(*)::int AS shared_categories
+  FROM game_education_categories e1
+  JOIN game_education_categories e2
+    ON e2.education_category_id = e1.education_category_id AND e2.game_id <> e1.game_id
+  GROUP BY 1, 2
+),
+pairs AS (
+  SELECT
+    a.id AS game_id,
+    b.id AS related_game_id,
+    COALESCE(s.shared_categories, 0) AS shared_categories,
+    GREATEST(0, LEAST(a.max_age, b.max_age) - GREATEST(a.min_age, b.min_age) + 1)::float8
+      / GREATEST(a.max_age - a.min_age + 1, 1) AS age_overlap,
+    COALESCE(co.co_plays, 0) AS co_plays
+  FROM active a
+  JOIN active b ON b.id <> a.id
+  LEFT JOIN shared s ON s.game_id = a.id AND s.related_game_id = b.id
+  LEFT JOIN co ON co.game_id = a.id AND co.related_game_id = b.id
+),
+scored AS (
+  SELECT p.*,
+         2.0 * p.shared_categories + p.age_overlap + 3.0 * LN(1 + p.co_plays) AS score
+  FROM pairs p
+),
+ranked AS (
+  SELECT s.*,
+         ROW_NUMBER() OVER (PARTITION BY s.game_id ORDER BY s.score DESC, s.related_game_id ASC) AS rn
+  FROM scored s
+  WHERE s.score > 0
+)
+INSERT INTO game_related (game_id, related_game_id, score, shared_categories, age_overlap, co_plays, computed_at)
+SELECT game_id, related_game_id, score, shared_categories, age_overlap, co_plays, NOW()
+FROM ranked
+WHERE rn <= $2;`
+
+// Rebuild replaces game_related in one transaction, so readers see either
+// the previous or the new set.
+func (r *GameRepo) RebuildRelated(ctx context.Context, since time.Time, perGame int) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("game_related.rebuild.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var locked bool
+	if err := tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1);`, relatedGamesLockKey).Scan(&locked); err != nil {
+		return 0, fmt.Errorf("game_related.rebuild.lock: %w", err)
+	}
+	if !locked {
+		return 0, ErrRelatedGamesLocked
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM game_related;`); err != nil {
+		return 0, fmt.Errorf("game_related.rebuild.delete: %w", err)
+	}
+	res, err := tx.ExecContext(ctx, rebuildRelatedQuery, since.UTC(), perGame)
+	if err != nil {
+		return 0, fmt.Errorf("game_related.rebuild.insert: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("game_related.rebuild.rows: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("game_related.rebuild.commit: %w", err)
+	}
+	committed = true
+	return n, nil
+}
+
+// ListRelatedPublic returns the stored related games of gameID that are
+// still active, best first.
+func (r *GameRepo) ListRelatedPublic(ctx context.Context, gameID int64, limit int) ([]GameListItem, error) {
+	q := r.publicGameSelect() + `
+JOIN game_related gr ON gr.related_game_id = g.id
+WHERE gr.game_id = $1
+  AND g.status = 'active'
+ORDER BY gr.score DESC, g.id ASC
+LIMIT $2;
+`
+	rows, err := r.db.QueryContext(ctx, q, gameID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("game_related.list: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]GameListItem, 0, limit)
+	for rows.Next() {
+		var it GameListItem
+		if err := scanPublicGame(rows, &it); err != nil {
+			return nil, fmt.Errorf("game_related.list.scan: %w", err)
+		}
+		out = append(out, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("game_related.list.rows: %w", err)
+	}
+	return out, nil
+}
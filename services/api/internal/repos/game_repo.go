@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -26,14 +27,27 @@ type Game struct {
 
 	EducationCategoryIDsJSON []byte
 	EducationCategoriesJSON  []byte
+
+	// FavoriteCount is only loaded by the admin list and GetByID.
+	FavoriteCount int
 }
 
 type GameRepo struct {
-	db *sql.DB
+	db             *sql.DB
+	popularityDays int
 }
 
 func NewGameRepo(db *sql.DB) *GameRepo {
-	return &GameRepo{db: db}
+	return &GameRepo{db: db, popularityDays: defaultPopularityWindowDays}
+}
+
+// WithPopularityWindow sets how many days of plays count towards play_count
+// and the popular sort.
+func (r *GameRepo) WithPopularityWindow(days int) *GameRepo {
+	if days > 0 {
+		r.popularityDays = days
+	}
+	return r
 }
 
 // ADMIN
@@ -43,6 +57,13 @@ type AdminGameFilter struct {
 	Q      sql.NullString
 	Page   int
 	Limit  int
+	// After switches to keyset pagination; Page is ignored when set.
+	After *AdminGameCursor
+}
+
+type AdminGameCursor struct {
+	UpdatedAt time.Time
+	ID        int64
 }
 
 func (f *AdminGameFilter) normalize() {
@@ -61,11 +82,20 @@ func (r *GameRepo) ListAdminGames(ctx context.Context, filter AdminGameFilter) (
 	filter.normalize()
 	offset := (filter.Page - 1) * filter.Limit
 
+	var afterUpdatedAt sql.NullTime
+	var afterID sql.NullInt64
+	if filter.After != nil {
+		offset = 0
+		afterUpdatedAt = sql.NullTime{Time: filter.After.UpdatedAt, Valid: true}
+		afterID = sql.NullInt64{Int64: filter.After.ID, Valid: true}
+	}
+
 	const q = `
 SELECT id, title, slug, description, thumbnail, game_url, difficulty,
        age_category_id, free, status, created_by, created_at, updated_at,
        COALESCE(edu.education_category_ids, '[]'::jsonb) AS education_category_ids,
-       COALESCE(edu.education_categories, '[]'::jsonb) AS education_categories
+       COALESCE(edu.education_categories, '[]'::jsonb) AS education_categories,
+       (SELECT COUNT(*) FROM player_favorites pf WHERE pf.game_id = g.id) AS favorite_count
 FROM games g
 LEFT JOIN LATERAL (
   SELECT
@@ -86,10 +116,11 @@ LEFT JOIN LATERAL (
 ) edu ON TRUE
 WHERE ($1::text IS NULL OR g.status = $1::game_status)
   AND ($2::text IS NULL OR g.title ILIKE '%'||$2||'%' OR g.slug ILIKE '%'||$2||'%')
+  AND ($5::timestamptz IS NULL OR (g.updated_at, g.id) < ($5::timestamptz, $6::bigint))
 ORDER BY g.updated_at DESC, g.id DESC
 LIMIT $3 OFFSET $4;
 `
-	rows, err := r.db.QueryContext(ctx, q, filter.Status, filter.Q, filter.Limit, offset)
+	rows, err := r.db.QueryContext(ctx, q, filter.Status, filter.Q, filter.Limit, offset, afterUpdatedAt, afterID)
 	if err != nil {
 		return nil, err
 	}
@@ -114,6 +145,7 @@ LIMIT $3 OFFSET $4;
 			&g.UpdatedAt,
 			&g.EducationCategoryIDsJSON,
 			&g.EducationCategoriesJSON,
+			&g.FavoriteCount,
 		); err != nil {
 			return nil, err
 		}
@@ -277,6 +309,8 @@ func (r *GameRepo) ReplaceGameEducationCategories(ctx context.Context, gameID in
 type CreateAdminGameInput struct {
 	Title         string
 	Slug          string
+	Description   sql.NullString
+	Difficulty    sql.NullString
 	Thumbnail     sql.NullString
 	GameURL       sql.NullString
 	AgeCategoryID int64
@@ -286,8 +320,8 @@ type CreateAdminGameInput struct {
 
 func (r *GameRepo) CreateGame(ctx context.Context, in CreateAdminGameInput) (int64, error) {
 	const q = `
-INSERT INTO games (title, slug, thumbnail, game_url, age_category_id, free, status, created_by)
-VALUES ($1, $2, $3, $4, $5, $6, 'draft', $7)
+INSERT INTO games (title, slug, thumbnail, game_url, age_category_id, free, status, created_by, description, difficulty)
+VALUES ($1, $2, $3, $4, $5, $6, 'draft', $7, $8, $9::game_difficulty)
 RETURNING id;
 `
 	var id int64
@@ -299,15 +333,21 @@ RETURNING id;
 		in.AgeCategoryID,
 		in.Free,
 		in.CreatedBy,
+		in.Description,
+		in.Difficulty,
 	).Scan(&id); err != nil {
 		return 0, err
 	}
 	return id, nil
 }
 
+// Description and Difficulty are nullable columns: a non-nil pointer holding
+// an invalid NullString clears the column.
 type UpdateAdminGameInput struct {
 	Title         *string
 	Slug          *string
+	Description   *sql.NullString
+	Difficulty    *sql.NullString
 	Thumbnail     *string
 	GameURL       *string
 	AgeCategoryID *int64
@@ -328,13 +368,42 @@ SET
   game_url = COALESCE($5, game_url),
   age_category_id = COALESCE($6::bigint, age_category_id),
   free = COALESCE($7::boolean, free),
+  description = CASE WHEN $8::boolean THEN $9 ELSE description END,
+  difficulty = CASE WHEN $10::boolean THEN $11::game_difficulty ELSE difficulty END,
   updated_at = NOW()
 WHERE id = $1
 RETURNING id, title, slug, description, thumbnail, game_url, difficulty,
           age_category_id, free, status, created_by, created_at, updated_at;
 `
+	var description, difficulty sql.NullString
+	if in.Description != nil {
+		description = *in.Description
+	}
+	if in.Difficulty != nil {
+		difficulty = *in.Difficulty
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("games.update.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var oldSlug string
+	if err := tx.QueryRowContext(ctx, `SELECT slug FROM games WHERE id = $1 FOR UPDATE;`, id).Scan(&oldSlug); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("games.update.lock: %w", err)
+	}
+
 	var g Game
-	err := r.db.QueryRowContext(ctx, q,
+	err = tx.QueryRowContext(ctx, q,
 		id,
 		in.Title,
 		in.Slug,
@@ -342,6 +411,10 @@ RETURNING id, title, slug, description, thumbnail, game_url, difficulty,
 		in.GameURL,
 		in.AgeCategoryID,
 		in.Free,
+		in.Description != nil,
+		description,
+		in.Difficulty != nil,
+		difficulty,
 	).Scan(
 		&g.ID,
 		&g.Title,
@@ -363,9 +436,38 @@ RETURNING id, title, slug, description, thumbnail, game_url, difficulty,
 		}
 		return nil, err
 	}
+
+	if g.Slug != oldSlug {
+		if err := recordSlugChange(ctx, tx, id, oldSlug, g.Slug); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("games.update.commit: %w", err)
+	}
+	committed = true
 	return &g, nil
 }
 
+// recordSlugChange keeps the old slug redirecting to this game. A slug that is
+// taken (back) by a live game stops being a redirect.
+func recordSlugChange(ctx context.Context, tx *sql.Tx, gameID int64, oldSlug, newSlug string) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM game_slug_history WHERE slug = $1;`, newSlug); err != nil {
+		return fmt.Errorf("games.slug_history.delete: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, `
+INSERT INTO game_slug_history (game_id, slug)
+VALUES ($1, $2)
+ON CONFLICT (slug) DO UPDATE
+SET game_id = EXCLUDED.game_id,
+    created_at = NOW();
+`, gameID, oldSlug); err != nil {
+		return fmt.Errorf("games.slug_history.insert: %w", err)
+	}
+	return nil
+}
+
 func (r *GameRepo) SetStatus(ctx context.Context, id int64, status string) (*Game, error) {
 	if id <= 0 {
 		return nil, errors.New("id is required")
@@ -442,6 +544,31 @@ RETURNING id, title, slug, description, thumbnail, game_url, difficulty,
 	return &g, nil
 }
 
+func (r *GameRepo) SetThumbnail(ctx context.Context, id int64, thumbnail sql.NullString) error {
+	if id <= 0 {
+		return errors.New("id is required")
+	}
+
+	const q = `
+UPDATE games
+SET thumbnail = $2,
+    updated_at = NOW()
+WHERE id = $1;
+`
+	res, err := r.db.ExecContext(ctx, q, id, thumbnail)
+	if err != nil {
+		return err
+	}
+	ra, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if ra == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 func (r *GameRepo) ListAdmin(ctx context.Context, filter AdminGameFilter) ([]Game, error) {
 	return r.ListAdminGames(ctx, filter)
 }
@@ -449,6 +576,27 @@ func (r *GameRepo) CountAdmin(ctx context.Context, filter AdminGameFilter) (int,
 	return r.CountAdminGames(ctx, filter)
 }
 
+func (r *GameRepo) ListIDs(ctx context.Context) ([]int64, error) {
+	rows, err := r.db.QueryContext(ctx, `SELECT id FROM games ORDER BY id;`)
+	if err != nil {
+		return nil, fmt.Errorf("games.list_ids: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("games.list_ids.scan: %w", err)
+		}
+		out = append(out, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("games.list_ids.rows: %w", err)
+	}
+	return out, nil
+}
+
 // PUBLIC
 
 func (r *GameRepo) ListBasic(ctx context.Context, limit int) ([]Game, error) {
@@ -507,7 +655,8 @@ func (r *GameRepo) GetByID(ctx context.Context, id int64) (*Game, error) {
 SELECT id, title, slug, description, thumbnail, game_url, difficulty,
        age_category_id, free, status, created_by, created_at, updated_at,
        COALESCE(edu.education_category_ids, '[]'::jsonb) AS education_category_ids,
-       COALESCE(edu.education_categories, '[]'::jsonb) AS education_categories
+       COALESCE(edu.education_categories, '[]'::jsonb) AS education_categories,
+       (SELECT COUNT(*) FROM player_favorites pf WHERE pf.game_id = games.id) AS favorite_count
 FROM games
 LEFT JOIN LATERAL (
   SELECT
@@ -546,6 +695,7 @@ LIMIT 1;
 		&g.UpdatedAt,
 		&g.EducationCategoryIDsJSON,
 		&g.EducationCategoriesJSON,
+		&g.FavoriteCount,
 	)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -556,16 +706,16 @@ LIMIT 1;
 	return &g, nil
 }
 
-func (r *GameRepo) GetByIDPublic(ctx context.Context, id int64) (*GameListItem, error) {
-	if id <= 0 {
-		return nil, errors.New("id is required")
-	}
-
-	const q = `
+// publicGameSelect is shared by every public catalog read. The edu lateral
+// also exposes category names so search can match on them.
+func (r *GameRepo) publicGameSelect() string {
+	return `
 SELECT
   g.id,
   g.title,
   g.slug,
+  g.description,
+  g.difficulty,
   g.thumbnail,
   g.game_url,
   g.age_category_id,
@@ -576,16 +726,16 @@ SELECT
   ac.max_age,
   COALESCE(pop.popularity, 0) AS play_count,
   COALESCE(edu.education_category_ids, '[]'::jsonb) AS education_category_ids,
-  COALESCE(edu.education_categories, '[]'::jsonb) AS education_categories
+  COALESCE(edu.education_categories, '[]'::jsonb) AS education_categories,
+  tm.variants AS thumbnail_variants,
+  COALESCE(rc.love_count, 0) AS love_count,
+  COALESCE(rc.like_count, 0) AS like_count,
+  COALESCE(rc.meh_count, 0) AS meh_count,
+  ` + gameRatingExpr + ` AS rating
 FROM games g
 JOIN age_categories ac ON ac.id = g.age_category_id
-LEFT JOIN (
-  SELECT ae.game_id, COUNT(*)::bigint AS popularity
-  FROM analytics_events ae
-  WHERE ae.event_name = 'game_start'
-    AND ae.created_at >= NOW() - INTERVAL '7 days'
-  GROUP BY ae.game_id
-) pop ON pop.game_id = g.id
+LEFT JOIN ` + popularitySubquery(r.popularityDays) + ` pop ON pop.game_id = g.id
+LEFT JOIN game_reaction_counts rc ON rc.game_id = g.id
 LEFT JOIN LATERAL (
   SELECT
     COALESCE(jsonb_agg(gec.education_category_id ORDER BY gec.education_category_id), '[]'::jsonb) AS education_category_ids,
@@ -598,21 +748,39 @@ LEFT JOIN LATERAL (
         ORDER BY ec.id
       ),
       '[]'::jsonb
-    ) AS education_categories
+    ) AS education_categories,
+    string_agg(ec.name, ' ') AS education_names
+  FROM game_education_categories gec
+  JOIN education_categories ec ON ec.id = gec.education_category_id
+  WHERE gec.game_id = g.id
+) edu ON TRUE
+LEFT JOIN game_media tm ON tm.game_id = g.id AND tm.kind = 'thumbnail'
+`
+}
+
+// gameRatingExpr is the top_rated sort key; unrated games sit at the prior.
+var gameRatingExpr = "COALESCE(rc.rating, " + strconv.FormatFloat(unratedGameRating, 'f', -1, 64) + ")"
+
+// publicGameCountFrom mirrors the joins of publicGameSelect that filters can
+// reference, without the popularity aggregate.
+const publicGameCountFrom = `
+FROM games g
+JOIN age_categories ac ON ac.id = g.age_category_id
+LEFT JOIN LATERAL (
+  SELECT string_agg(ec.name, ' ') AS education_names
   FROM game_education_categories gec
   JOIN education_categories ec ON ec.id = gec.education_category_id
   WHERE gec.game_id = g.id
 ) edu ON TRUE
-WHERE g.id = $1
-  AND g.status = 'active'
-LIMIT 1;
 `
 
-	var it GameListItem
-	err := r.db.QueryRowContext(ctx, q, id).Scan(
+func scanPublicGame(row interface{ Scan(...any) error }, it *GameListItem) error {
+	return row.Scan(
 		&it.ID,
 		&it.Title,
 		&it.Slug,
+		&it.Description,
+		&it.Difficulty,
 		&it.Thumbnail,
 		&it.GameURL,
 		&it.AgeCategoryID,
@@ -624,8 +792,49 @@ LIMIT 1;
 		&it.PlayCount,
 		&it.EducationCategoryIDsJSON,
 		&it.EducationCategoriesJSON,
+		&it.ThumbnailVariantsJSON,
+		&it.LoveCount,
+		&it.LikeCount,
+		&it.MehCount,
+		&it.Rating,
 	)
-	if err != nil {
+}
+
+func (r *GameRepo) GetByIDPublic(ctx context.Context, id int64) (*GameListItem, error) {
+	if id <= 0 {
+		return nil, errors.New("id is required")
+	}
+
+	q := r.publicGameSelect() + `
+WHERE g.id = $1
+  AND g.status = 'active'
+LIMIT 1;
+`
+
+	var it GameListItem
+	if err := scanPublicGame(r.db.QueryRowContext(ctx, q, id), &it); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &it, nil
+}
+
+func (r *GameRepo) GetBySlugPublic(ctx context.Context, slug string) (*GameListItem, error) {
+	if slug == "" {
+		return nil, errors.New("slug is required")
+	}
+
+	q := r.publicGameSelect() + `
+WHERE g.slug = $1
+  AND g.status = 'active'
+LIMIT 1;
+`
+
+	var it GameListItem
+	if err := scanPublicGame(r.db.QueryRowContext(ctx, q, slug), &it); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
 		}
@@ -635,25 +844,67 @@ LIMIT 1;
 	return &it, nil
 }
 
+// ResolveSlugRedirect returns the current slug of the active game that used
+// to be published under slug.
+func (r *GameRepo) ResolveSlugRedirect(ctx context.Context, slug string) (string, error) {
+	const q = `
+SELECT g.slug
+FROM game_slug_history h
+JOIN games g ON g.id = h.game_id
+WHERE h.slug = $1
+  AND g.status = 'active'
+LIMIT 1;
+`
+	var current string
+	if err := r.db.QueryRowContext(ctx, q, slug).Scan(&current); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("games.slug_history.resolve: %w", err)
+	}
+	return current, nil
+}
+
 type GameListSort string
 
 const (
-	GameSortNewest  GameListSort = "newest"
-	GameSortPopular GameListSort = "popular"
+	GameSortNewest    GameListSort = "newest"
+	GameSortPopular   GameListSort = "popular"
+	GameSortRelevance GameListSort = "relevance"
+	GameSortTopRated  GameListSort = "top_rated"
 )
 
+// GameListFilter values within one slice are ORed; different fields are ANDed.
 type GameListFilter struct {
-	AgeCategoryID       sql.NullInt64
-	EducationCategoryID sql.NullInt64
-	Sort                GameListSort
-	Page                int
-	Limit               int
+	AgeCategoryIDs       []int64
+	EducationCategoryIDs []int64
+	Difficulties         []string
+	Free                 sql.NullBool
+	Age                  sql.NullInt64
+	Query                string
+	Sort                 GameListSort
+	Page                 int
+	Limit                int
+	// After switches to keyset pagination; Page is ignored when set.
+	After *GameListCursor
+	// SearchLocale makes Query match titles translated into it as well.
+	SearchLocale string
+}
+
+// GameListCursor is the sort key of the last row of the previous page.
+type GameListCursor struct {
+	CreatedAt time.Time
+	PlayCount int64
+	Rating    float64
+	ID        int64
 }
 
 type GameListItem struct {
 	ID            int64
 	Title         string
 	Slug          string
+	Description   sql.NullString
+	Difficulty    sql.NullString
 	Thumbnail     sql.NullString
 	GameURL       sql.NullString
 	AgeCategoryID int64
@@ -663,9 +914,14 @@ type GameListItem struct {
 	MinAge        sql.NullInt64
 	MaxAge        sql.NullInt64
 	PlayCount     int64
+	LoveCount     int
+	LikeCount     int
+	MehCount      int
+	Rating        float64
 
 	EducationCategoryIDsJSON []byte
 	EducationCategoriesJSON  []byte
+	ThumbnailVariantsJSON    []byte
 }
 
 func (f *GameListFilter) normalize() {
@@ -678,135 +934,124 @@ func (f *GameListFilter) normalize() {
 	if f.Limit > 100 {
 		f.Limit = 100
 	}
+	f.Query = strings.TrimSpace(f.Query)
 	switch f.Sort {
-	case GameSortNewest, GameSortPopular:
+	case GameSortNewest, GameSortPopular, GameSortTopRated:
+	case GameSortRelevance:
+		if f.Query == "" {
+			f.Sort = GameSortNewest
+		}
 	default:
 		f.Sort = GameSortNewest
 	}
 }
 
-func (r *GameRepo) ListPublic(ctx context.Context, filter GameListFilter) ([]GameListItem, error) {
-	filter.normalize()
-	offset := (filter.Page - 1) * filter.Limit
+// publicGameWhere collects the filter clauses and their positional args so
+// list and count queries stay in sync.
+type publicGameWhere struct {
+	clauses []string
+	args    []any
+	rank    string
+}
 
-	const qNewest = `
-SELECT
-  g.id,
-  g.title,
-  g.slug,
-  g.thumbnail,
-  g.game_url,
-  g.age_category_id,
-  g.free,
-  g.created_at,
-  ac.label AS age_label,
-  ac.min_age,
-  ac.max_age,
-  COALESCE(pop.popularity, 0) AS play_count,
-  COALESCE(edu.education_category_ids, '[]'::jsonb) AS education_category_ids,
-  COALESCE(edu.education_categories, '[]'::jsonb) AS education_categories
-FROM games g
-JOIN age_categories ac ON ac.id = g.age_category_id
-LEFT JOIN (
-  SELECT ae.game_id, COUNT(*)::bigint AS popularity
-  FROM analytics_events ae
-  WHERE ae.event_name = 'game_start'
-    AND ae.created_at >= NOW() - INTERVAL '7 days'
-  GROUP BY ae.game_id
-) pop ON pop.game_id = g.id
-LEFT JOIN LATERAL (
-  SELECT
-    COALESCE(jsonb_agg(gec.education_category_id ORDER BY gec.education_category_id), '[]'::jsonb) AS education_category_ids,
-    COALESCE(
-      jsonb_agg(
-        jsonb_build_object(
-          'id', ec.id,
-          'name', ec.name
-        )
-        ORDER BY ec.id
-      ),
-      '[]'::jsonb
-    ) AS education_categories
-  FROM game_education_categories gec
-  JOIN education_categories ec ON ec.id = gec.education_category_id
-  WHERE gec.game_id = g.id
-) edu ON TRUE
-WHERE g.status = 'active'
-  AND ($1::bigint IS NULL OR g.age_category_id = $1::bigint)
-  AND ($2::bigint IS NULL OR EXISTS (
-    SELECT 1
-    FROM game_education_categories gec
-    WHERE gec.game_id = g.id
-      AND gec.education_category_id = $2::bigint
-  ))
-ORDER BY g.created_at DESC
-LIMIT $3 OFFSET $4;
-`
-	const qPopular = `
-SELECT
-  g.id,
-  g.title,
-  g.slug,
-  g.thumbnail,
-  g.game_url,
-  g.age_category_id,
-  g.free,
-  g.created_at,
-  ac.label AS age_label,
-  ac.min_age,
-  ac.max_age,
-  COALESCE(pop.popularity, 0) AS play_count,
-  COALESCE(edu.education_category_ids, '[]'::jsonb) AS education_category_ids,
-  COALESCE(edu.education_categories, '[]'::jsonb) AS education_categories
-FROM games g
-JOIN age_categories ac ON ac.id = g.age_category_id
-LEFT JOIN (
-  SELECT ae.game_id, COUNT(*)::bigint AS popularity
-  FROM analytics_events ae
-  WHERE ae.event_name = 'game_start'
-    AND ae.created_at >= NOW() - INTERVAL '7 days'
-  GROUP BY ae.game_id
-) pop ON pop.game_id = g.id
-LEFT JOIN LATERAL (
-  SELECT
-    COALESCE(jsonb_agg(gec.education_category_id ORDER BY gec.education_category_id), '[]'::jsonb) AS education_category_ids,
-    COALESCE(
-      jsonb_agg(
-        jsonb_build_object(
-          'id', ec.id,
-          'name', ec.name
-        )
-        ORDER BY ec.id
-      ),
-      '[]'::jsonb
-    ) AS education_categories
-  FROM game_education_categories gec
-  JOIN education_categories ec ON ec.id = gec.education_category_id
-  WHERE gec.game_id = g.id
-) edu ON TRUE
-WHERE g.status = 'active'
-  AND ($1::bigint IS NULL OR g.age_category_id = $1::bigint)
-  AND ($2::bigint IS NULL OR EXISTS (
+func (w *publicGameWhere) arg(v any) string {
+	w.args = append(w.args, v)
+	return "$" + strconv.Itoa(len(w.args))
+}
+
+func (w *publicGameWhere) argList(vals []any) string {
+	ph := make([]string, 0, len(vals))
+	for _, v := range vals {
+		ph = append(ph, w.arg(v))
+	}
+	return strings.Join(ph, ", ")
+}
+
+func (w *publicGameWhere) sql() string {
+	return "WHERE " + strings.Join(w.clauses, "\n  AND ")
+}
+
+func buildPublicGameWhere(filter GameListFilter) *publicGameWhere {
+	w := &publicGameWhere{clauses: []string{"g.status = 'active'"}}
+
+	if len(filter.AgeCategoryIDs) > 0 {
+		w.clauses = append(w.clauses, "g.age_category_id IN ("+w.argList(int64Args(filter.AgeCategoryIDs))+")")
+	}
+	if len(filter.EducationCategoryIDs) > 0 {
+		w.clauses = append(w.clauses, `EXISTS (
     SELECT 1
     FROM game_education_categories gec
     WHERE gec.game_id = g.id
-      AND gec.education_category_id = $2::bigint
-  ))
-ORDER BY COALESCE(pop.popularity, 0) DESC, g.created_at DESC, g.id DESC
-LIMIT $3 OFFSET $4;
-`
+      AND gec.education_category_id IN (`+w.argList(int64Args(filter.EducationCategoryIDs))+`)
+  )`)
+	}
+	if len(filter.Difficulties) > 0 {
+		w.clauses = append(w.clauses, "g.difficulty::text IN ("+w.argList(stringArgs(filter.Difficulties))+")")
+	}
+	if filter.Free.Valid {
+		w.clauses = append(w.clauses, "g.free = "+w.arg(filter.Free.Bool))
+	}
+	if filter.Age.Valid {
+		age := w.arg(filter.Age.Int64)
+		w.clauses = append(w.clauses, "ac.min_age <= "+age+" AND ac.max_age >= "+age)
+	}
+	if filter.Query != "" {
+		doc := "(g.search_vector || setweight(to_tsvector('simple', ac.label || ' ' || COALESCE(edu.education_names, '')), 'B'))"
+		raw := w.arg(filter.Query)
+		fuzzy := "word_similarity(" + raw + ", g.title)"
+		if filter.SearchLocale != "" {
+			title := "(SELECT gt.title FROM game_translations gt WHERE gt.game_id = g.id AND gt.locale = " + w.arg(filter.SearchLocale) + ")"
+			doc = "(" + doc + " || setweight(to_tsvector('simple', COALESCE(" + title + ", '')), 'A'))"
+			fuzzy = "GREATEST(" + fuzzy + ", COALESCE(word_similarity(" + raw + ", " + title + "), 0))"
+		}
+		if tsq := searchTSQuery(filter.Query); tsq != "" {
+			query := "to_tsquery('simple', " + w.arg(tsq) + ")"
+			w.clauses = append(w.clauses, "("+doc+" @@ "+query+" OR "+fuzzy+" >= "+w.arg(searchFuzzyThreshold)+")")
+			w.rank = "(ts_rank(" + doc + ", " + query + ") + " + fuzzy + ")"
+		} else {
+			w.clauses = append(w.clauses, fuzzy+" >= "+w.arg(searchFuzzyThreshold))
+			w.rank = fuzzy
+		}
+	}
+	return w
+}
+
+func (r *GameRepo) ListPublic(ctx context.Context, filter GameListFilter) ([]GameListItem, error) {
+	filter.normalize()
+	offset := (filter.Page - 1) * filter.Limit
 
-	query := qNewest
-	if filter.Sort == GameSortPopular {
-		query = qPopular
+	w := buildPublicGameWhere(filter)
+	if filter.After != nil {
+		offset = 0
+		switch filter.Sort {
+		case GameSortPopular:
+			w.clauses = append(w.clauses, "(COALESCE(pop.popularity, 0), g.created_at, g.id) < ("+
+				w.arg(filter.After.PlayCount)+"::bigint, "+w.arg(filter.After.CreatedAt)+"::timestamptz, "+w.arg(filter.After.ID)+"::bigint)")
+		case GameSortTopRated:
+			w.clauses = append(w.clauses, "("+gameRatingExpr+", g.created_at, g.id) < ("+
+				w.arg(filter.After.Rating)+"::double precision, "+w.arg(filter.After.CreatedAt)+"::timestamptz, "+w.arg(filter.After.ID)+"::bigint)")
+		default:
+			w.clauses = append(w.clauses, "(g.created_at, g.id) < ("+
+				w.arg(filter.After.CreatedAt)+"::timestamptz, "+w.arg(filter.After.ID)+"::bigint)")
+		}
 	}
 
-	rows, err := r.db.QueryContext(ctx, query,
-		filter.AgeCategoryID,
-		filter.EducationCategoryID,
-		filter.Limit,
-		offset,
-	)
+	orderBy := "g.created_at DESC, g.id DESC"
+	switch {
+	case filter.Sort == GameSortPopular:
+		orderBy = "COALESCE(pop.popularity, 0) DESC, g.created_at DESC, g.id DESC"
+	case filter.Sort == GameSortTopRated:
+		orderBy = gameRatingExpr + " DESC, g.created_at DESC, g.id DESC"
+	case filter.Sort == GameSortRelevance && w.rank != "":
+		orderBy = w.rank + " DESC, g.created_at DESC, g.id DESC"
+	}
+
+	q := r.publicGameSelect() + w.sql() + `
+ORDER BY ` + orderBy + `
+LIMIT ` + w.arg(filter.Limit) + ` OFFSET ` + w.arg(offset) + `;
+`
+
+	rows, err := r.db.QueryContext(ctx, q, w.args...)
 	if err != nil {
 		return nil, err
 	}
@@ -815,22 +1060,7 @@ LIMIT $3 OFFSET $4;
 	out := make([]GameListItem, 0, filter.Limit)
 	for rows.Next() {
 		var it GameListItem
-		if err := rows.Scan(
-			&it.ID,
-			&it.Title,
-			&it.Slug,
-			&it.Thumbnail,
-			&it.GameURL,
-			&it.AgeCategoryID,
-			&it.Free,
-			&it.CreatedAt,
-			&it.AgeLabel,
-			&it.MinAge,
-			&it.MaxAge,
-			&it.PlayCount,
-			&it.EducationCategoryIDsJSON,
-			&it.EducationCategoriesJSON,
-		); err != nil {
+		if err := scanPublicGame(rows, &it); err != nil {
 			return nil, err
 		}
 		out = append(out, it)
@@ -841,26 +1071,30 @@ LIMIT $3 OFFSET $4;
 	return out, nil
 }
 
+func int64Args(vals []int64) []any {
+	out := make([]any, 0, len(vals))
+	for _, v := range vals {
+		out = append(out, v)
+	}
+	return out
+}
+
+func stringArgs(vals []string) []any {
+	out := make([]any, 0, len(vals))
+	for _, v := range vals {
+		out = append(out, v)
+	}
+	return out
+}
+
 func (r *GameRepo) CountPublic(ctx context.Context, filter GameListFilter) (int, error) {
 	filter.normalize()
 
-	const q = `
-SELECT COUNT(*)
-FROM games g
-WHERE g.status = 'active'
-  AND ($1::bigint IS NULL OR g.age_category_id = $1::bigint)
-  AND ($2::bigint IS NULL OR EXISTS (
-    SELECT 1
-    FROM game_education_categories gec
-    WHERE gec.game_id = g.id
-      AND gec.education_category_id = $2::bigint
-  ));
-`
+	w := buildPublicGameWhere(filter)
+	q := "SELECT COUNT(*)" + publicGameCountFrom + w.sql() + ";"
+
 	var total int
-	if err := r.db.QueryRowContext(ctx, q,
-		filter.AgeCategoryID,
-		filter.EducationCategoryID,
-	).Scan(&total); err != nil {
+	if err := r.db.QueryRowContext(ctx, q, w.args...).Scan(&total); err != nil {
 		return 0, err
 	}
 	return total, nil
@@ -0,0 +1,173 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// gameScheduleLockKey is the pg advisory lock that keeps replicas from
+// applying the same schedules concurrently.
+const gameScheduleLockKey int64 = 0x6b705f7363686564 // "kp_sched"
+
+type GameSchedule struct {
+	GameID      int64
+	Title       string
+	Slug        string
+	Status      string
+	PublishAt   sql.NullTime
+	UnpublishAt sql.NullTime
+	UpdatedAt   time.Time
+}
+
+const gameScheduleColumns = `id, title, slug, status, publish_at, unpublish_at, updated_at`
+
+func scanGameSchedule(row interface{ Scan(...any) error }, s *GameSchedule) error {
+	return row.Scan(&s.GameID, &s.Title, &s.Slug, &s.Status, &s.PublishAt, &s.UnpublishAt, &s.UpdatedAt)
+}
+
+func (r *GameRepo) GetSchedule(ctx context.Context, gameID int64) (*GameSchedule, error) {
+	q := `SELECT ` + gameScheduleColumns + ` FROM games WHERE id = $1;`
+	var s GameSchedule
+	if err := scanGameSchedule(r.db.QueryRowContext(ctx, q, gameID), &s); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("games.schedule.get: %w", err)
+	}
+	return &s, nil
+}
+
+// SetSchedule replaces both times; an invalid NullTime clears that side.
+func (r *GameRepo) SetSchedule(ctx context.Context, gameID int64, publishAt, unpublishAt sql.NullTime) (*GameSchedule, error) {
+	q := `
+UPDATE games
+SET publish_at = $2,
+    unpublish_at = $3,
+    updated_at = NOW()
+WHERE id = $1
+RETURNING ` + gameScheduleColumns + `;`
+	var s GameSchedule
+	if err := scanGameSchedule(r.db.QueryRowContext(ctx, q, gameID, publishAt, unpublishAt), &s); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("games.schedule.set: %w", err)
+	}
+	return &s, nil
+}
+
+// ListSchedules returns games with a pending publish or unpublish, soonest
+// first.
+func (r *GameRepo) ListSchedules(ctx context.Context, limit int) ([]GameSchedule, error) {
+	if limit <= 0 || limit > 200 {
+		limit = 100
+	}
+
+	q := `
+SELECT ` + gameScheduleColumns + `
+FROM games
+WHERE publish_at IS NOT NULL OR unpublish_at IS NOT NULL
+ORDER BY LEAST(publish_at, unpublish_at) ASC, id ASC
+LIMIT $1;`
+	rows, err := r.db.QueryContext(ctx, q, limit)
+	if err != nil {
+		return nil, fmt.Errorf("games.schedule.list: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]GameSchedule, 0)
+	for rows.Next() {
+		var s GameSchedule
+		if err := scanGameSchedule(rows, &s); err != nil {
+			return nil, fmt.Errorf("games.schedule.list.scan: %w", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("games.schedule.list.rows: %w", err)
+	}
+	return out, nil
+}
+
+type AppliedSchedules struct {
+	Published   []int64
+	Unpublished []int64
+	// Skipped is true when another replica held the scheduler lock.
+	Skipped bool
+}
+
+// ApplyDueSchedules flips every game whose publish_at/unpublish_at has passed
+// and clears the applied time. Publishes run first so a window that elapsed
+// entirely between ticks still ends unpublished. Archived games are left
+// alone.
+func (r *GameRepo) ApplyDueSchedules(ctx context.Context, now time.Time) (*AppliedSchedules, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("games.schedule.apply.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var locked bool
+	if err := tx.QueryRowContext(ctx, `SELECT pg_try_advisory_xact_lock($1);`, gameScheduleLockKey).Scan(&locked); err != nil {
+		return nil, fmt.Errorf("games.schedule.apply.lock: %w", err)
+	}
+	if !locked {
+		return &AppliedSchedules{Skipped: true}, nil
+	}
+
+	published, err := queryIDs(ctx, tx, `
+UPDATE games
+SET status = 'active',
+    publish_at = NULL,
+    updated_at = NOW()
+WHERE publish_at <= $1
+  AND status <> 'archived'
+RETURNING id;`, now)
+	if err != nil {
+		return nil, fmt.Errorf("games.schedule.apply.publish: %w", err)
+	}
+
+	unpublished, err := queryIDs(ctx, tx, `
+UPDATE games
+SET status = 'draft',
+    unpublish_at = NULL,
+    updated_at = NOW()
+WHERE unpublish_at <= $1
+  AND status <> 'archived'
+RETURNING id;`, now)
+	if err != nil {
+		return nil, fmt.Errorf("games.schedule.apply.unpublish: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("games.schedule.apply.commit: %w", err)
+	}
+	committed = true
+	return &AppliedSchedules{Published: published, Unpublished: unpublished}, nil
+}
+
+func queryIDs(ctx context.Context, tx *sql.Tx, q string, args ...any) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]int64, 0)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		out = append(out, id)
+	}
+	return out, rows.Err()
+}
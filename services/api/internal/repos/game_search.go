@@ -0,0 +1,86 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"unicode"
+)
+
+// searchFuzzyThreshold is the pg_trgm word_similarity a title needs to match
+// a misspelled query ("dinosor" -> "Dinosaur Dig").
+const searchFuzzyThreshold = 0.3
+
+const maxSearchTerms = 8
+
+// searchTSQuery turns free text into a prefix tsquery ("dino mat" ->
+// "dino:* & mat:*"). Only letters and digits survive, so the result is
+// always valid to_tsquery input.
+func searchTSQuery(q string) string {
+	terms := strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(terms) > maxSearchTerms {
+		terms = terms[:maxSearchTerms]
+	}
+	for i, t := range terms {
+		terms[i] = t + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+type GameSuggestion struct {
+	ID                    int64
+	Title                 string
+	Slug                  string
+	Thumbnail             sql.NullString
+	ThumbnailVariantsJSON []byte
+}
+
+// Suggest is the typeahead lookup: title prefix hits first, then fuzzy
+// title matches. With a locale, titles translated into it match too and are
+// returned in place of the base title.
+func (r *GameRepo) Suggest(ctx context.Context, q string, locale string, limit int) ([]GameSuggestion, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return []GameSuggestion{}, nil
+	}
+	if limit <= 0 {
+		limit = 8
+	}
+
+	const query = `
+SELECT g.id, COALESCE(gt.title, g.title), g.slug, g.thumbnail, tm.variants
+FROM games g
+LEFT JOIN game_translations gt ON gt.game_id = g.id AND gt.locale = $5
+LEFT JOIN game_media tm ON tm.game_id = g.id AND tm.kind = 'thumbnail'
+WHERE g.status = 'active'
+  AND (
+    to_tsvector('simple', g.title || ' ' || COALESCE(gt.title, '')) @@ to_tsquery('simple', NULLIF($2, ''))
+    OR word_similarity($1, g.title) >= $3
+    OR word_similarity($1, gt.title) >= $3
+  )
+ORDER BY strpos(lower(COALESCE(gt.title, g.title)), lower($1)) = 1 DESC,
+         GREATEST(word_similarity($1, g.title), COALESCE(word_similarity($1, gt.title), 0)) DESC,
+         COALESCE(gt.title, g.title) ASC
+LIMIT $4;
+`
+	rows, err := r.db.QueryContext(ctx, query, q, searchTSQuery(q), searchFuzzyThreshold, limit, locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	out := make([]GameSuggestion, 0, limit)
+	for rows.Next() {
+		var it GameSuggestion
+		if err := rows.Scan(&it.ID, &it.Title, &it.Slug, &it.Thumbnail, &it.ThumbnailVariantsJSON); err != nil {
+			return nil, err
+		}
+		out = append(out, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
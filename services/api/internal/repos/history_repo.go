@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -23,9 +24,21 @@ func NewPlayerHistoryRepo(db *sql.DB) *PlayerHistoryRepo {
 	return &PlayerHistoryRepo{db: db}
 }
 
+// profileEventFilter is the analytics_events.event_data->>'profile_id' value
+// to match, or "" for every event of the account.
+func profileEventFilter(profileID int64) string {
+	if profileID <= 0 {
+		return ""
+	}
+	return strconv.FormatInt(profileID, 10)
+}
+
+// ListByPlayerID lists the account's sessions; with profileID > 0 only that
+// profile's.
 func (r *PlayerHistoryRepo) ListByPlayerID(
 	ctx context.Context,
 	playerID string,
+	profileID int64,
 	page int,
 	limit int,
 ) ([]PlayerHistoryRow, int, error) {
@@ -53,6 +66,8 @@ WITH player_events AS (
     MAX(ae.created_at) AS played_at
   FROM analytics_events ae
   WHERE COALESCE(ae.event_data->>'player_id', '') = $1
+    AND ($2 = '' OR ae.event_data->>'profile_id' = $2)
+    AND NOT ae.preview
     AND (ae.event_name = 'game_start' OR ae.event_name LIKE 'gameplay%')
   GROUP BY ae.session_id, ae.game_id
 )
@@ -60,7 +75,7 @@ SELECT COUNT(*) FROM player_events;
 `
 
 	var total int
-	if err := r.db.QueryRowContext(ctx, countQ, playerID).Scan(&total); err != nil {
+	if err := r.db.QueryRowContext(ctx, countQ, playerID, profileEventFilter(profileID)).Scan(&total); err != nil {
 		return nil, 0, fmt.Errorf("player_history.count: %w", err)
 	}
 
@@ -76,6 +91,8 @@ WITH player_events AS (
     MAX(ae.created_at) AS played_at
   FROM analytics_events ae
   WHERE COALESCE(ae.event_data->>'player_id', '') = $1
+    AND ($4 = '' OR ae.event_data->>'profile_id' = $4)
+    AND NOT ae.preview
     AND (ae.event_name = 'game_start' OR ae.event_name LIKE 'gameplay%')
   GROUP BY ae.session_id, ae.game_id
 ),
@@ -102,7 +119,7 @@ ORDER BY pe.played_at DESC, pe.game_id DESC
 LIMIT $2 OFFSET $3;
 `
 
-	rows, err := r.db.QueryContext(ctx, listQ, playerID, limit, offset)
+	rows, err := r.db.QueryContext(ctx, listQ, playerID, limit, offset, profileEventFilter(profileID))
 	if err != nil {
 		return nil, 0, fmt.Errorf("player_history.list: %w", err)
 	}
@@ -127,3 +144,75 @@ LIMIT $2 OFFSET $3;
 
 	return out, total, nil
 }
+
+type PlayerRecentGameRow struct {
+	GameID       int64
+	Title        string
+	Slug         string
+	Thumbnail    sql.NullString
+	LastPlayedAt time.Time
+	Sessions     int
+}
+
+// ListRecentGames collapses the player's sessions to one row per game,
+// most recently played first. Only games that can still be played are
+// returned.
+func (r *PlayerHistoryRepo) ListRecentGames(ctx context.Context, playerID string, profileID int64, limit int) ([]PlayerRecentGameRow, error) {
+	playerID = strings.TrimSpace(playerID)
+	if playerID == "" {
+		return nil, ErrInvalidPlayerID
+	}
+
+	const q = `
+WITH player_games AS (
+  SELECT
+    ae.game_id,
+    MAX(ae.created_at) AS last_played_at,
+    COUNT(DISTINCT ae.session_id) AS sessions
+  FROM analytics_events ae
+  WHERE COALESCE(ae.event_data->>'player_id', '') = $1
+    AND ($3 = '' OR ae.event_data->>'profile_id' = $3)
+    AND NOT ae.preview
+    AND (ae.event_name = 'game_start' OR ae.event_name LIKE 'gameplay%')
+  GROUP BY ae.game_id
+)
+SELECT
+  pg.game_id,
+  g.title,
+  g.slug,
+  g.thumbnail,
+  pg.last_played_at,
+  pg.sessions
+FROM player_games pg
+JOIN games g
+  ON g.id = pg.game_id
+ AND g.status = 'active'
+ORDER BY pg.last_played_at DESC, pg.game_id DESC
+LIMIT $2;
+`
+	rows, err := r.db.QueryContext(ctx, q, playerID, limit, profileEventFilter(profileID))
+	if err != nil {
+		return nil, fmt.Errorf("player_history.recent: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]PlayerRecentGameRow, 0, limit)
+	for rows.Next() {
+		var row PlayerRecentGameRow
+		if err := rows.Scan(
+			&row.GameID,
+			&row.Title,
+			&row.Slug,
+			&row.Thumbnail,
+			&row.LastPlayedAt,
+			&row.Sessions,
+		); err != nil {
+			return nil, fmt.Errorf("player_history.recent.scan: %w", err)
+		}
+		out = append(out, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("player_history.recent.rows: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,60 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const defaultPopularityWindowDays = 7
+
+type PopularityRepo struct {
+	db *sql.DB
+}
+
+func NewPopularityRepo(db *sql.DB) *PopularityRepo {
+	return &PopularityRepo{db: db}
+}
+
+// Reconcile recounts the rollup from analytics_events for every day since
+// the given date. It backfills history and repairs counters that fell
+// behind; counters for days with no remaining events are left untouched.
+// The recount comes from the statement's snapshot, so it never lowers a
+// counter: a game_start committed after the snapshot has already been added
+// by its own insert and would otherwise be lost until the next run.
+func (r *PopularityRepo) Reconcile(ctx context.Context, since time.Time) (int64, error) {
+	const q = `
+INSERT INTO game_play_daily (game_id, day, plays)
+SELECT ae.game_id, (ae.created_at AT TIME ZONE 'UTC')::date, COUNT(*)::bigint
+FROM analytics_events ae
+JOIN games g ON g.id = ae.game_id
+WHERE ae.event_name = 'game_start'
+  AND NOT ae.preview
+  AND ae.created_at >= $1
+GROUP BY 1, 2
+ON CONFLICT (game_id, day) DO UPDATE
+SET plays = GREATEST(game_play_daily.plays, EXCLUDED.plays);
+`
+	res, err := r.db.ExecContext(ctx, q, since.UTC().Truncate(24*time.Hour))
+	if err != nil {
+		return 0, fmt.Errorf("game_play_daily.reconcile: %w", err)
+	}
+	n, _ := res.RowsAffected()
+	return n, nil
+}
+
+// popularitySubquery sums the rollup over the last days (today included).
+// days is an int, so inlining it is safe and keeps callers' $n numbering.
+func popularitySubquery(days int) string {
+	if days <= 0 {
+		days = defaultPopularityWindowDays
+	}
+	return `(
+  SELECT gpd.game_id, SUM(gpd.plays)::bigint AS popularity
+  FROM game_play_daily gpd
+  WHERE gpd.day > (NOW() AT TIME ZONE 'UTC')::date - ` + strconv.Itoa(days) + `
+  GROUP BY gpd.game_id
+)`
+}
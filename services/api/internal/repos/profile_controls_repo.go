@@ -0,0 +1,157 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ProfileControls are a child profile's parental controls. Empty allow
+// lists mean no restriction. Quiet hours are minutes after local midnight
+// in TimeZone.
+type ProfileControls struct {
+	AllowedAgeCategoryIDs       []int64
+	AllowedEducationCategoryIDs []int64
+	BlockedGameIDs              []int64
+	DailyMinutes                sql.NullInt64
+	QuietStart                  sql.NullInt64
+	QuietEnd                    sql.NullInt64
+	TimeZone                    string
+	UpdatedAt                   sql.NullTime
+}
+
+type ProfileControlsRepo struct {
+	db *sql.DB
+}
+
+func NewProfileControlsRepo(db *sql.DB) *ProfileControlsRepo {
+	return &ProfileControlsRepo{db: db}
+}
+
+// Get returns the profile's controls; a profile nobody configured yet gets
+// the unrestricted defaults.
+func (r *ProfileControlsRepo) Get(ctx context.Context, profileID int64) (*ProfileControls, error) {
+	const q = `
+SELECT
+  pc.daily_minutes,
+  pc.quiet_start,
+  pc.quiet_end,
+  COALESCE(pc.time_zone, 'UTC'),
+  pc.updated_at,
+  COALESCE((SELECT jsonb_agg(a.age_category_id ORDER BY a.age_category_id)
+            FROM profile_allowed_age_categories a WHERE a.profile_id = p.id), '[]'::jsonb),
+  COALESCE((SELECT jsonb_agg(e.education_category_id ORDER BY e.education_category_id)
+            FROM profile_allowed_education_categories e WHERE e.profile_id = p.id), '[]'::jsonb),
+  COALESCE((SELECT jsonb_agg(b.game_id ORDER BY b.game_id)
+            FROM profile_blocked_games b WHERE b.profile_id = p.id), '[]'::jsonb)
+FROM players p
+LEFT JOIN profile_controls pc ON pc.profile_id = p.id
+WHERE p.id = $1;`
+
+	var c ProfileControls
+	var ageJSON, eduJSON, blockedJSON []byte
+	if err := r.db.QueryRowContext(ctx, q, profileID).Scan(
+		&c.DailyMinutes, &c.QuietStart, &c.QuietEnd, &c.TimeZone, &c.UpdatedAt,
+		&ageJSON, &eduJSON, &blockedJSON,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("profile_controls.get: %w", err)
+	}
+	for _, it := range []struct {
+		raw []byte
+		dst *[]int64
+	}{
+		{ageJSON, &c.AllowedAgeCategoryIDs},
+		{eduJSON, &c.AllowedEducationCategoryIDs},
+		{blockedJSON, &c.BlockedGameIDs},
+	} {
+		if err := json.Unmarshal(it.raw, it.dst); err != nil {
+			return nil, fmt.Errorf("profile_controls.get.decode: %w", err)
+		}
+	}
+	return &c, nil
+}
+
+// Replace overwrites every control of the profile in one transaction.
+func (r *ProfileControlsRepo) Replace(ctx context.Context, profileID int64, c ProfileControls) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("profile_controls.replace.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, `SELECT id FROM players WHERE id = $1 FOR UPDATE;`, profileID).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("profile_controls.replace.lock: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `
+INSERT INTO profile_controls (profile_id, daily_minutes, quiet_start, quiet_end, time_zone)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (profile_id) DO UPDATE
+SET daily_minutes = EXCLUDED.daily_minutes,
+    quiet_start = EXCLUDED.quiet_start,
+    quiet_end = EXCLUDED.quiet_end,
+    time_zone = EXCLUDED.time_zone,
+    updated_at = NOW();`,
+		profileID, c.DailyMinutes, c.QuietStart, c.QuietEnd, c.TimeZone,
+	); err != nil {
+		return fmt.Errorf("profile_controls.replace.upsert: %w", err)
+	}
+
+	for _, list := range []struct {
+		table, column string
+		ids           []int64
+	}{
+		{"profile_allowed_age_categories", "age_category_id", c.AllowedAgeCategoryIDs},
+		{"profile_allowed_education_categories", "education_category_id", c.AllowedEducationCategoryIDs},
+		{"profile_blocked_games", "game_id", c.BlockedGameIDs},
+	} {
+		if err := replaceProfileIDList(ctx, tx, list.table, list.column, profileID, list.ids); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("profile_controls.replace.commit: %w", err)
+	}
+	committed = true
+	return nil
+}
+
+// replaceProfileIDList sets the ids of one of the profile's list tables.
+// table and column are constants, never user input.
+func replaceProfileIDList(ctx context.Context, tx *sql.Tx, table, column string, profileID int64, ids []int64) error {
+	if _, err := tx.ExecContext(ctx, `DELETE FROM `+table+` WHERE profile_id = $1;`, profileID); err != nil {
+		return fmt.Errorf("%s.replace.delete: %w", table, err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(ids)+1)
+	args = append(args, profileID)
+	valueParts := make([]string, 0, len(ids))
+	for i, id := range ids {
+		args = append(args, id)
+		valueParts = append(valueParts, fmt.Sprintf("($1, $%d)", i+2))
+	}
+	q := `INSERT INTO ` + table + ` (profile_id, ` + column + `) VALUES ` + strings.Join(valueParts, ", ") + `;`
+	if _, err := tx.ExecContext(ctx, q, args...); err != nil {
+		return fmt.Errorf("%s.replace.insert: %w", table, err)
+	}
+	return nil
+}
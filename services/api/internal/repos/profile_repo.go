@@ -0,0 +1,186 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrProfileLimitReached = errors.New("profile limit reached")
+
+// Profile is a child profile (a players row) owned by a parent account.
+type Profile struct {
+	ID            int64
+	Nickname      string
+	BirthYear     sql.NullInt64
+	AgeCategoryID sql.NullInt64
+	AgeLabel      sql.NullString
+	Avatar        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// ProfileInput holds every editable field; updates replace them all.
+type ProfileInput struct {
+	Nickname      string
+	BirthYear     sql.NullInt64
+	AgeCategoryID sql.NullInt64
+	Avatar        string
+}
+
+type ProfileRepo struct {
+	db *sql.DB
+}
+
+func NewProfileRepo(db *sql.DB) *ProfileRepo {
+	return &ProfileRepo{db: db}
+}
+
+// Profiles are always addressed through the parent's public id, so one
+// account can never reach another's profiles.
+const profileSelect = `
+SELECT p.id, p.nickname, p.birth_year, p.age_category_id, ac.label, p.avatar, p.created_at, p.updated_at
+FROM players p
+JOIN users u ON u.id = p.user_id
+LEFT JOIN age_categories ac ON ac.id = p.age_category_id
+WHERE u.public_id = $1
+  AND u.role = 'player'`
+
+func scanProfile(row interface{ Scan(...any) error }, p *Profile) error {
+	return row.Scan(&p.ID, &p.Nickname, &p.BirthYear, &p.AgeCategoryID, &p.AgeLabel, &p.Avatar, &p.CreatedAt, &p.UpdatedAt)
+}
+
+func (r *ProfileRepo) ListByPlayerID(ctx context.Context, playerID string) ([]Profile, error) {
+	rows, err := r.db.QueryContext(ctx, profileSelect+`
+ORDER BY p.id ASC;`, playerID)
+	if err != nil {
+		return nil, fmt.Errorf("players.list: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]Profile, 0)
+	for rows.Next() {
+		var p Profile
+		if err := scanProfile(rows, &p); err != nil {
+			return nil, fmt.Errorf("players.list.scan: %w", err)
+		}
+		out = append(out, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("players.list.rows: %w", err)
+	}
+	return out, nil
+}
+
+func (r *ProfileRepo) GetForPlayer(ctx context.Context, playerID string, profileID int64) (*Profile, error) {
+	var p Profile
+	if err := scanProfile(r.db.QueryRowContext(ctx, profileSelect+`
+  AND p.id = $2;`, playerID, profileID), &p); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("players.get: %w", err)
+	}
+	return &p, nil
+}
+
+// Create adds a profile unless the account already has max. The parent's
+// users row is locked so concurrent creates cannot overshoot.
+func (r *ProfileRepo) Create(ctx context.Context, playerID string, in ProfileInput, max int) (int64, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("players.create.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var userID int64
+	if err := tx.QueryRowContext(ctx,
+		`SELECT id FROM users WHERE public_id = $1 AND role = 'player' FOR UPDATE;`,
+		playerID,
+	).Scan(&userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("players.create.lock: %w", err)
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM players WHERE user_id = $1;`, userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("players.create.count: %w", err)
+	}
+	if count >= max {
+		return 0, ErrProfileLimitReached
+	}
+
+	var id int64
+	if err := tx.QueryRowContext(ctx, `
+INSERT INTO players (user_id, nickname, birth_year, age_category_id, avatar)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id;`, userID, in.Nickname, in.BirthYear, in.AgeCategoryID, in.Avatar).Scan(&id); err != nil {
+		return 0, fmt.Errorf("players.create.insert: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("players.create.commit: %w", err)
+	}
+	committed = true
+	return id, nil
+}
+
+func (r *ProfileRepo) Update(ctx context.Context, playerID string, profileID int64, in ProfileInput) error {
+	const q = `
+UPDATE players p
+SET nickname = $3,
+    birth_year = $4,
+    age_category_id = $5,
+    avatar = $6,
+    updated_at = NOW()
+FROM users u
+WHERE u.id = p.user_id
+  AND u.public_id = $1
+  AND u.role = 'player'
+  AND p.id = $2;`
+	res, err := r.db.ExecContext(ctx, q, playerID, profileID, in.Nickname, in.BirthYear, in.AgeCategoryID, in.Avatar)
+	if err != nil {
+		return fmt.Errorf("players.update: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("players.update.rows: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Delete removes the profile. Sessions and leaderboard submissions keep
+// their rows with the profile reference cleared.
+func (r *ProfileRepo) Delete(ctx context.Context, playerID string, profileID int64) error {
+	const q = `
+DELETE FROM players p
+USING users u
+WHERE u.id = p.user_id
+  AND u.public_id = $1
+  AND u.role = 'player'
+  AND p.id = $2;`
+	res, err := r.db.ExecContext(ctx, q, playerID, profileID)
+	if err != nil {
+		return fmt.Errorf("players.delete: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("players.delete.rows: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// unratedGameRating is the rating of a game nobody has reacted to yet; it is
+// the prior baked into game_reaction_counts.rating.
+const unratedGameRating = 0.5
+
+type GameReactionCounts struct {
+	Love   int
+	Like   int
+	Meh    int
+	Rating float64
+}
+
+type ReactionRepo struct {
+	db *sql.DB
+}
+
+func NewReactionRepo(db *sql.DB) *ReactionRepo {
+	return &ReactionRepo{db: db}
+}
+
+// Get returns the member's reaction, or "" if there is none, along with the
+// game's counts.
+func (r *ReactionRepo) Get(ctx context.Context, gameID int64, member string) (string, *GameReactionCounts, error) {
+	var reaction sql.NullString
+	err := r.db.QueryRowContext(ctx,
+		`SELECT reaction FROM game_reactions WHERE game_id = $1 AND member = $2;`,
+		gameID, member,
+	).Scan(&reaction)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", nil, fmt.Errorf("game_reactions.get: %w", err)
+	}
+
+	counts, err := r.Counts(ctx, gameID)
+	if err != nil {
+		return "", nil, err
+	}
+	return reaction.String, counts, nil
+}
+
+func (r *ReactionRepo) Counts(ctx context.Context, gameID int64) (*GameReactionCounts, error) {
+	c := GameReactionCounts{Rating: unratedGameRating}
+	err := r.db.QueryRowContext(ctx,
+		`SELECT love_count, like_count, meh_count, rating FROM game_reaction_counts WHERE game_id = $1;`,
+		gameID,
+	).Scan(&c.Love, &c.Like, &c.Meh, &c.Rating)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("game_reaction_counts.get: %w", err)
+	}
+	return &c, nil
+}
+
+// Set records or changes the member's reaction and moves the aggregate by the
+// difference, in one transaction.
+func (r *ReactionRepo) Set(ctx context.Context, gameID int64, member, reaction string) (*GameReactionCounts, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("game_reactions.set.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	prev, err := lockReaction(ctx, tx, gameID, member)
+	if err != nil {
+		return nil, err
+	}
+	if prev == "" {
+		res, err := tx.ExecContext(ctx, `
+INSERT INTO game_reactions (game_id, member, reaction)
+VALUES ($1, $2, $3)
+ON CONFLICT (game_id, member) DO NOTHING;`, gameID, member, reaction)
+		if err != nil {
+			return nil, fmt.Errorf("game_reactions.set.insert: %w", err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, fmt.Errorf("game_reactions.set.insert.rows: %w", err)
+		}
+		// A concurrent request inserted first; take its row and update it.
+		if n == 0 {
+			if prev, err = lockReaction(ctx, tx, gameID, member); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if prev != "" && prev != reaction {
+		if _, err := tx.ExecContext(ctx, `
+UPDATE game_reactions
+SET reaction = $3,
+    updated_at = NOW()
+WHERE game_id = $1
+  AND member = $2;`, gameID, member, reaction); err != nil {
+			return nil, fmt.Errorf("game_reactions.set.update: %w", err)
+		}
+	}
+
+	delta := map[string]int{}
+	if prev != reaction {
+		delta[reaction]++
+		if prev != "" {
+			delta[prev]--
+		}
+	}
+	counts, err := applyReactionDelta(ctx, tx, gameID, delta)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("game_reactions.set.commit: %w", err)
+	}
+	committed = true
+	return counts, nil
+}
+
+// Clear removes the member's reaction, if any.
+func (r *ReactionRepo) Clear(ctx context.Context, gameID int64, member string) (*GameReactionCounts, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("game_reactions.clear.begin: %w", err)
+	}
+	committed := false
+	defer func() {
+		if !committed {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var prev string
+	err = tx.QueryRowContext(ctx,
+		`DELETE FROM game_reactions WHERE game_id = $1 AND member = $2 RETURNING reaction;`,
+		gameID, member,
+	).Scan(&prev)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("game_reactions.clear.delete: %w", err)
+	}
+
+	delta := map[string]int{}
+	if prev != "" {
+		delta[prev]--
+	}
+	counts, err := applyReactionDelta(ctx, tx, gameID, delta)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("game_reactions.clear.commit: %w", err)
+	}
+	committed = true
+	return counts, nil
+}
+
+func lockReaction(ctx context.Context, tx *sql.Tx, gameID int64, member string) (string, error) {
+	var reaction string
+	err := tx.QueryRowContext(ctx,
+		`SELECT reaction FROM game_reactions WHERE game_id = $1 AND member = $2 FOR UPDATE;`,
+		gameID, member,
+	).Scan(&reaction)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("game_reactions.lock: %w", err)
+	}
+	return reaction, nil
+}
+
+// applyReactionDelta adds delta to the game's counts and returns the result.
+// An empty delta still returns the current counts.
+func applyReactionDelta(ctx context.Context, tx *sql.Tx, gameID int64, delta map[string]int) (*GameReactionCounts, error) {
+	const q = `
+INSERT INTO game_reaction_counts (game_id, love_count, like_count, meh_count)
+VALUES ($1, GREATEST($2, 0), GREATEST($3, 0), GREATEST($4, 0))
+ON CONFLICT (game_id) DO UPDATE
+SET love_count = GREATEST(game_reaction_counts.love_count + $2, 0),
+    like_count = GREATEST(game_reaction_counts.like_count + $3, 0),
+    meh_count = GREATEST(game_reaction_counts.meh_count + $4, 0),
+    updated_at = NOW()
+RETURNING love_count, like_count, meh_count, rating;`
+	var c GameReactionCounts
+	if err := tx.QueryRowContext(ctx, q, gameID, delta["love"], delta["like"], delta["meh"]).
+		Scan(&c.Love, &c.Like, &c.Meh, &c.Rating); err != nil {
+		return nil, fmt.Errorf("game_reaction_counts.apply: %w", err)
+	}
+	return &c, nil
+}
@@ -0,0 +1,220 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ReportSession is one played session of a report window.
+type ReportSession struct {
+	SessionID     int64
+	GameID        int64
+	GameTitle     string
+	StartedAt     time.Time
+	PlayedSeconds int64
+	BestScore     sql.NullInt64
+	// EducationCategoriesJSON is [{"id":1,"name":"Math"}, ...].
+	EducationCategoriesJSON []byte
+}
+
+// ReportTarget is an account, or one of its profiles (ProfileID > 0), that
+// played in a window and has not been mailed that week's report yet.
+type ReportTarget struct {
+	UserID    int64
+	PlayerID  string
+	Email     string
+	ProfileID int64
+}
+
+type ReportRepo struct {
+	db *sql.DB
+}
+
+func NewReportRepo(db *sql.DB) *ReportRepo {
+	return &ReportRepo{db: db}
+}
+
+// ListSessions returns the account's sessions started in [from, to), or only
+// the profile's with profileID > 0. Sessions are attributed to a player
+// through the player_id stamped on their analytics events; a session lasts
+// from its start to its last event, capped at maxSessionSeconds.
+func (r *ReportRepo) ListSessions(ctx context.Context, playerID string, profileID int64, from, to time.Time, maxSessionSeconds int) ([]ReportSession, error) {
+	playerID = strings.TrimSpace(playerID)
+	if playerID == "" {
+		return nil, ErrInvalidPlayerID
+	}
+
+	const q = `
+WITH player_sessions AS (
+  SELECT ae.session_id, MAX(ae.created_at) AS last_at
+  FROM analytics_events ae
+  WHERE COALESCE(ae.event_data->>'player_id', '') = $1
+    AND ($2 = '' OR ae.event_data->>'profile_id' = $2)
+    AND NOT ae.preview
+    AND ae.session_id IS NOT NULL
+    AND ae.created_at >= $3
+    AND ae.created_at < $4::timestamptz + ($5::int * INTERVAL '1 second')
+  GROUP BY ae.session_id
+)
+SELECT
+  s.id,
+  s.game_id,
+  g.title,
+  s.started_at,
+  LEAST(EXTRACT(EPOCH FROM (GREATEST(ps.last_at, s.started_at) - s.started_at)), $5::int)::bigint,
+  (SELECT MAX(ls.score) FROM leaderboard_submissions ls WHERE ls.session_id = s.id)::bigint,
+  COALESCE((
+    SELECT jsonb_agg(jsonb_build_object('id', ec.id, 'name', ec.name) ORDER BY ec.id)
+    FROM game_education_categories gec
+    JOIN education_categories ec ON ec.id = gec.education_category_id
+    WHERE gec.game_id = s.game_id
+  ), '[]'::jsonb)
+FROM player_sessions ps
+JOIN sessions s ON s.id = ps.session_id
+JOIN games g ON g.id = s.game_id
+WHERE s.started_at >= $3
+  AND s.started_at < $4
+  AND NOT s.preview
+ORDER BY s.started_at ASC, s.id ASC;`
+
+	rows, err := r.db.QueryContext(ctx, q, playerID, profileEventFilter(profileID), from, to, maxSessionSeconds)
+	if err != nil {
+		return nil, fmt.Errorf("reports.sessions: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]ReportSession, 0)
+	for rows.Next() {
+		var s ReportSession
+		if err := rows.Scan(&s.SessionID, &s.GameID, &s.GameTitle, &s.StartedAt, &s.PlayedSeconds, &s.BestScore, &s.EducationCategoriesJSON); err != nil {
+			return nil, fmt.Errorf("reports.sessions.scan: %w", err)
+		}
+		out = append(out, s)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reports.sessions.rows: %w", err)
+	}
+	return out, nil
+}
+
+// ListPlayDays returns the distinct local dates in timeZone, ascending, on
+// which the account (or profile) played in [from, to).
+func (r *ReportRepo) ListPlayDays(ctx context.Context, playerID string, profileID int64, from, to time.Time, timeZone string) ([]time.Time, error) {
+	playerID = strings.TrimSpace(playerID)
+	if playerID == "" {
+		return nil, ErrInvalidPlayerID
+	}
+
+	const q = `
+SELECT DISTINCT (ae.created_at AT TIME ZONE $5)::date AS day
+FROM analytics_events ae
+WHERE COALESCE(ae.event_data->>'player_id', '') = $1
+  AND ($2 = '' OR ae.event_data->>'profile_id' = $2)
+  AND NOT ae.preview
+  AND ae.session_id IS NOT NULL
+  AND ae.created_at >= $3
+  AND ae.created_at < $4
+ORDER BY day ASC;`
+
+	rows, err := r.db.QueryContext(ctx, q, playerID, profileEventFilter(profileID), from, to, timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("reports.play_days: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]time.Time, 0)
+	for rows.Next() {
+		var day time.Time
+		if err := rows.Scan(&day); err != nil {
+			return nil, fmt.Errorf("reports.play_days.scan: %w", err)
+		}
+		out = append(out, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reports.play_days.rows: %w", err)
+	}
+	return out, nil
+}
+
+// ListWeeklyTargets returns what the weekly mail goes to for activity in
+// [from, to): every existing profile that played, and accounts without
+// profiles. Targets already mailed the report of weekStart are left out.
+func (r *ReportRepo) ListWeeklyTargets(ctx context.Context, from, to time.Time, weekStart time.Time) ([]ReportTarget, error) {
+	const q = `
+SELECT DISTINCT u.id, u.public_id::text, u.email, COALESCE(p.id, 0)
+FROM analytics_events ae
+JOIN users u
+  ON u.public_id::text = ae.event_data->>'player_id'
+ AND u.role = 'player'
+ AND u.status = 'active'
+LEFT JOIN players p
+  ON p.user_id = u.id
+ AND p.id::text = ae.event_data->>'profile_id'
+WHERE ae.created_at >= $1
+  AND ae.created_at < $2
+  AND NOT ae.preview
+  AND ae.session_id IS NOT NULL
+  AND (p.id IS NOT NULL OR NOT EXISTS (SELECT 1 FROM players x WHERE x.user_id = u.id))
+  AND NOT EXISTS (
+    SELECT 1
+    FROM weekly_report_deliveries d
+    WHERE d.user_id = u.id
+      AND d.week_start = $3::date
+      AND COALESCE(d.profile_id, 0) = COALESCE(p.id, 0)
+  )
+ORDER BY u.id ASC, 4 ASC;`
+
+	rows, err := r.db.QueryContext(ctx, q, from, to, weekStart.Format(time.DateOnly))
+	if err != nil {
+		return nil, fmt.Errorf("reports.weekly_targets: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]ReportTarget, 0)
+	for rows.Next() {
+		var t ReportTarget
+		if err := rows.Scan(&t.UserID, &t.PlayerID, &t.Email, &t.ProfileID); err != nil {
+			return nil, fmt.Errorf("reports.weekly_targets.scan: %w", err)
+		}
+		out = append(out, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reports.weekly_targets.rows: %w", err)
+	}
+	return out, nil
+}
+
+// ClaimDelivery records that the target's report of weekStart is being
+// mailed. It returns false when another replica claimed it first.
+func (r *ReportRepo) ClaimDelivery(ctx context.Context, userID, profileID int64, weekStart time.Time) (bool, error) {
+	const q = `
+INSERT INTO weekly_report_deliveries (user_id, profile_id, week_start)
+VALUES ($1, NULLIF($2::bigint, 0), $3::date)
+ON CONFLICT (user_id, week_start, (COALESCE(profile_id, 0))) DO NOTHING;`
+	res, err := r.db.ExecContext(ctx, q, userID, profileID, weekStart.Format(time.DateOnly))
+	if err != nil {
+		return false, fmt.Errorf("reports.claim_delivery: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("reports.claim_delivery.rows: %w", err)
+	}
+	return n == 1, nil
+}
+
+// ReleaseDelivery drops a claim whose mail could not be sent so the next
+// run retries it.
+func (r *ReportRepo) ReleaseDelivery(ctx context.Context, userID, profileID int64, weekStart time.Time) error {
+	const q = `
+DELETE FROM weekly_report_deliveries
+WHERE user_id = $1
+  AND COALESCE(profile_id, 0) = $2
+  AND week_start = $3::date;`
+	if _, err := r.db.ExecContext(ctx, q, userID, profileID, weekStart.Format(time.DateOnly)); err != nil {
+		return fmt.Errorf("reports.release_delivery: %w", err)
+	}
+	return nil
+}
@@ -15,15 +15,38 @@ func NewSessionRepo(db *sql.DB) *SessionRepo {
 	return &SessionRepo{db: db}
 }
 
-func (r *SessionRepo) Create(ctx context.Context, gameID int64, startedAt time.Time) (int64, error) {
+func (r *SessionRepo) Create(ctx context.Context, gameID int64, startedAt time.Time, expiresAt time.Time, preview bool, profileID sql.NullInt64, guestID sql.NullString) (int64, error) {
 	const q = `
-INSERT INTO sessions (game_id, started_at)
-VALUES ($1, $2)
+INSERT INTO sessions (game_id, started_at, expires_at, preview, profile_id, guest_id)
+VALUES ($1, $2, $3, $4, $5, $6)
 RETURNING id;
 `
 	var id int64
-	if err := r.db.QueryRowContext(ctx, q, gameID, startedAt).Scan(&id); err != nil {
+	if err := r.db.QueryRowContext(ctx, q, gameID, startedAt, expiresAt, preview, profileID, guestID).Scan(&id); err != nil {
 		return 0, fmt.Errorf("sessions.create: %w", err)
 	}
 	return id, nil
 }
+
+// PlayedSecondsSince sums the profile's play time in sessions started at or
+// after since. A session counts from its start to its recorded end or, while
+// none is recorded, to its token expiry, so sessions still running count in
+// full. Each is capped at maxSessionSeconds; preview sessions do not count.
+func (r *SessionRepo) PlayedSecondsSince(ctx context.Context, profileID int64, since time.Time, maxSessionSeconds int) (int64, error) {
+	const q = `
+SELECT COALESCE(SUM(
+  LEAST(
+    GREATEST(EXTRACT(EPOCH FROM (COALESCE(s.ended_at, s.expires_at, s.started_at + make_interval(secs => $3)) - s.started_at)), 0),
+    $3::int
+  )
+), 0)::bigint
+FROM sessions s
+WHERE s.profile_id = $1
+  AND s.started_at >= $2
+  AND NOT s.preview;`
+	var secs int64
+	if err := r.db.QueryRowContext(ctx, q, profileID, since, maxSessionSeconds).Scan(&secs); err != nil {
+		return 0, fmt.Errorf("sessions.played_seconds: %w", err)
+	}
+	return secs, nil
+}
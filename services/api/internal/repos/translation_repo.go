@@ -0,0 +1,276 @@
+package repos
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type TranslationRepo struct {
+	db *sql.DB
+}
+
+func NewTranslationRepo(db *sql.DB) *TranslationRepo {
+	return &TranslationRepo{db: db}
+}
+
+type GameTranslation struct {
+	GameID      int64
+	Locale      string
+	Title       string
+	Description sql.NullString
+	UpdatedAt   time.Time
+}
+
+// CategoryTranslation is a translated age label or education category name.
+type CategoryTranslation struct {
+	CategoryID int64
+	Locale     string
+	Text       string
+	UpdatedAt  time.Time
+}
+
+type CategoryKind string
+
+const (
+	CategoryKindAge       CategoryKind = "age"
+	CategoryKindEducation CategoryKind = "education"
+)
+
+type categoryTranslationTable struct {
+	table  string
+	fk     string
+	column string
+}
+
+var categoryTranslationTables = map[CategoryKind]categoryTranslationTable{
+	CategoryKindAge:       {table: "age_category_translations", fk: "age_category_id", column: "label"},
+	CategoryKindEducation: {table: "education_category_translations", fk: "education_category_id", column: "name"},
+}
+
+func categoryTable(kind CategoryKind) (categoryTranslationTable, error) {
+	t, ok := categoryTranslationTables[kind]
+	if !ok {
+		return categoryTranslationTable{}, fmt.Errorf("unknown category kind %q", kind)
+	}
+	return t, nil
+}
+
+func (r *TranslationRepo) ListGameTranslations(ctx context.Context, gameID int64) ([]GameTranslation, error) {
+	const q = `
+SELECT game_id, locale, title, description, updated_at
+FROM game_translations
+WHERE game_id = $1
+ORDER BY locale ASC;`
+	rows, err := r.db.QueryContext(ctx, q, gameID)
+	if err != nil {
+		return nil, fmt.Errorf("game_translations.list: %w", err)
+	}
+	defer rows.Close()
+
+	out := make([]GameTranslation, 0)
+	for rows.Next() {
+		var t GameTranslation
+		if err := rows.Scan(&t.GameID, &t.Locale, &t.Title, &t.Description, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("game_translations.list.scan: %w", err)
+		}
+		out = append(out, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("game_translations.list.rows: %w", err)
+	}
+	return out, nil
+}
+
+func (r *TranslationRepo) UpsertGameTranslation(ctx context.Context, in GameTranslation) (*GameTranslation, error) {
+	const q = `
+INSERT INTO game_translations (game_id, locale, title, description)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (game_id, locale) DO UPDATE
+SET title = EXCLUDED.title,
+    description = EXCLUDED.description,
+    updated_at = NOW()
+RETURNING game_id, locale, title, description, updated_at;`
+	var t GameTranslation
+	if err := r.db.QueryRowContext(ctx, q, in.GameID, in.Locale, in.Title, in.Description).Scan(
+		&t.GameID, &t.Locale, &t.Title, &t.Description, &t.UpdatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("game_translations.upsert: %w", err)
+	}
+	return &t, nil
+}
+
+func (r *TranslationRepo) DeleteGameTranslation(ctx context.Context, gameID int64, locale string) error {
+	res, err := r.db.ExecContext(ctx, `DELETE FROM game_translations WHERE game_id = $1 AND locale = $2;`, gameID, locale)
+	if err != nil {
+		return fmt.Errorf("game_translations.delete: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("game_translations.delete.rows: %w", err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// GameTranslationsFor loads the locale's translations for the given games,
+// keyed by game id. Games without one are absent from the map.
+func (r *TranslationRepo) GameTranslationsFor(ctx context.Context, locale string, gameIDs []int64) (map[int64]GameTranslation, error) {
+	out := make(map[int64]GameTranslation, len(gameIDs))
+	if len(gameIDs) == 0 {
+		return out, nil
+	}
+
+	q := `
+SELECT game_id, locale, title, description, updated_at
+FROM game_translations
+WHERE locale = $1
+  AND game_id IN (` + placeholdersFrom(2, len(gameIDs)) + `);`
+	args := append([]any{locale}, int64Args(gameIDs)...)
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, fmt.Errorf("game_translations.for: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t GameTranslation
+		if err := rows.Scan(&t.GameID, &t.Locale, &t.Title, &t.Description, &t.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("game_translations.for.scan: %w", err)
+		}
+		out[t.GameID] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("game_translations.for.rows: %w", err)
+	}
+	return out, nil
+}
+
+func (r *TranslationRepo) ListCategoryTranslations(ctx context.Context, kind CategoryKind, categoryID int64) ([]CategoryTranslation, error) {
+	t, err := categoryTable(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	q := fmt.Sprintf(`
+SELECT %[2]s, locale, %[3]s, updated_at
+FROM %[1]s
+WHERE %[2]s = $1
+ORDER BY locale ASC;`, t.table, t.fk, t.column)
+	rows, err := r.db.QueryContext(ctx, q, categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("%s.list: %w", t.table, err)
+	}
+	defer rows.Close()
+
+	out := make([]CategoryTranslation, 0)
+	for rows.Next() {
+		var ct CategoryTranslation
+		if err := rows.Scan(&ct.CategoryID, &ct.Locale, &ct.Text, &ct.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("%s.list.scan: %w", t.table, err)
+		}
+		out = append(out, ct)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s.list.rows: %w", t.table, err)
+	}
+	return out, nil
+}
+
+func (r *TranslationRepo) UpsertCategoryTranslation(ctx context.Context, kind CategoryKind, in CategoryTranslation) (*CategoryTranslation, error) {
+	t, err := categoryTable(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	q := fmt.Sprintf(`
+INSERT INTO %[1]s (%[2]s, locale, %[3]s)
+VALUES ($1, $2, $3)
+ON CONFLICT (%[2]s, locale) DO UPDATE
+SET %[3]s = EXCLUDED.%[3]s,
+    updated_at = NOW()
+RETURNING %[2]s, locale, %[3]s, updated_at;`, t.table, t.fk, t.column)
+	var ct CategoryTranslation
+	if err := r.db.QueryRowContext(ctx, q, in.CategoryID, in.Locale, in.Text).Scan(
+		&ct.CategoryID, &ct.Locale, &ct.Text, &ct.UpdatedAt,
+	); err != nil {
+		return nil, fmt.Errorf("%s.upsert: %w", t.table, err)
+	}
+	return &ct, nil
+}
+
+func (r *TranslationRepo) DeleteCategoryTranslation(ctx context.Context, kind CategoryKind, categoryID int64, locale string) error {
+	t, err := categoryTable(kind)
+	if err != nil {
+		return err
+	}
+
+	q := fmt.Sprintf(`DELETE FROM %s WHERE %s = $1 AND locale = $2;`, t.table, t.fk)
+	res, err := r.db.ExecContext(ctx, q, categoryID, locale)
+	if err != nil {
+		return fmt.Errorf("%s.delete: %w", t.table, err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s.delete.rows: %w", t.table, err)
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// CategoryTranslationsFor returns translated text keyed by category id.
+// A nil ids slice loads every translation for the locale.
+func (r *TranslationRepo) CategoryTranslationsFor(ctx context.Context, kind CategoryKind, locale string, ids []int64) (map[int64]string, error) {
+	t, err := categoryTable(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(map[int64]string)
+	if ids != nil && len(ids) == 0 {
+		return out, nil
+	}
+
+	q := fmt.Sprintf(`SELECT %s, %s FROM %s WHERE locale = $1`, t.fk, t.column, t.table)
+	args := []any{locale}
+	if ids != nil {
+		q += fmt.Sprintf(` AND %s IN (%s)`, t.fk, placeholdersFrom(2, len(ids)))
+		args = append(args, int64Args(ids)...)
+	}
+
+	rows, err := r.db.QueryContext(ctx, q+";", args...)
+	if err != nil {
+		return nil, fmt.Errorf("%s.for: %w", t.table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id   int64
+			text string
+		)
+		if err := rows.Scan(&id, &text); err != nil {
+			return nil, fmt.Errorf("%s.for.scan: %w", t.table, err)
+		}
+		out[id] = text
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s.for.rows: %w", t.table, err)
+	}
+	return out, nil
+}
+
+// placeholdersFrom renders "$start, $start+1, ..." for n arguments.
+func placeholdersFrom(start, n int) string {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = fmt.Sprintf("$%d", start+i)
+	}
+	return strings.Join(parts, ", ")
+}
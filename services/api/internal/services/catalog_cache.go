@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/clients"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+)
+
+// Entries are namespaced by generation counters so a mutation can drop every
+// dependent entry with a single INCR: game writes bump the list generation,
+// category writes bump the category generation, which lists, details and
+// the category listing all embed. Related-game lists and collections also
+// embed their own generation, bumped after a rebuild or a collection edit.
+const (
+	catalogGamesGenKey       = "cache:catalog:gen:games"
+	catalogCategoriesGenKey  = "cache:catalog:gen:categories"
+	catalogRelatedGenKey     = "cache:catalog:gen:related"
+	catalogCollectionsGenKey = "cache:catalog:gen:collections"
+)
+
+// CatalogCache is a read-through cache for public catalog reads. A nil cache,
+// a nil Valkey client or a zero TTL disables it; Valkey errors fall back to
+// Postgres.
+type CatalogCache struct {
+	valkey  *clients.Valkey
+	ttl     time.Duration
+	locales []string
+}
+
+func NewCatalogCache(valkey *clients.Valkey, cacheCfg config.CatalogCacheConfig) *CatalogCache {
+	return &CatalogCache{valkey: valkey, ttl: cacheCfg.TTL}
+}
+
+// WithLocales sets the locales a game's detail entries may be cached under,
+// so InvalidateGame can drop all of them.
+func (c *CatalogCache) WithLocales(locales []string) *CatalogCache {
+	c.locales = append([]string(nil), locales...)
+	return c
+}
+
+func (c *CatalogCache) enabled() bool {
+	return c != nil && c.valkey != nil && c.ttl > 0
+}
+
+type catalogGenerations struct {
+	games       string
+	categories  string
+	related     string
+	collections string
+}
+
+func (c *CatalogCache) generations(ctx context.Context) (catalogGenerations, bool) {
+	vals, err := c.valkey.MGet(ctx, catalogGamesGenKey, catalogCategoriesGenKey, catalogRelatedGenKey, catalogCollectionsGenKey)
+	if err != nil {
+		log.Printf("level=warn msg=%q err=%v", "catalog cache generations failed", err)
+		return catalogGenerations{}, false
+	}
+	return catalogGenerations{
+		games:       genOrZero(vals[0]),
+		categories:  genOrZero(vals[1]),
+		related:     genOrZero(vals[2]),
+		collections: genOrZero(vals[3]),
+	}, true
+}
+
+func genOrZero(v string) string {
+	if v == "" {
+		return "0"
+	}
+	return v
+}
+
+func (c *CatalogCache) get(ctx context.Context, key string, dst any) bool {
+	raw, ok, err := c.valkey.Get(ctx, key)
+	if err != nil {
+		log.Printf("level=warn msg=%q key=%s err=%v", "catalog cache get failed", key, err)
+		return false
+	}
+	if !ok {
+		return false
+	}
+	return json.Unmarshal(raw, dst) == nil
+}
+
+func (c *CatalogCache) set(ctx context.Context, key string, v any) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	if err := c.valkey.Set(ctx, key, raw, c.ttl); err != nil {
+		log.Printf("level=warn msg=%q key=%s err=%v", "catalog cache set failed", key, err)
+	}
+}
+
+// gameListCacheKey is built from the filter after validation so equivalent
+// requests (?a=1,2 vs ?a=2&a=1) share an entry.
+type gameListCacheKey struct {
+	AgeCategoryIDs       []int64  `json:"a,omitempty"`
+	EducationCategoryIDs []int64  `json:"e,omitempty"`
+	Difficulties         []string `json:"d,omitempty"`
+	Free                 *bool    `json:"f,omitempty"`
+	Age                  *int64   `json:"g,omitempty"`
+	Query                string   `json:"q,omitempty"`
+	Sort                 string   `json:"s"`
+	Page                 int      `json:"p"`
+	Limit                int      `json:"l"`
+	Cursor               string   `json:"c,omitempty"`
+	Locale               string   `json:"lc"`
+}
+
+func (k gameListCacheKey) hash() string {
+	ageIDs := append([]int64(nil), k.AgeCategoryIDs...)
+	sort.Slice(ageIDs, func(i, j int) bool { return ageIDs[i] < ageIDs[j] })
+	k.AgeCategoryIDs = ageIDs
+
+	eduIDs := append([]int64(nil), k.EducationCategoryIDs...)
+	sort.Slice(eduIDs, func(i, j int) bool { return eduIDs[i] < eduIDs[j] })
+	k.EducationCategoryIDs = eduIDs
+
+	difficulties := append([]string(nil), k.Difficulties...)
+	sort.Strings(difficulties)
+	k.Difficulties = difficulties
+
+	raw, _ := json.Marshal(k)
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:16])
+}
+
+func (c *CatalogCache) gameListKey(ctx context.Context, k gameListCacheKey) (string, bool) {
+	if !c.enabled() {
+		return "", false
+	}
+	gen, ok := c.generations(ctx)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("cache:catalog:games:%s:%s:%s", gen.games, gen.categories, k.hash()), true
+}
+
+func gameDetailCacheKey(id int64, categoriesGen, locale string) string {
+	return fmt.Sprintf("cache:catalog:game:%d:%s:%s", id, categoriesGen, locale)
+}
+
+func (c *CatalogCache) gameDetailKey(ctx context.Context, id int64, locale string) (string, bool) {
+	if !c.enabled() {
+		return "", false
+	}
+	gen, ok := c.generations(ctx)
+	if !ok {
+		return "", false
+	}
+	return gameDetailCacheKey(id, gen.categories, locale), true
+}
+
+func (c *CatalogCache) categoriesKey(ctx context.Context, locale string) (string, bool) {
+	if !c.enabled() {
+		return "", false
+	}
+	gen, ok := c.generations(ctx)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("cache:catalog:categories:%s:%s", gen.categories, locale), true
+}
+
+func (c *CatalogCache) relatedKey(ctx context.Context, id int64, limit int, locale string) (string, bool) {
+	if !c.enabled() {
+		return "", false
+	}
+	gen, ok := c.generations(ctx)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("cache:catalog:related:%s:%s:%s:%d:%d:%s", gen.related, gen.games, gen.categories, id, limit, locale), true
+}
+
+// collectionsKey covers both the shelf listing (slug "") and single
+// collections.
+func (c *CatalogCache) collectionsKey(ctx context.Context, slug string, gamesLimit int, locale string) (string, bool) {
+	if !c.enabled() {
+		return "", false
+	}
+	gen, ok := c.generations(ctx)
+	if !ok {
+		return "", false
+	}
+	return fmt.Sprintf("cache:catalog:collections:%s:%s:%s:%s:%d:%s", gen.collections, gen.games, gen.categories, slug, gamesLimit, locale), true
+}
+
+// InvalidateGame drops the game's detail entries in every locale and every
+// cached list page.
+func (c *CatalogCache) InvalidateGame(ctx context.Context, id int64) {
+	if !c.enabled() {
+		return
+	}
+	if gen, ok := c.generations(ctx); ok && len(c.locales) > 0 {
+		keys := make([]string, 0, len(c.locales))
+		for _, locale := range c.locales {
+			keys = append(keys, gameDetailCacheKey(id, gen.categories, locale))
+		}
+		if err := c.valkey.Del(ctx, keys...); err != nil {
+			log.Printf("level=warn msg=%q game_id=%d err=%v", "catalog cache invalidate failed", id, err)
+		}
+	}
+	if _, err := c.valkey.Incr(ctx, catalogGamesGenKey); err != nil {
+		log.Printf("level=warn msg=%q game_id=%d err=%v", "catalog cache invalidate failed", id, err)
+	}
+}
+
+// InvalidateCategories drops everything that embeds category data.
+func (c *CatalogCache) InvalidateCategories(ctx context.Context) {
+	if !c.enabled() {
+		return
+	}
+	if _, err := c.valkey.Incr(ctx, catalogCategoriesGenKey); err != nil {
+		log.Printf("level=warn msg=%q err=%v", "catalog cache invalidate failed", err)
+	}
+}
+
+// InvalidateRelated drops every cached related-games list.
+func (c *CatalogCache) InvalidateRelated(ctx context.Context) {
+	if !c.enabled() {
+		return
+	}
+	if _, err := c.valkey.Incr(ctx, catalogRelatedGenKey); err != nil {
+		log.Printf("level=warn msg=%q err=%v", "catalog cache invalidate failed", err)
+	}
+}
+
+// InvalidateCollections drops every cached collection read.
+func (c *CatalogCache) InvalidateCollections(ctx context.Context) {
+	if !c.enabled() {
+		return
+	}
+	if _, err := c.valkey.Incr(ctx, catalogCollectionsGenKey); err != nil {
+		log.Printf("level=warn msg=%q err=%v", "catalog cache invalidate failed", err)
+	}
+}
@@ -0,0 +1,571 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/storage"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+const (
+	catalogManifestName     = "catalog.json"
+	catalogBuildsDir        = "builds/"
+	catalogManifestMaxBytes = 10 * 1024 * 1024
+
+	CatalogConflictSkip      = "skip"
+	CatalogConflictOverwrite = "overwrite"
+)
+
+// CatalogService moves the catalog between environments. Export writes a ZIP
+// with catalog.json and, optionally, each game's current upload ZIP under
+// builds/. Import accepts that archive (or a bare catalog.json), matches
+// existing rows by label, name and slug, and goes through the regular admin
+// services so every item gets the same validation as the admin API.
+type CatalogService struct {
+	catalogRepo *repos.CatalogRepo
+	gameSvc     *GameService
+	categorySvc *CategoryService
+	store       storage.ObjectStore
+	zipMaxBytes int64
+}
+
+func NewCatalogService(
+	catalogRepo *repos.CatalogRepo,
+	gameSvc *GameService,
+	categorySvc *CategoryService,
+	store storage.ObjectStore,
+	uploadCfg config.UploadConfig,
+) *CatalogService {
+	return &CatalogService{
+		catalogRepo: catalogRepo,
+		gameSvc:     gameSvc,
+		categorySvc: categorySvc,
+		store:       store,
+		zipMaxBytes: uploadCfg.ZipMaxBytes,
+	}
+}
+
+// Export writes the archive to w. A build whose upload object is gone is
+// left out and the game is exported without one.
+func (s *CatalogService) Export(ctx context.Context, w io.Writer, includeBuilds bool) error {
+	if includeBuilds && s.store == nil {
+		return utils.ErrInternal()
+	}
+
+	ageItems, err := s.catalogRepo.ListAgeCategories(ctx)
+	if err != nil {
+		return utils.ErrInternal()
+	}
+	eduItems, err := s.catalogRepo.ListEducationCategories(ctx)
+	if err != nil {
+		return utils.ErrInternal()
+	}
+	games, err := s.catalogRepo.ListGames(ctx)
+	if err != nil {
+		return utils.ErrInternal()
+	}
+
+	manifest := models.CatalogManifest{
+		Version:             models.CatalogFormatVersion,
+		ExportedAt:          time.Now().UTC(),
+		AgeCategories:       make([]models.CatalogAgeCategory, 0, len(ageItems)),
+		EducationCategories: make([]models.CatalogEducationCategory, 0, len(eduItems)),
+		Games:               make([]models.CatalogGame, 0, len(games)),
+	}
+	for _, it := range ageItems {
+		manifest.AgeCategories = append(manifest.AgeCategories, models.CatalogAgeCategory{
+			Label:  it.Label,
+			MinAge: it.MinAge,
+			MaxAge: it.MaxAge,
+		})
+	}
+	for _, it := range eduItems {
+		manifest.EducationCategories = append(manifest.EducationCategories, models.CatalogEducationCategory{
+			Name:  it.Name,
+			Icon:  toNullableString(it.Icon),
+			Color: toNullableString(it.Color),
+		})
+	}
+
+	zw := zip.NewWriter(w)
+	for _, g := range games {
+		item := models.CatalogGame{
+			Slug:                g.Slug,
+			Title:               g.Title,
+			Description:         g.Description.String,
+			Difficulty:          g.Difficulty.String,
+			Free:                g.Free,
+			Status:              models.GameStatus(g.Status),
+			AgeCategory:         g.AgeCategoryLabel,
+			EducationCategories: g.EducationCategoryNames,
+		}
+		if includeBuilds && g.BuildObjectKey.Valid {
+			name := catalogBuildsDir + g.Slug + ".zip"
+			if err := s.copyBuild(ctx, zw, name, g.BuildObjectKey.String); err != nil {
+				if !errors.Is(err, storage.ErrNotFound) {
+					return utils.ErrInternal()
+				}
+				log.Printf("level=warn msg=%q slug=%s key=%s", "catalog export build missing", g.Slug, g.BuildObjectKey.String)
+			} else {
+				item.Build = name
+			}
+		}
+		manifest.Games = append(manifest.Games, item)
+	}
+
+	mw, err := zw.Create(catalogManifestName)
+	if err != nil {
+		return utils.ErrInternal()
+	}
+	enc := json.NewEncoder(mw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return utils.ErrInternal()
+	}
+	if err := zw.Close(); err != nil {
+		return utils.ErrInternal()
+	}
+	return nil
+}
+
+// copyBuild stores the upload ZIP as-is; it is already compressed.
+func (s *CatalogService) copyBuild(ctx context.Context, zw *zip.Writer, name, key string) error {
+	rc, _, err := s.store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = rc.Close() }()
+
+	fw, err := zw.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: time.Now().UTC()})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(fw, rc)
+	return err
+}
+
+type CatalogImportInput struct {
+	File       io.ReaderAt
+	Size       int64
+	DryRun     bool
+	OnConflict string
+}
+
+// catalogImport carries the lookups for one run. On a dry run, items that
+// would be created are recorded with id 0 so later items can resolve them.
+type catalogImport struct {
+	in      CatalogImportInput
+	adminID int64
+	builds  map[string]*zip.File
+	ages    map[string]int64
+	edus    map[string]int64
+	games   map[string]repos.CatalogGameRef
+	report  *models.CatalogImportReport
+}
+
+func (s *CatalogService) Import(ctx context.Context, adminID int64, in CatalogImportInput) (*models.CatalogImportReport, error) {
+	if adminID <= 0 {
+		return nil, utils.ErrInternal()
+	}
+	in.OnConflict = strings.ToLower(strings.TrimSpace(in.OnConflict))
+	if in.OnConflict == "" {
+		in.OnConflict = CatalogConflictSkip
+	}
+	if in.OnConflict != CatalogConflictSkip && in.OnConflict != CatalogConflictOverwrite {
+		return nil, utils.ErrBadRequest("on_conflict must be one of: skip, overwrite")
+	}
+	if in.File == nil || in.Size <= 0 {
+		return nil, utils.ErrBadRequest("file is required")
+	}
+
+	manifest, builds, err := readCatalogArchive(in.File, in.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &catalogImport{
+		in:      in,
+		adminID: adminID,
+		builds:  builds,
+		report: &models.CatalogImportReport{
+			DryRun:     in.DryRun,
+			OnConflict: in.OnConflict,
+			Items:      make([]models.CatalogImportItem, 0, len(manifest.AgeCategories)+len(manifest.EducationCategories)+len(manifest.Games)),
+		},
+	}
+	if run.ages, err = s.catalogRepo.AgeCategoryIDsByLabel(ctx); err != nil {
+		return nil, utils.ErrInternal()
+	}
+	if run.edus, err = s.catalogRepo.EducationCategoryIDsByName(ctx); err != nil {
+		return nil, utils.ErrInternal()
+	}
+	if run.games, err = s.catalogRepo.GameRefsBySlug(ctx); err != nil {
+		return nil, utils.ErrInternal()
+	}
+
+	seen := make(map[string]bool)
+	for _, it := range manifest.AgeCategories {
+		run.add(s.importAgeCategory(ctx, run, it, seen))
+	}
+	seen = make(map[string]bool)
+	for _, it := range manifest.EducationCategories {
+		run.add(s.importEducationCategory(ctx, run, it, seen))
+	}
+	seen = make(map[string]bool)
+	for _, it := range manifest.Games {
+		run.add(s.importGame(ctx, run, it, seen))
+	}
+	return run.report, nil
+}
+
+func (r *catalogImport) add(item models.CatalogImportItem) {
+	switch item.Action {
+	case models.CatalogActionCreate:
+		r.report.Created++
+	case models.CatalogActionUpdate:
+		r.report.Updated++
+	case models.CatalogActionSkip:
+		r.report.Skipped++
+	default:
+		r.report.Failed++
+	}
+	r.report.Items = append(r.report.Items, item)
+}
+
+func readCatalogArchive(f io.ReaderAt, size int64) (*models.CatalogManifest, map[string]*zip.File, error) {
+	head := make([]byte, 2)
+	if _, err := f.ReadAt(head, 0); err != nil {
+		return nil, nil, utils.ErrBadRequest("invalid catalog file")
+	}
+
+	builds := make(map[string]*zip.File)
+	var raw io.Reader
+	if head[0] == 'P' && head[1] == 'K' {
+		zr, err := zip.NewReader(f, size)
+		if err != nil {
+			return nil, nil, utils.ErrBadRequest("invalid catalog archive")
+		}
+		for _, zf := range zr.File {
+			switch {
+			case zf.Name == catalogManifestName:
+				rc, err := zf.Open()
+				if err != nil {
+					return nil, nil, utils.ErrBadRequest("invalid catalog archive")
+				}
+				defer func() { _ = rc.Close() }()
+				raw = rc
+			case strings.HasPrefix(zf.Name, catalogBuildsDir) && !zf.FileInfo().IsDir():
+				builds[zf.Name] = zf
+			}
+		}
+		if raw == nil {
+			return nil, nil, utils.ErrBadRequest("archive has no " + catalogManifestName)
+		}
+	} else {
+		raw = io.NewSectionReader(f, 0, size)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(raw, catalogManifestMaxBytes+1))
+	if err != nil {
+		return nil, nil, utils.ErrBadRequest("invalid catalog archive")
+	}
+	if len(body) > catalogManifestMaxBytes {
+		return nil, nil, utils.ErrBadRequest(catalogManifestName + " is too large")
+	}
+
+	var manifest models.CatalogManifest
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&manifest); err != nil {
+		return nil, nil, utils.ErrBadRequest("invalid " + catalogManifestName)
+	}
+	if manifest.Version != models.CatalogFormatVersion {
+		return nil, nil, utils.ErrBadRequest("unsupported catalog version")
+	}
+	return &manifest, builds, nil
+}
+
+func catalogErrMessage(err error) string {
+	var appErr utils.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Message
+	}
+	return "internal error"
+}
+
+func (s *CatalogService) importAgeCategory(ctx context.Context, run *catalogImport, it models.CatalogAgeCategory, seen map[string]bool) models.CatalogImportItem {
+	label := strings.TrimSpace(it.Label)
+	item := models.CatalogImportItem{Kind: models.CatalogItemAgeCategory, Key: label}
+	if label == "" {
+		return failItem(item, "label is required")
+	}
+	if seen[label] {
+		return failItem(item, "duplicate label in catalog")
+	}
+	seen[label] = true
+	if it.MinAge < 0 || it.MaxAge < it.MinAge {
+		return failItem(item, "min_age must be >= 0 and max_age >= min_age")
+	}
+
+	if id, ok := run.ages[label]; ok {
+		item.ID = id
+		if run.in.OnConflict == CatalogConflictSkip {
+			item.Action = models.CatalogActionSkip
+			return item
+		}
+		item.Action = models.CatalogActionUpdate
+		if run.in.DryRun {
+			return item
+		}
+		if _, ae := s.categorySvc.UpdateAgeCategory(ctx, id, UpdateAgeCategoryInput{
+			Label:  &label,
+			MinAge: &it.MinAge,
+			MaxAge: &it.MaxAge,
+		}); ae != nil {
+			return failItem(item, ae.Message)
+		}
+		return item
+	}
+
+	item.Action = models.CatalogActionCreate
+	if run.in.DryRun {
+		run.ages[label] = 0
+		return item
+	}
+	out, ae := s.categorySvc.CreateAgeCategory(ctx, CreateAgeCategoryInput{
+		Label:  label,
+		MinAge: it.MinAge,
+		MaxAge: it.MaxAge,
+	})
+	if ae != nil {
+		return failItem(item, ae.Message)
+	}
+	item.ID = out.ID
+	run.ages[label] = out.ID
+	return item
+}
+
+func (s *CatalogService) importEducationCategory(ctx context.Context, run *catalogImport, it models.CatalogEducationCategory, seen map[string]bool) models.CatalogImportItem {
+	name := strings.TrimSpace(it.Name)
+	item := models.CatalogImportItem{Kind: models.CatalogItemEducationCategory, Key: name}
+	if name == "" {
+		return failItem(item, "name is required")
+	}
+	if seen[name] {
+		return failItem(item, "duplicate name in catalog")
+	}
+	seen[name] = true
+	if it.Color != nil {
+		if v := strings.TrimSpace(*it.Color); v != "" && !hexColorRe.MatchString(v) {
+			return failItem(item, "color must be a hex code like #RRGGBB")
+		}
+	}
+
+	if id, ok := run.edus[name]; ok {
+		item.ID = id
+		if run.in.OnConflict == CatalogConflictSkip {
+			item.Action = models.CatalogActionSkip
+			return item
+		}
+		item.Action = models.CatalogActionUpdate
+		if run.in.DryRun {
+			return item
+		}
+		if _, ae := s.categorySvc.UpdateEducationCategory(ctx, id, UpdateEducationCategoryInput{
+			Name:  &name,
+			Icon:  it.Icon,
+			Color: it.Color,
+		}); ae != nil {
+			return failItem(item, ae.Message)
+		}
+		return item
+	}
+
+	item.Action = models.CatalogActionCreate
+	if run.in.DryRun {
+		run.edus[name] = 0
+		return item
+	}
+	out, ae := s.categorySvc.CreateEducationCategory(ctx, CreateEducationCategoryInput{
+		Name:  name,
+		Icon:  it.Icon,
+		Color: it.Color,
+	})
+	if ae != nil {
+		return failItem(item, ae.Message)
+	}
+	item.ID = out.ID
+	run.edus[name] = out.ID
+	return item
+}
+
+func (s *CatalogService) importGame(ctx context.Context, run *catalogImport, it models.CatalogGame, seen map[string]bool) models.CatalogImportItem {
+	slug := strings.TrimSpace(it.Slug)
+	item := models.CatalogImportItem{Kind: models.CatalogItemGame, Key: slug}
+	if slug == "" || len(slug) > 150 || !slugRe.MatchString(slug) {
+		return failItem(item, "slug must be lowercase and dash-separated (e.g. color-match)")
+	}
+	if seen[slug] {
+		return failItem(item, "duplicate slug in catalog")
+	}
+	seen[slug] = true
+
+	title := strings.TrimSpace(it.Title)
+	if title == "" || len(title) > 150 {
+		return failItem(item, "title is required and must be <= 150 chars")
+	}
+	if _, err := normalizeDescription(it.Description); err != nil {
+		return failItem(item, catalogErrMessage(err))
+	}
+	if _, err := normalizeDifficulty(it.Difficulty); err != nil {
+		return failItem(item, catalogErrMessage(err))
+	}
+
+	ageID, ok := run.ages[strings.TrimSpace(it.AgeCategory)]
+	if !ok {
+		return failItem(item, "age category not found: "+it.AgeCategory)
+	}
+	eduIDs := make([]int64, 0, len(it.EducationCategories))
+	for _, name := range it.EducationCategories {
+		id, ok := run.edus[strings.TrimSpace(name)]
+		if !ok {
+			return failItem(item, "education category not found: "+name)
+		}
+		eduIDs = append(eduIDs, id)
+	}
+
+	var build *zip.File
+	if it.Build != "" {
+		build = run.builds[it.Build]
+		if build == nil || path.Ext(it.Build) != ".zip" {
+			return failItem(item, "build not found in archive: "+it.Build)
+		}
+	}
+
+	ref, exists := run.games[slug]
+	switch {
+	case exists && ref.Deleted:
+		item.ID = ref.ID
+		return failItem(item, "slug belongs to a deleted game")
+	case exists && run.in.OnConflict == CatalogConflictSkip:
+		item.ID = ref.ID
+		item.Action = models.CatalogActionSkip
+		return item
+	case exists:
+		item.ID = ref.ID
+		item.Action = models.CatalogActionUpdate
+	default:
+		item.Action = models.CatalogActionCreate
+	}
+
+	if run.in.DryRun {
+		if !exists {
+			run.games[slug] = repos.CatalogGameRef{}
+		}
+		if build != nil {
+			item.Build = "would_upload"
+		}
+		return item
+	}
+
+	free := it.Free
+	if exists {
+		difficulty := it.Difficulty
+		description := it.Description
+		if _, err := s.gameSvc.UpdateAdminGame(ctx, ref.ID, models.UpdateGameRequest{
+			Title:                &title,
+			Description:          &description,
+			Difficulty:           &difficulty,
+			AgeCategoryID:        &ageID,
+			EducationCategoryIDs: &eduIDs,
+			Free:                 &free,
+		}); err != nil {
+			return failItem(item, catalogErrMessage(err))
+		}
+	} else {
+		out, err := s.gameSvc.CreateAdminGame(ctx, run.adminID, models.CreateGameRequest{
+			Title:                title,
+			Slug:                 slug,
+			Description:          it.Description,
+			Difficulty:           it.Difficulty,
+			AgeCategoryID:        ageID,
+			EducationCategoryIDs: eduIDs,
+			Free:                 &free,
+		})
+		if err != nil {
+			return failItem(item, catalogErrMessage(err))
+		}
+		item.ID = out.ID
+		run.games[slug] = repos.CatalogGameRef{ID: out.ID}
+	}
+
+	if build == nil {
+		return item
+	}
+	if err := s.importBuild(ctx, item.ID, build); err != nil {
+		item.Build = catalogErrMessage(err)
+		return item
+	}
+	item.Build = "uploaded"
+
+	// Only games that arrive with a playable build are published.
+	if it.Status == models.GameStatusActive {
+		if _, err := s.gameSvc.PublishAdminGame(ctx, item.ID); err == nil {
+			item.Published = true
+		}
+	}
+	return item
+}
+
+// importBuild spools the nested ZIP to disk because uploads need a seekable
+// file, then runs the regular upload pipeline.
+func (s *CatalogService) importBuild(ctx context.Context, gameID int64, zf *zip.File) error {
+	if s.zipMaxBytes > 0 && zf.UncompressedSize64 > uint64(s.zipMaxBytes) {
+		return utils.ErrZipTooLarge(s.zipMaxBytes)
+	}
+
+	rc, err := zf.Open()
+	if err != nil {
+		return utils.ErrInvalidZip("invalid build in archive")
+	}
+	defer func() { _ = rc.Close() }()
+
+	tmp, err := os.CreateTemp("", "kids-planet-catalog-build-")
+	if err != nil {
+		return utils.ErrInternal()
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	size, err := io.Copy(tmp, rc)
+	if err != nil {
+		return utils.ErrInvalidZip("invalid build in archive")
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return utils.ErrInternal()
+	}
+
+	_, err = s.gameSvc.UploadAdminGameZip(ctx, gameID, path.Base(zf.Name), tmp, size, "application/zip")
+	return err
+}
+
+func failItem(item models.CatalogImportItem, msg string) models.CatalogImportItem {
+	item.Action = models.CatalogActionError
+	item.Error = msg
+	return item
+}
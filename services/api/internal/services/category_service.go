@@ -12,17 +12,88 @@ import (
 )
 
 type CategoryService struct {
-	ageRepo *repos.AgeCategoryRepo
-	eduRepo *repos.EducationCategoryRepo
+	ageRepo      *repos.AgeCategoryRepo
+	eduRepo      *repos.EducationCategoryRepo
+	catalogCache *CatalogCache
+	localizer    *Localizer
 }
 
-func NewCategoryService(ageRepo *repos.AgeCategoryRepo, eduRepo *repos.EducationCategoryRepo) *CategoryService {
+func NewCategoryService(ageRepo *repos.AgeCategoryRepo, eduRepo *repos.EducationCategoryRepo, catalogCache *CatalogCache, localizer *Localizer) *CategoryService {
 	return &CategoryService{
-		ageRepo: ageRepo,
-		eduRepo: eduRepo,
+		ageRepo:      ageRepo,
+		eduRepo:      eduRepo,
+		catalogCache: catalogCache,
+		localizer:    localizer,
 	}
 }
 
+type PublicAgeCategoryDTO struct {
+	ID     int64  `json:"id"`
+	Label  string `json:"label"`
+	MinAge int    `json:"min_age"`
+	MaxAge int    `json:"max_age"`
+}
+
+type PublicCategoriesDTO struct {
+	AgeCategories       []PublicAgeCategoryDTO       `json:"age_categories"`
+	EducationCategories []PublicEducationCategoryDTO `json:"education_categories"`
+	Locale              string                       `json:"locale"`
+}
+
+const publicCategoriesLimit = 500
+
+// ListPublicCategories backs /api/categories; both lists are cached together.
+func (s *CategoryService) ListPublicCategories(ctx context.Context, locale string) (*PublicCategoriesDTO, *utils.AppError) {
+	locale = s.localizer.Resolve(locale)
+	cacheKey, cacheable := s.catalogCache.categoriesKey(ctx, locale)
+	if cacheable {
+		var cached PublicCategoriesDTO
+		if s.catalogCache.get(ctx, cacheKey, &cached) {
+			return &cached, nil
+		}
+	}
+
+	ageItems, err := s.ageRepo.List(ctx, "", 1, publicCategoriesLimit, nil)
+	if err != nil {
+		ae := utils.ErrInternal()
+		return nil, &ae
+	}
+	eduItems, err := s.eduRepo.List(ctx, "", 1, publicCategoriesLimit, nil)
+	if err != nil {
+		ae := utils.ErrInternal()
+		return nil, &ae
+	}
+
+	out := &PublicCategoriesDTO{
+		AgeCategories:       make([]PublicAgeCategoryDTO, 0, len(ageItems)),
+		EducationCategories: make([]PublicEducationCategoryDTO, 0, len(eduItems)),
+	}
+	for _, item := range ageItems {
+		out.AgeCategories = append(out.AgeCategories, PublicAgeCategoryDTO{
+			ID:     item.ID,
+			Label:  item.Label,
+			MinAge: item.MinAge,
+			MaxAge: item.MaxAge,
+		})
+	}
+	for _, item := range eduItems {
+		out.EducationCategories = append(out.EducationCategories, PublicEducationCategoryDTO{
+			ID:   item.ID,
+			Name: item.Name,
+		})
+	}
+
+	if err := s.localizer.LocalizeCategories(ctx, locale, out); err != nil {
+		ae := err.(utils.AppError)
+		return nil, &ae
+	}
+
+	if cacheable {
+		s.catalogCache.set(ctx, cacheKey, out)
+	}
+	return out, nil
+}
+
 type CreateAgeCategoryInput struct {
 	Label  string
 	MinAge int
@@ -35,13 +106,26 @@ type UpdateAgeCategoryInput struct {
 	MaxAge *int
 }
 
-func (s *CategoryService) ListAgeCategories(ctx context.Context, q string, page, limit int) ([]repos.AgeCategory, *utils.AppError) {
-	items, err := s.ageRepo.List(ctx, strings.TrimSpace(q), page, limit)
+// ListAgeCategories returns the next page cursor when the page came back full.
+func (s *CategoryService) ListAgeCategories(ctx context.Context, q string, page, limit int, cursor string) ([]repos.AgeCategory, *string, *utils.AppError) {
+	after, err := decodeAgeCategoryCursor(cursor)
+	if err != nil {
+		ae := err.(utils.AppError)
+		return nil, nil, &ae
+	}
+
+	items, err := s.ageRepo.List(ctx, strings.TrimSpace(q), page, limit, after)
 	if err != nil {
 		ae := utils.ErrInternal()
-		return nil, &ae
+		return nil, nil, &ae
 	}
-	return items, nil
+
+	var next *string
+	if n := len(items); n > 0 && n == listLimit(limit) {
+		last := items[n-1]
+		next = encodeCursorPtr(ageCategoryCursor{MinAge: last.MinAge, MaxAge: last.MaxAge, ID: last.ID})
+	}
+	return items, next, nil
 }
 
 func (s *CategoryService) CreateAgeCategory(ctx context.Context, in CreateAgeCategoryInput) (*repos.AgeCategory, *utils.AppError) {
@@ -86,6 +170,7 @@ func (s *CategoryService) CreateAgeCategory(ctx context.Context, in CreateAgeCat
 		ae := utils.ErrInternal()
 		return nil, &ae
 	}
+	s.catalogCache.InvalidateCategories(ctx)
 	return out, nil
 }
 
@@ -150,6 +235,7 @@ func (s *CategoryService) UpdateAgeCategory(ctx context.Context, id int64, in Up
 		ae := utils.ErrInternal()
 		return nil, &ae
 	}
+	s.catalogCache.InvalidateCategories(ctx)
 	return out, nil
 }
 
@@ -170,6 +256,7 @@ func (s *CategoryService) DeleteAgeCategory(ctx context.Context, id int64) *util
 		ae := utils.ErrInternal()
 		return &ae
 	}
+	s.catalogCache.InvalidateCategories(ctx)
 	return nil
 }
 
@@ -187,13 +274,25 @@ type UpdateEducationCategoryInput struct {
 
 var hexColorRe = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
 
-func (s *CategoryService) ListEducationCategories(ctx context.Context, q string, page, limit int) ([]repos.EducationCategory, *utils.AppError) {
-	items, err := s.eduRepo.List(ctx, strings.TrimSpace(q), page, limit)
+func (s *CategoryService) ListEducationCategories(ctx context.Context, q string, page, limit int, cursor string) ([]repos.EducationCategory, *string, *utils.AppError) {
+	after, err := decodeEducationCategoryCursor(cursor)
+	if err != nil {
+		ae := err.(utils.AppError)
+		return nil, nil, &ae
+	}
+
+	items, err := s.eduRepo.List(ctx, strings.TrimSpace(q), page, limit, after)
 	if err != nil {
 		ae := utils.ErrInternal()
-		return nil, &ae
+		return nil, nil, &ae
+	}
+
+	var next *string
+	if n := len(items); n > 0 && n == listLimit(limit) {
+		last := items[n-1]
+		next = encodeCursorPtr(educationCategoryCursor{Name: last.Name, ID: last.ID})
 	}
-	return items, nil
+	return items, next, nil
 }
 
 func (s *CategoryService) CreateEducationCategory(ctx context.Context, in CreateEducationCategoryInput) (*repos.EducationCategory, *utils.AppError) {
@@ -258,6 +357,7 @@ func (s *CategoryService) CreateEducationCategory(ctx context.Context, in Create
 		ae := utils.ErrInternal()
 		return nil, &ae
 	}
+	s.catalogCache.InvalidateCategories(ctx)
 	return out, nil
 }
 
@@ -335,6 +435,7 @@ func (s *CategoryService) UpdateEducationCategory(ctx context.Context, id int64,
 		ae := utils.ErrInternal()
 		return nil, &ae
 	}
+	s.catalogCache.InvalidateCategories(ctx)
 	return out, nil
 }
 
@@ -355,6 +456,7 @@ func (s *CategoryService) DeleteEducationCategory(ctx context.Context, id int64)
 		ae := utils.ErrInternal()
 		return &ae
 	}
+	s.catalogCache.InvalidateCategories(ctx)
 	return nil
 }
 
@@ -0,0 +1,382 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+const (
+	maxCollectionGames         = 100
+	defaultCollectionShelfSize = 12
+	maxCollectionShelfSize     = 50
+	maxHeroImageURLLen         = 2048
+)
+
+// CollectionService manages curated shelves. Admins see every collection;
+// public reads only show live ones (active and inside their date window)
+// and only their active games.
+type CollectionService struct {
+	collectionRepo *repos.CollectionRepo
+	gameRepo       *repos.GameRepo
+	catalogCache   *CatalogCache
+	localizer      *Localizer
+}
+
+func NewCollectionService(collectionRepo *repos.CollectionRepo, gameRepo *repos.GameRepo, catalogCache *CatalogCache, localizer *Localizer) *CollectionService {
+	return &CollectionService{
+		collectionRepo: collectionRepo,
+		gameRepo:       gameRepo,
+		catalogCache:   catalogCache,
+		localizer:      localizer,
+	}
+}
+
+type PublicCollectionDTO struct {
+	ID           int64             `json:"id"`
+	Slug         string            `json:"slug"`
+	Title        string            `json:"title"`
+	Description  *string           `json:"description"`
+	HeroImageURL *string           `json:"hero_image_url"`
+	EndsAt       *time.Time        `json:"ends_at"`
+	Games        []GameListItemDTO `json:"games"`
+}
+
+type PublicCollectionListDTO struct {
+	Items []PublicCollectionDTO `json:"items"`
+}
+
+type AdminCollectionListDTO struct {
+	Items []models.AdminCollectionDTO `json:"items"`
+}
+
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	v := t.Time.UTC()
+	return &v
+}
+
+func collectionIsLive(c repos.Collection, now time.Time) bool {
+	return c.Active &&
+		(!c.StartsAt.Valid || !c.StartsAt.Time.After(now)) &&
+		(!c.EndsAt.Valid || c.EndsAt.Time.After(now))
+}
+
+func toAdminCollectionDTO(c repos.Collection, now time.Time) models.AdminCollectionDTO {
+	return models.AdminCollectionDTO{
+		ID:           c.ID,
+		Slug:         c.Slug,
+		Title:        c.Title,
+		Description:  toNullableString(c.Description),
+		HeroImageURL: toNullableString(c.HeroImageURL),
+		Position:     c.Position,
+		Active:       c.Active,
+		StartsAt:     nullTimePtr(c.StartsAt),
+		EndsAt:       nullTimePtr(c.EndsAt),
+		Live:         collectionIsLive(c, now),
+		GameCount:    c.GameCount,
+		CreatedAt:    c.CreatedAt.UTC(),
+		UpdatedAt:    c.UpdatedAt.UTC(),
+	}
+}
+
+func (s *CollectionService) ListCollections(ctx context.Context) (*AdminCollectionListDTO, error) {
+	items, err := s.collectionRepo.List(ctx)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	now := time.Now()
+	out := make([]models.AdminCollectionDTO, 0, len(items))
+	for _, it := range items {
+		out = append(out, toAdminCollectionDTO(it, now))
+	}
+	return &AdminCollectionListDTO{Items: out}, nil
+}
+
+func (s *CollectionService) GetCollection(ctx context.Context, id int64) (*models.AdminCollectionDTO, error) {
+	c, err := s.collectionRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("collection not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	refs, err := s.collectionRepo.ListGameRefs(ctx, id)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+
+	dto := toAdminCollectionDTO(*c, time.Now())
+	dto.Games = make([]models.CollectionGameDTO, 0, len(refs))
+	for _, ref := range refs {
+		dto.Games = append(dto.Games, models.CollectionGameDTO{
+			GameID:   ref.GameID,
+			Title:    ref.Title,
+			Slug:     ref.Slug,
+			Status:   models.GameStatus(ref.Status),
+			Position: ref.Position,
+		})
+	}
+	return &dto, nil
+}
+
+func normalizeHeroImageURL(raw string) (sql.NullString, error) {
+	v := strings.TrimSpace(raw)
+	if v == "" {
+		return sql.NullString{}, nil
+	}
+	if len(v) > maxHeroImageURLLen {
+		return sql.NullString{}, utils.ErrBadRequest(fmt.Sprintf("hero_image_url must be <= %d chars", maxHeroImageURLLen))
+	}
+	u, err := url.Parse(v)
+	if err != nil {
+		return sql.NullString{}, utils.ErrBadRequest("hero_image_url must be an http(s) URL or an absolute path")
+	}
+	isAbsPath := u.Scheme == "" && u.Host == "" && strings.HasPrefix(v, "/") && !strings.HasPrefix(v, "//")
+	isHTTP := (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	if !isAbsPath && !isHTTP {
+		return sql.NullString{}, utils.ErrBadRequest("hero_image_url must be an http(s) URL or an absolute path")
+	}
+	return sql.NullString{String: v, Valid: true}, nil
+}
+
+func (s *CollectionService) collectionInput(ctx context.Context, req models.CollectionRequest, excludeID *int64) (repos.CollectionInput, error) {
+	title := strings.TrimSpace(req.Title)
+	slug := strings.TrimSpace(strings.ToLower(req.Slug))
+
+	if title == "" {
+		return repos.CollectionInput{}, utils.ErrBadRequest("title is required")
+	}
+	if len(title) > 150 {
+		return repos.CollectionInput{}, utils.ErrBadRequest("title must be <= 150 chars")
+	}
+	if slug == "" {
+		return repos.CollectionInput{}, utils.ErrBadRequest("slug is required")
+	}
+	if len(slug) > 150 || !slugRe.MatchString(slug) {
+		return repos.CollectionInput{}, utils.ErrBadRequest("slug must be lowercase letters, digits and single hyphens (<= 150 chars)")
+	}
+	description, err := normalizeDescription(req.Description)
+	if err != nil {
+		return repos.CollectionInput{}, err
+	}
+	hero, err := normalizeHeroImageURL(req.HeroImageURL)
+	if err != nil {
+		return repos.CollectionInput{}, err
+	}
+
+	in := repos.CollectionInput{
+		Slug:         slug,
+		Title:        title,
+		Description:  description,
+		HeroImageURL: hero,
+		Position:     req.Position,
+		Active:       true,
+	}
+	if req.Active != nil {
+		in.Active = *req.Active
+	}
+	if req.StartsAt != nil {
+		in.StartsAt = sql.NullTime{Time: req.StartsAt.UTC(), Valid: true}
+	}
+	if req.EndsAt != nil {
+		in.EndsAt = sql.NullTime{Time: req.EndsAt.UTC(), Valid: true}
+	}
+	if in.StartsAt.Valid && in.EndsAt.Valid && !in.EndsAt.Time.After(in.StartsAt.Time) {
+		return repos.CollectionInput{}, utils.ErrBadRequest("ends_at must be after starts_at")
+	}
+
+	exists, err := s.collectionRepo.SlugExists(ctx, slug, excludeID)
+	if err != nil {
+		return repos.CollectionInput{}, utils.ErrInternal()
+	}
+	if exists {
+		return repos.CollectionInput{}, utils.ErrBadRequest("slug already exists")
+	}
+	return in, nil
+}
+
+func (s *CollectionService) CreateCollection(ctx context.Context, adminID int64, req models.CollectionRequest) (*models.AdminCollectionDTO, error) {
+	in, err := s.collectionInput(ctx, req, nil)
+	if err != nil {
+		return nil, err
+	}
+	id, err := s.collectionRepo.Create(ctx, adminID, in)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, utils.ErrBadRequest("slug already exists")
+		}
+		return nil, utils.ErrInternal()
+	}
+	s.catalogCache.InvalidateCollections(ctx)
+	return s.GetCollection(ctx, id)
+}
+
+func (s *CollectionService) UpdateCollection(ctx context.Context, id int64, req models.CollectionRequest) (*models.AdminCollectionDTO, error) {
+	in, err := s.collectionInput(ctx, req, &id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.collectionRepo.Update(ctx, id, in); err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("collection not found")
+		}
+		if isUniqueViolation(err) {
+			return nil, utils.ErrBadRequest("slug already exists")
+		}
+		return nil, utils.ErrInternal()
+	}
+	s.catalogCache.InvalidateCollections(ctx)
+	return s.GetCollection(ctx, id)
+}
+
+func (s *CollectionService) DeleteCollection(ctx context.Context, id int64) error {
+	if err := s.collectionRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return utils.ErrNotFound("collection not found")
+		}
+		return utils.ErrInternal()
+	}
+	s.catalogCache.InvalidateCollections(ctx)
+	return nil
+}
+
+// SetCollectionGames replaces the membership. Duplicate ids keep their first
+// position; games of any status may be added and show up publicly once
+// active.
+func (s *CollectionService) SetCollectionGames(ctx context.Context, id int64, req models.SetCollectionGamesRequest) (*models.AdminCollectionDTO, error) {
+	seen := make(map[int64]struct{}, len(req.GameIDs))
+	gameIDs := make([]int64, 0, len(req.GameIDs))
+	for _, gameID := range req.GameIDs {
+		if gameID < 1 {
+			return nil, utils.ErrBadRequest("game_ids must contain integers >= 1")
+		}
+		if _, ok := seen[gameID]; ok {
+			continue
+		}
+		seen[gameID] = struct{}{}
+		gameIDs = append(gameIDs, gameID)
+	}
+	if len(gameIDs) > maxCollectionGames {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("a collection holds at most %d games", maxCollectionGames))
+	}
+
+	if err := s.collectionRepo.ReplaceGames(ctx, id, gameIDs); err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("collection not found")
+		}
+		if isFKViolation(err) {
+			return nil, utils.ErrBadRequest("game_ids contains unknown games")
+		}
+		return nil, utils.ErrInternal()
+	}
+	s.catalogCache.InvalidateCollections(ctx)
+	return s.GetCollection(ctx, id)
+}
+
+func (s *CollectionService) toPublicCollection(ctx context.Context, c repos.Collection, gamesLimit int, locale string) (*PublicCollectionDTO, error) {
+	items, err := s.gameRepo.ListCollectionGamesPublic(ctx, c.ID, gamesLimit)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	games := make([]GameListItemDTO, 0, len(items))
+	for _, it := range items {
+		games = append(games, toGameListItemDTO(it))
+	}
+	if err := s.localizer.LocalizeGameList(ctx, locale, games); err != nil {
+		return nil, err
+	}
+	return &PublicCollectionDTO{
+		ID:           c.ID,
+		Slug:         c.Slug,
+		Title:        c.Title,
+		Description:  toNullableString(c.Description),
+		HeroImageURL: toNullableString(c.HeroImageURL),
+		EndsAt:       nullTimePtr(c.EndsAt),
+		Games:        games,
+	}, nil
+}
+
+// ListPublicCollections returns live shelves with up to gamesLimit games
+// each; shelves with no active games are left out.
+func (s *CollectionService) ListPublicCollections(ctx context.Context, gamesLimit int, locale string) (*PublicCollectionListDTO, error) {
+	if gamesLimit == 0 {
+		gamesLimit = defaultCollectionShelfSize
+	}
+	if gamesLimit < 1 || gamesLimit > maxCollectionShelfSize {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("games_limit must be between 1 and %d", maxCollectionShelfSize))
+	}
+	locale = s.localizer.Resolve(locale)
+
+	cacheKey, cacheable := s.catalogCache.collectionsKey(ctx, "", gamesLimit, locale)
+	if cacheable {
+		var cached PublicCollectionListDTO
+		if s.catalogCache.get(ctx, cacheKey, &cached) {
+			return &cached, nil
+		}
+	}
+
+	collections, err := s.collectionRepo.ListLive(ctx, time.Now())
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	out := make([]PublicCollectionDTO, 0, len(collections))
+	for _, c := range collections {
+		dto, err := s.toPublicCollection(ctx, c, gamesLimit, locale)
+		if err != nil {
+			return nil, err
+		}
+		if len(dto.Games) == 0 {
+			continue
+		}
+		out = append(out, *dto)
+	}
+
+	list := &PublicCollectionListDTO{Items: out}
+	if cacheable {
+		s.catalogCache.set(ctx, cacheKey, list)
+	}
+	return list, nil
+}
+
+func (s *CollectionService) GetPublicCollection(ctx context.Context, slug, locale string) (*PublicCollectionDTO, error) {
+	slug = strings.TrimSpace(strings.ToLower(slug))
+	if slug == "" || len(slug) > 150 || !slugRe.MatchString(slug) {
+		return nil, utils.ErrNotFound("collection not found")
+	}
+	locale = s.localizer.Resolve(locale)
+
+	cacheKey, cacheable := s.catalogCache.collectionsKey(ctx, slug, maxCollectionGames, locale)
+	if cacheable {
+		var cached PublicCollectionDTO
+		if s.catalogCache.get(ctx, cacheKey, &cached) {
+			return &cached, nil
+		}
+	}
+
+	c, err := s.collectionRepo.GetLiveBySlug(ctx, slug, time.Now())
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("collection not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	dto, err := s.toPublicCollection(ctx, *c, maxCollectionGames, locale)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable {
+		s.catalogCache.set(ctx, cacheKey, dto)
+	}
+	return dto, nil
+}
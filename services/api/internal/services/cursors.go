@@ -0,0 +1,129 @@
+package services
+
+import (
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+// Cursor payloads use short keys; the sort name is embedded so a cursor from
+// one ordering cannot be replayed against another.
+
+type gameListCursor struct {
+	Sort      string    `json:"s"`
+	CreatedAt time.Time `json:"c"`
+	PlayCount int64     `json:"p,omitempty"`
+	Rating    float64   `json:"r,omitempty"`
+	ID        int64     `json:"i"`
+}
+
+type adminGameCursor struct {
+	UpdatedAt time.Time `json:"u"`
+	ID        int64     `json:"i"`
+}
+
+type ageCategoryCursor struct {
+	MinAge int   `json:"a"`
+	MaxAge int   `json:"b"`
+	ID     int64 `json:"i"`
+}
+
+type educationCategoryCursor struct {
+	Name string `json:"n"`
+	ID   int64  `json:"i"`
+}
+
+func errInvalidCursor() utils.AppError {
+	return utils.ErrBadRequest("cursor is invalid")
+}
+
+// listLimit mirrors the clamping the repos apply so callers can tell whether
+// a page came back full.
+func listLimit(limit int) int {
+	if limit <= 0 {
+		return 24
+	}
+	if limit > 100 {
+		return 100
+	}
+	return limit
+}
+
+// hasNextPage is exact for offset pages; in cursor mode a full page is the
+// only signal, so the last page may come back empty.
+func hasNextPage(cursorMode bool, page, limit, n, total int) bool {
+	if n == 0 || n < limit {
+		return false
+	}
+	if cursorMode {
+		return true
+	}
+	return (page-1)*limit+n < total
+}
+
+func decodeGameListCursor(token string, sort repos.GameListSort) (*repos.GameListCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	if sort == repos.GameSortRelevance {
+		return nil, utils.ErrBadRequest("cursor is only supported for sort newest, popular or top_rated")
+	}
+	var c gameListCursor
+	if err := utils.DecodeCursor(token, &c); err != nil || c.Sort != string(sort) || c.ID < 1 {
+		return nil, errInvalidCursor()
+	}
+	return &repos.GameListCursor{CreatedAt: c.CreatedAt, PlayCount: c.PlayCount, Rating: c.Rating, ID: c.ID}, nil
+}
+
+func encodeGameListCursor(sort repos.GameListSort, last repos.GameListItem) *string {
+	c := gameListCursor{Sort: string(sort), CreatedAt: last.CreatedAt, ID: last.ID}
+	switch sort {
+	case repos.GameSortPopular:
+		c.PlayCount = last.PlayCount
+	case repos.GameSortTopRated:
+		c.Rating = last.Rating
+	}
+	return encodeCursorPtr(c)
+}
+
+func decodeAdminGameCursor(token string) (*repos.AdminGameCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	var c adminGameCursor
+	if err := utils.DecodeCursor(token, &c); err != nil || c.ID < 1 {
+		return nil, errInvalidCursor()
+	}
+	return &repos.AdminGameCursor{UpdatedAt: c.UpdatedAt, ID: c.ID}, nil
+}
+
+func decodeAgeCategoryCursor(token string) (*repos.AgeCategoryCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	var c ageCategoryCursor
+	if err := utils.DecodeCursor(token, &c); err != nil || c.ID < 1 {
+		return nil, errInvalidCursor()
+	}
+	return &repos.AgeCategoryCursor{MinAge: c.MinAge, MaxAge: c.MaxAge, ID: c.ID}, nil
+}
+
+func decodeEducationCategoryCursor(token string) (*repos.EducationCategoryCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	var c educationCategoryCursor
+	if err := utils.DecodeCursor(token, &c); err != nil || c.ID < 1 {
+		return nil, errInvalidCursor()
+	}
+	return &repos.EducationCategoryCursor{Name: c.Name, ID: c.ID}, nil
+}
+
+func encodeCursorPtr(v any) *string {
+	token, err := utils.EncodeCursor(v)
+	if err != nil {
+		return nil
+	}
+	return &token
+}
@@ -3,16 +3,21 @@ package services
 import (
 	"context"
 
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
 )
 
 type DashboardService struct {
-	dashboardRepo *repos.DashboardRepo
+	dashboardRepo        *repos.DashboardRepo
+	popularityWindowDays int
 }
 
-func NewDashboardService(dashboardRepo *repos.DashboardRepo) *DashboardService {
-	return &DashboardService{dashboardRepo: dashboardRepo}
+func NewDashboardService(dashboardRepo *repos.DashboardRepo, popularityCfg config.PopularityConfig) *DashboardService {
+	return &DashboardService{
+		dashboardRepo:        dashboardRepo,
+		popularityWindowDays: popularityCfg.WindowDays,
+	}
 }
 
 type DashboardTopGameDTO struct {
@@ -21,11 +26,18 @@ type DashboardTopGameDTO struct {
 	Plays  int    `json:"plays"`
 }
 
+type DashboardTopFavoritedDTO struct {
+	GameID    int64  `json:"game_id"`
+	Title     string `json:"title"`
+	Favorites int    `json:"favorites"`
+}
+
 type DashboardOverviewDTO struct {
-	SessionsToday    int                   `json:"sessions_today"`
-	TopGames         []DashboardTopGameDTO `json:"top_games"`
-	TotalActiveGames int                   `json:"total_active_games"`
-	TotalPlayers     int                   `json:"total_players"`
+	SessionsToday    int                        `json:"sessions_today"`
+	TopGames         []DashboardTopGameDTO      `json:"top_games"`
+	TopFavorited     []DashboardTopFavoritedDTO `json:"top_favorited"`
+	TotalActiveGames int                        `json:"total_active_games"`
+	TotalPlayers     int                        `json:"total_players"`
 }
 
 func (s *DashboardService) GetOverview(ctx context.Context) (*DashboardOverviewDTO, *utils.AppError) {
@@ -35,7 +47,13 @@ func (s *DashboardService) GetOverview(ctx context.Context) (*DashboardOverviewD
 		return nil, &ae
 	}
 
-	topGames, err := s.dashboardRepo.ListTopGames(ctx, 5)
+	topGames, err := s.dashboardRepo.ListTopGames(ctx, 5, s.popularityWindowDays)
+	if err != nil {
+		ae := utils.ErrInternal()
+		return nil, &ae
+	}
+
+	topFavorited, err := s.dashboardRepo.ListTopFavoritedGames(ctx, 5)
 	if err != nil {
 		ae := utils.ErrInternal()
 		return nil, &ae
@@ -62,9 +80,19 @@ func (s *DashboardService) GetOverview(ctx context.Context) (*DashboardOverviewD
 		})
 	}
 
+	outFavorited := make([]DashboardTopFavoritedDTO, 0, len(topFavorited))
+	for _, it := range topFavorited {
+		outFavorited = append(outFavorited, DashboardTopFavoritedDTO{
+			GameID:    it.GameID,
+			Title:     it.Title,
+			Favorites: it.Favorites,
+		})
+	}
+
 	return &DashboardOverviewDTO{
 		SessionsToday:    sessionsToday,
 		TopGames:         outTop,
+		TopFavorited:     outFavorited,
 		TotalActiveGames: activeGames,
 		TotalPlayers:     totalPlayers,
 	}, nil
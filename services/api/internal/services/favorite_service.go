@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+const maxFavoritesLimit = 100
+
+type FavoriteService struct {
+	favoriteRepo *repos.FavoriteRepo
+	gameRepo     *repos.GameRepo
+}
+
+func NewFavoriteService(favoriteRepo *repos.FavoriteRepo, gameRepo *repos.GameRepo) *FavoriteService {
+	return &FavoriteService{
+		favoriteRepo: favoriteRepo,
+		gameRepo:     gameRepo,
+	}
+}
+
+// Favorite only accepts games players can currently see.
+func (s *FavoriteService) Favorite(ctx context.Context, playerID string, gameID int64) (*models.PlayerFavoriteStatus, error) {
+	playerID = normalizePlayerID(playerID)
+	if playerID == "" {
+		return nil, utils.ErrUnauthorized()
+	}
+	if gameID < 1 {
+		return nil, utils.ErrBadRequest("game_id must be an integer >= 1")
+	}
+
+	game, err := s.gameRepo.GetDeletion(ctx, gameID)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	if game.Status != string(models.GameStatusActive) {
+		return nil, utils.ErrNotFound("game not found")
+	}
+
+	if err := s.favoriteRepo.Add(ctx, playerID, gameID); err != nil {
+		// The token outlived its player account.
+		if isFKViolation(err) {
+			return nil, utils.ErrUnauthorized()
+		}
+		return nil, utils.ErrInternal()
+	}
+	return &models.PlayerFavoriteStatus{GameID: gameID, Favorited: true}, nil
+}
+
+// Unfavorite is idempotent and works for games that are no longer active.
+func (s *FavoriteService) Unfavorite(ctx context.Context, playerID string, gameID int64) (*models.PlayerFavoriteStatus, error) {
+	playerID = normalizePlayerID(playerID)
+	if playerID == "" {
+		return nil, utils.ErrUnauthorized()
+	}
+	if gameID < 1 {
+		return nil, utils.ErrBadRequest("game_id must be an integer >= 1")
+	}
+
+	if err := s.favoriteRepo.Remove(ctx, playerID, gameID); err != nil {
+		return nil, utils.ErrInternal()
+	}
+	return &models.PlayerFavoriteStatus{GameID: gameID, Favorited: false}, nil
+}
+
+func (s *FavoriteService) ListFavorites(ctx context.Context, playerID string, page, limit int) (*models.PlayerFavoritesResponse, error) {
+	playerID = normalizePlayerID(playerID)
+	if playerID == "" {
+		return nil, utils.ErrUnauthorized()
+	}
+	if page <= 0 {
+		page = 1
+	}
+	if limit <= 0 {
+		limit = 24
+	}
+	if limit > maxFavoritesLimit {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("limit must be an integer between 1 and %d", maxFavoritesLimit))
+	}
+
+	rows, total, err := s.favoriteRepo.ListByPlayerID(ctx, playerID, page, limit)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+
+	out := make([]models.PlayerFavoriteItem, 0, len(rows))
+	for _, it := range rows {
+		out = append(out, models.PlayerFavoriteItem{
+			GameID:      it.GameID,
+			Title:       it.Title,
+			Slug:        it.Slug,
+			Thumbnail:   toNullableString(it.Thumbnail),
+			FavoritedAt: it.FavoritedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		})
+	}
+
+	return &models.PlayerFavoritesResponse{
+		Data: out,
+		Pagination: models.PlayerHistoryPagination{
+			Page:  page,
+			Limit: limit,
+			Total: total,
+		},
+	}, nil
+}
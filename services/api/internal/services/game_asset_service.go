@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/storage"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+const (
+	assetCacheImmutable  = "public, max-age=31536000, immutable"
+	assetCacheRevalidate = "public, no-cache"
+)
+
+// Hex runs in a file name such as app.3f9a2b1c.js; one that starts the file's
+// recorded sha256 marks the name as content-addressed.
+var hashedAssetTokenRe = regexp.MustCompile(`[0-9a-f]{8,64}`)
+
+type GameAssetService struct {
+	buildRepo *repos.GameBuildRepo
+	store     storage.ObjectStore
+}
+
+func NewGameAssetService(buildRepo *repos.GameBuildRepo, store storage.ObjectStore) *GameAssetService {
+	return &GameAssetService{buildRepo: buildRepo, store: store}
+}
+
+type GameAsset struct {
+	Body         io.ReadSeekCloser
+	Size         int64
+	ETag         string
+	ContentType  string
+	CacheControl string
+	LastModified string
+}
+
+// OpenCurrent opens a file of the game's current build. Games without a
+// recorded build are served from the legacy current/ prefix. The caller owns
+// Body.
+func (s *GameAssetService) OpenCurrent(ctx context.Context, gameID int64, assetPath string) (*GameAsset, error) {
+	if gameID < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	rel := assetRelPath(assetPath)
+	build, err := s.buildRepo.GetCurrent(ctx, gameID)
+	if err == nil {
+		return s.openBuildFile(ctx, gameID, build.ID, rel)
+	}
+	if !errors.Is(err, repos.ErrNotFound) {
+		return nil, utils.ErrInternal()
+	}
+
+	asset, err := s.open(ctx, gameID, "current", rel)
+	if err != nil {
+		return nil, err
+	}
+	asset.CacheControl = assetCacheControl(rel, "")
+	return asset, nil
+}
+
+// OpenBuild opens a file of one build, which is what game_url points at.
+func (s *GameAssetService) OpenBuild(ctx context.Context, gameID int64, buildID int64, assetPath string) (*GameAsset, error) {
+	if gameID < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	return s.openBuildFile(ctx, gameID, buildID, assetRelPath(assetPath))
+}
+
+func (s *GameAssetService) openBuildFile(ctx context.Context, gameID int64, buildID int64, rel string) (*GameAsset, error) {
+	f, err := s.buildRepo.GetFile(ctx, gameID, buildID, rel)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("asset not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	asset, err := s.open(ctx, gameID, fmt.Sprintf("builds/%d", buildID), rel)
+	if err != nil {
+		return nil, err
+	}
+	asset.CacheControl = assetCacheControl(rel, f.SHA256.String)
+	return asset, nil
+}
+
+func assetRelPath(assetPath string) string {
+	rel := strings.TrimPrefix(assetPath, "/")
+	if rel == "" || strings.HasSuffix(rel, "/") {
+		rel += "index.html"
+	}
+	return rel
+}
+
+// OpenMedia opens an image variant. Media keys embed a random token per
+// upload, so they are always safe to cache forever.
+func (s *GameAssetService) OpenMedia(ctx context.Context, gameID int64, assetPath string) (*GameAsset, error) {
+	asset, err := s.open(ctx, gameID, "media", strings.TrimPrefix(assetPath, "/"))
+	if err != nil {
+		return nil, err
+	}
+	asset.CacheControl = assetCacheImmutable
+	return asset, nil
+}
+
+func (s *GameAssetService) open(ctx context.Context, gameID int64, section string, rel string) (*GameAsset, error) {
+	if gameID < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	if s.store == nil {
+		return nil, utils.ErrInternal()
+	}
+
+	key := fmt.Sprintf("%d/%s/%s", gameID, section, rel)
+	body, info, err := s.store.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) || errors.Is(err, storage.ErrInvalidKey) {
+			return nil, utils.ErrNotFound("asset not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+
+	ct := strings.TrimSpace(info.ContentType)
+	if ct == "" || ct == "application/octet-stream" {
+		if byExt := mime.TypeByExtension(path.Ext(rel)); byExt != "" {
+			ct = byExt
+		} else if ct == "" {
+			ct = "application/octet-stream"
+		}
+	}
+
+	etag := strings.Trim(info.ETag, `"`)
+	if etag != "" {
+		etag = `"` + etag + `"`
+	}
+
+	var lastModified string
+	if !info.LastModified.IsZero() {
+		lastModified = info.LastModified.UTC().Format("Mon, 02 Jan 2006 15:04:05 GMT")
+	}
+
+	return &GameAsset{
+		Body:         body,
+		Size:         info.Size,
+		ETag:         etag,
+		ContentType:  ct,
+		LastModified: lastModified,
+	}, nil
+}
+
+// assetCacheControl allows caching forever only when the file name carries
+// the file's own sha256 (at least its first 8 hex digits), so a changed file
+// always gets a new URL. Everything else is revalidated with its ETag.
+func assetCacheControl(rel string, sha256Hex string) string {
+	if strings.EqualFold(path.Ext(rel), ".html") || sha256Hex == "" {
+		return assetCacheRevalidate
+	}
+	sha256Hex = strings.ToLower(sha256Hex)
+	for _, token := range hashedAssetTokenRe.FindAllString(path.Base(rel), -1) {
+		if strings.HasPrefix(sha256Hex, token) {
+			return assetCacheImmutable
+		}
+	}
+	return assetCacheRevalidate
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/clients"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/storage"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+// storageDeleteBatch bounds a single ObjectStore.Delete call.
+const storageDeleteBatch = 1000
+
+// GameDeleteService handles the two-step game removal: a soft delete that
+// archives the game and can be undone within the retention window, then a
+// hard delete that drops the rows, the game's leaderboard keys and every
+// object under "{id}/".
+type GameDeleteService struct {
+	gameRepo     *repos.GameRepo
+	store        storage.ObjectStore
+	valkey       *clients.Valkey
+	retention    time.Duration
+	catalogCache *CatalogCache
+}
+
+func NewGameDeleteService(
+	gameRepo *repos.GameRepo,
+	store storage.ObjectStore,
+	valkey *clients.Valkey,
+	deleteCfg config.GameDeleteConfig,
+	catalogCache *CatalogCache,
+) *GameDeleteService {
+	return &GameDeleteService{
+		gameRepo:     gameRepo,
+		store:        store,
+		valkey:       valkey,
+		retention:    deleteCfg.Retention,
+		catalogCache: catalogCache,
+	}
+}
+
+func (s *GameDeleteService) toDeletionDTO(d repos.GameDeletion) models.GameDeletionDTO {
+	dto := models.GameDeletionDTO{
+		GameID: d.GameID,
+		Title:  d.Title,
+		Slug:   d.Slug,
+		Status: models.GameStatus(d.Status),
+	}
+	if d.DeletedAt.Valid {
+		deletedAt := d.DeletedAt.Time.UTC()
+		purgeAfter := deletedAt.Add(s.retention)
+		dto.DeletedAt = &deletedAt
+		dto.PurgeAfter = &purgeAfter
+	}
+	return dto
+}
+
+// SoftDelete hides the game everywhere by archiving it. Repeating it is a
+// no-op that keeps the original deletion time.
+func (s *GameDeleteService) SoftDelete(ctx context.Context, id int64) (*models.GameDeletionDTO, error) {
+	if id < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+
+	d, err := s.gameRepo.SoftDelete(ctx, id)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	s.catalogCache.InvalidateGame(ctx, id)
+
+	dto := s.toDeletionDTO(*d)
+	return &dto, nil
+}
+
+// Restore undoes a soft delete. The game comes back as a draft and has to be
+// published again.
+func (s *GameDeleteService) Restore(ctx context.Context, id int64) (*models.GameDeletionDTO, error) {
+	if id < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+
+	cur, err := s.gameRepo.GetDeletion(ctx, id)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	if !cur.DeletedAt.Valid {
+		return nil, utils.ErrBadRequest("game is not deleted")
+	}
+
+	d, err := s.gameRepo.Restore(ctx, id)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	s.catalogCache.InvalidateGame(ctx, id)
+
+	dto := s.toDeletionDTO(*d)
+	return &dto, nil
+}
+
+// globalLeaderboardNote goes on every purge report. The global boards rank
+// members without a per-game breakdown, so the purge cannot take this game's
+// scores out of them.
+const globalLeaderboardNote = "global leaderboards (lb:global:*) have no per-game breakdown and keep this game's scores until their day or week rolls over"
+
+// HardDelete permanently removes a soft-deleted game. Storage and leaderboard
+// keys go first so a failure leaves the game soft-deleted and the purge can
+// be retried; the DB rows are removed last.
+func (s *GameDeleteService) HardDelete(ctx context.Context, id int64) (*models.GameDeleteReport, error) {
+	if id < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+
+	cur, err := s.gameRepo.GetDeletion(ctx, id)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	if !cur.DeletedAt.Valid {
+		return nil, utils.ErrBadRequest("game must be soft-deleted before it can be purged")
+	}
+
+	report := &models.GameDeleteReport{GameID: id, Slug: cur.Slug}
+
+	objects, bytes, err := s.deleteObjects(ctx, id)
+	if err != nil {
+		log.Printf("level=error msg=%q game_id=%d err=%v", "game purge storage failed", id, err)
+		return nil, utils.ErrInternal()
+	}
+	report.StorageObjects = objects
+	report.StorageBytes = bytes
+
+	keys, err := s.deleteLeaderboardKeys(ctx, id)
+	if err != nil {
+		log.Printf("level=error msg=%q game_id=%d err=%v", "game purge leaderboard failed", id, err)
+		return nil, utils.ErrInternal()
+	}
+	report.LeaderboardKeys = keys
+	report.Notes = append(report.Notes, globalLeaderboardNote)
+
+	rows, err := s.gameRepo.HardDelete(ctx, id)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		if errors.Is(err, repos.ErrGameNotDeleted) {
+			return nil, utils.ErrBadRequest("game was restored while being purged")
+		}
+		log.Printf("level=error msg=%q game_id=%d err=%v", "game purge rows failed", id, err)
+		return nil, utils.ErrInternal()
+	}
+	report.Rows = rows
+	report.DeletedAt = time.Now().UTC()
+
+	s.catalogCache.InvalidateGame(ctx, id)
+	return report, nil
+}
+
+func (s *GameDeleteService) deleteObjects(ctx context.Context, id int64) (int, int64, error) {
+	if s.store == nil {
+		return 0, 0, errors.New("object store is not configured")
+	}
+
+	objects, err := s.store.List(ctx, fmt.Sprintf("%d/", id), true)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var bytes int64
+	keys := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		keys = append(keys, obj.Key)
+		bytes += obj.Size
+	}
+	for start := 0; start < len(keys); start += storageDeleteBatch {
+		end := min(start+storageDeleteBatch, len(keys))
+		if err := s.store.Delete(ctx, keys[start:end]); err != nil {
+			return 0, 0, err
+		}
+	}
+	return len(keys), bytes, nil
+}
+
+func (s *GameDeleteService) deleteLeaderboardKeys(ctx context.Context, id int64) (int64, error) {
+	if s.valkey == nil {
+		return 0, nil
+	}
+
+	var total int64
+	for _, pattern := range clients.KeyGamePatterns(id) {
+		n, err := s.valkey.ScanDel(ctx, pattern)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// PurgeExpired hard-deletes games whose retention window has passed. A game
+// that fails is logged and retried on the next run.
+func (s *GameDeleteService) PurgeExpired(ctx context.Context) ([]models.GameDeleteReport, error) {
+	ids, err := s.gameRepo.ListPurgeable(ctx, time.Now().UTC().Add(-s.retention), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]models.GameDeleteReport, 0, len(ids))
+	for _, id := range ids {
+		report, err := s.HardDelete(ctx, id)
+		if err != nil {
+			log.Printf("level=warn msg=%q game_id=%d err=%v", "game purge skipped", id, err)
+			continue
+		}
+		reports = append(reports, *report)
+	}
+	return reports, nil
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+// maxFilterValues bounds repeated query params so a URL cannot expand into
+// an arbitrarily large IN list.
+const maxFilterValues = 20
+
+type GameFacetCountDTO struct {
+	ID    int64  `json:"id"`
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+type GameDifficultyFacetDTO struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type GameFreeFacetDTO struct {
+	Value bool `json:"value"`
+	Count int  `json:"count"`
+}
+
+type GameFacetsDTO struct {
+	AgeCategories       []GameFacetCountDTO      `json:"age_categories"`
+	EducationCategories []GameFacetCountDTO      `json:"education_categories"`
+	Difficulties        []GameDifficultyFacetDTO `json:"difficulties"`
+	Free                []GameFreeFacetDTO       `json:"free"`
+}
+
+func sanitizeFilterIDs(name string, ids []int64) ([]int64, error) {
+	if len(ids) > maxFilterValues {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("%s accepts at most %d values", name, maxFilterValues))
+	}
+	seen := make(map[int64]struct{}, len(ids))
+	out := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id < 1 {
+			return nil, utils.ErrBadRequest(name + " must be >= 1")
+		}
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out, nil
+}
+
+func sanitizeDifficultyFilter(values []string) ([]string, error) {
+	if len(values) > maxFilterValues {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("difficulty accepts at most %d values", maxFilterValues))
+	}
+	seen := make(map[string]struct{}, len(values))
+	out := make([]string, 0, len(values))
+	for _, raw := range values {
+		d, err := normalizeDifficulty(raw)
+		if err != nil {
+			return nil, err
+		}
+		if !d.Valid {
+			continue
+		}
+		if _, ok := seen[d.String]; ok {
+			continue
+		}
+		seen[d.String] = struct{}{}
+		out = append(out, d.String)
+	}
+	return out, nil
+}
+
+func toGameFacetsDTO(f *repos.GameFacets) *GameFacetsDTO {
+	if f == nil {
+		return nil
+	}
+	out := &GameFacetsDTO{
+		AgeCategories:       toGameFacetCountDTOs(f.AgeCategories),
+		EducationCategories: toGameFacetCountDTOs(f.EducationCategories),
+		Difficulties:        make([]GameDifficultyFacetDTO, 0, len(f.Difficulties)),
+		Free:                make([]GameFreeFacetDTO, 0, len(f.Free)),
+	}
+	for _, d := range f.Difficulties {
+		out.Difficulties = append(out.Difficulties, GameDifficultyFacetDTO{Value: d.Value, Count: d.Count})
+	}
+	for _, v := range f.Free {
+		out.Free = append(out.Free, GameFreeFacetDTO{Value: v.Value == "true", Count: v.Count})
+	}
+	return out
+}
+
+func toGameFacetCountDTOs(items []repos.GameFacetCount) []GameFacetCountDTO {
+	out := make([]GameFacetCountDTO, 0, len(items))
+	for _, it := range items {
+		out = append(out, GameFacetCountDTO{ID: it.ID, Label: it.Label, Count: it.Count})
+	}
+	return out
+}
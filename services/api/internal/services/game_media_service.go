@@ -0,0 +1,285 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"path"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/storage"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type imageVariantSpec struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// Variants are always PNG: the standard library has no WebP encoder.
+var (
+	thumbnailVariantSpecs = []imageVariantSpec{
+		{Name: "sm", Width: 160, Height: 120},
+		{Name: "md", Width: 320, Height: 240},
+		{Name: "lg", Width: 640, Height: 480},
+	}
+	screenshotVariantSpecs = []imageVariantSpec{
+		{Name: "sm", Width: 320, Height: 180},
+		{Name: "lg", Width: 1280, Height: 720},
+	}
+)
+
+// thumbnailPrimaryVariant is mirrored into games.thumbnail for clients that
+// only read the legacy field.
+const thumbnailPrimaryVariant = "md"
+
+type GameMediaService struct {
+	gameRepo       *repos.GameRepo
+	mediaRepo      *repos.GameMediaRepo
+	store          storage.ObjectStore
+	maxImageBytes  int64
+	maxPixels      int
+	maxScreenshots int
+	catalogCache   *CatalogCache
+}
+
+func NewGameMediaService(gameRepo *repos.GameRepo, mediaRepo *repos.GameMediaRepo, store storage.ObjectStore, mediaCfg config.MediaConfig, catalogCache *CatalogCache) *GameMediaService {
+	return &GameMediaService{
+		gameRepo:       gameRepo,
+		mediaRepo:      mediaRepo,
+		store:          store,
+		maxImageBytes:  mediaCfg.MaxImageBytes,
+		maxPixels:      mediaCfg.MaxPixels,
+		maxScreenshots: mediaCfg.MaxScreenshots,
+		catalogCache:   catalogCache,
+	}
+}
+
+type GameMediaDTO struct {
+	ID        int64             `json:"id"`
+	Kind      string            `json:"kind"`
+	Position  int               `json:"position"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Variants  map[string]string `json:"variants"`
+	CreatedAt string            `json:"created_at"`
+}
+
+func (s *GameMediaService) UploadThumbnail(ctx context.Context, gameID int64, file io.ReadSeeker, size int64) (*GameMediaDTO, error) {
+	in, err := s.processUpload(ctx, gameID, repos.GameMediaKindThumbnail, file, size)
+	if err != nil {
+		return nil, err
+	}
+
+	m, old, err := s.mediaRepo.ReplaceThumbnail(ctx, *in)
+	if err != nil {
+		s.removeMediaObjects(in.GameID, in.VariantsJSON)
+		return nil, utils.ErrInternal()
+	}
+
+	variants := mediaVariantURLs(m.VariantsJSON)
+	if err := s.gameRepo.SetThumbnail(ctx, gameID, nullString(variants[thumbnailPrimaryVariant])); err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	s.catalogCache.InvalidateGame(ctx, gameID)
+
+	if old != nil {
+		s.removeMediaObjects(gameID, old.VariantsJSON)
+	}
+
+	dto := toGameMediaDTO(*m)
+	return &dto, nil
+}
+
+func (s *GameMediaService) UploadScreenshot(ctx context.Context, gameID int64, file io.ReadSeeker, size int64) (*GameMediaDTO, error) {
+	in, err := s.processUpload(ctx, gameID, repos.GameMediaKindScreenshot, file, size)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := s.mediaRepo.AddScreenshot(ctx, *in, s.maxScreenshots)
+	if err != nil {
+		s.removeMediaObjects(in.GameID, in.VariantsJSON)
+		switch {
+		case errors.Is(err, repos.ErrMediaLimitReached):
+			return nil, utils.ErrBadRequest(fmt.Sprintf("a game can have at most %d screenshots", s.maxScreenshots))
+		case errors.Is(err, repos.ErrNotFound):
+			return nil, utils.ErrNotFound("game not found")
+		default:
+			return nil, utils.ErrInternal()
+		}
+	}
+	s.catalogCache.InvalidateGame(ctx, gameID)
+
+	dto := toGameMediaDTO(*m)
+	return &dto, nil
+}
+
+func (s *GameMediaService) DeleteScreenshot(ctx context.Context, gameID int64, mediaID int64) error {
+	if gameID < 1 {
+		return utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	if mediaID < 1 {
+		return utils.ErrBadRequest("media_id must be an integer >= 1")
+	}
+
+	m, err := s.mediaRepo.Delete(ctx, gameID, mediaID, repos.GameMediaKindScreenshot)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return utils.ErrNotFound("screenshot not found")
+		}
+		return utils.ErrInternal()
+	}
+	s.catalogCache.InvalidateGame(ctx, gameID)
+
+	s.removeMediaObjects(gameID, m.VariantsJSON)
+	return nil
+}
+
+// processUpload validates and decodes the image, then stores every variant
+// under a fresh prefix so cached URLs of older uploads never change content.
+func (s *GameMediaService) processUpload(ctx context.Context, gameID int64, kind string, file io.ReadSeeker, size int64) (*repos.CreateGameMediaInput, error) {
+	if gameID < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	if s.store == nil {
+		return nil, utils.ErrInternal()
+	}
+	if size <= 0 {
+		return nil, utils.ErrBadRequest("file is required")
+	}
+	if s.maxImageBytes > 0 && size > s.maxImageBytes {
+		return nil, utils.ErrImageTooLarge(s.maxImageBytes)
+	}
+
+	if _, err := s.gameRepo.GetByID(ctx, gameID); err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+
+	img, err := utils.SafeDecodeImage(file, s.maxPixels)
+	if err != nil {
+		var inputErr utils.ImageInputError
+		if errors.As(err, &inputErr) {
+			return nil, utils.ErrInvalidImage(inputErr.Error())
+		}
+		return nil, utils.ErrInternal()
+	}
+
+	specs := thumbnailVariantSpecs
+	dir := "thumbnail"
+	if kind == repos.GameMediaKindScreenshot {
+		specs = screenshotVariantSpecs
+		dir = "screenshots"
+	}
+
+	token, err := randHex(8)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	prefix := fmt.Sprintf("%d/media/%s/%s", gameID, dir, token)
+
+	variants := make(map[string]string, len(specs))
+	for _, spec := range specs {
+		data, err := utils.EncodePNG(utils.ResizeCover(img, spec.Width, spec.Height))
+		if err != nil {
+			s.removeMediaKeys(gameID, variants)
+			return nil, utils.ErrInternal()
+		}
+
+		key := path.Join(prefix, spec.Name+".png")
+		if _, err := s.store.Put(ctx, key, bytes.NewReader(data), int64(len(data)), "image/png"); err != nil {
+			s.removeMediaKeys(gameID, variants)
+			return nil, utils.ErrInternal()
+		}
+		variants[spec.Name] = key
+	}
+
+	variantsJSON, err := json.Marshal(variants)
+	if err != nil {
+		s.removeMediaKeys(gameID, variants)
+		return nil, utils.ErrInternal()
+	}
+
+	b := img.Bounds()
+	return &repos.CreateGameMediaInput{
+		GameID:       gameID,
+		ObjectPrefix: prefix,
+		Width:        b.Dx(),
+		Height:       b.Dy(),
+		VariantsJSON: variantsJSON,
+	}, nil
+}
+
+func (s *GameMediaService) removeMediaObjects(gameID int64, variantsJSON []byte) {
+	var keys map[string]string
+	if err := json.Unmarshal(variantsJSON, &keys); err != nil {
+		return
+	}
+	s.removeMediaKeys(gameID, keys)
+}
+
+// removeMediaKeys is best-effort; leftovers only cost storage.
+func (s *GameMediaService) removeMediaKeys(gameID int64, keys map[string]string) {
+	if len(keys) == 0 {
+		return
+	}
+	list := make([]string, 0, len(keys))
+	for _, k := range keys {
+		list = append(list, k)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := s.store.Delete(ctx, list); err != nil {
+		log.Printf("level=warn msg=%q game_id=%d err=%v", "media cleanup failed", gameID, err)
+	}
+}
+
+// mediaVariantURLs maps stored object keys to the public /games/ URLs.
+func mediaVariantURLs(variantsJSON []byte) map[string]string {
+	if len(variantsJSON) == 0 {
+		return nil
+	}
+	var keys map[string]string
+	if err := json.Unmarshal(variantsJSON, &keys); err != nil || len(keys) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(keys))
+	for name, key := range keys {
+		out[name] = "/games/" + key
+	}
+	return out
+}
+
+func toGameMediaDTO(m repos.GameMedia) GameMediaDTO {
+	return GameMediaDTO{
+		ID:        m.ID,
+		Kind:      m.Kind,
+		Position:  m.Position,
+		Width:     m.Width,
+		Height:    m.Height,
+		Variants:  mediaVariantURLs(m.VariantsJSON),
+		CreatedAt: m.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func toGameMediaDTOs(items []repos.GameMedia) []GameMediaDTO {
+	out := make([]GameMediaDTO, 0, len(items))
+	for _, m := range items {
+		out = append(out, toGameMediaDTO(m))
+	}
+	return out
+}
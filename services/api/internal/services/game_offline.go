@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+const (
+	OfflineManifestFormatDefault = "default"
+	OfflineManifestFormatWorkbox = "workbox"
+)
+
+type GameOfflineDTO struct {
+	CacheVersion int    `json:"cache_version"`
+	CacheName    string `json:"cache_name"`
+	FileCount    int    `json:"file_count"`
+	TotalBytes   int64  `json:"total_bytes"`
+	ManifestURL  string `json:"manifest_url"`
+}
+
+type GameOfflineFileDTO struct {
+	URL         string  `json:"url"`
+	Path        string  `json:"path"`
+	Size        int64   `json:"size"`
+	SHA256      *string `json:"sha256"`
+	ContentType *string `json:"content_type"`
+}
+
+type GameOfflineManifestDTO struct {
+	GameID       int64                `json:"game_id"`
+	BuildID      int64                `json:"build_id"`
+	CacheVersion int                  `json:"cache_version"`
+	CacheName    string               `json:"cache_name"`
+	StartURL     string               `json:"start_url"`
+	FileCount    int                  `json:"file_count"`
+	TotalBytes   int64                `json:"total_bytes"`
+	Files        []GameOfflineFileDTO `json:"files"`
+}
+
+// PrecacheEntryDTO matches the Workbox precache manifest entry shape.
+type PrecacheEntryDTO struct {
+	URL      string `json:"url"`
+	Revision string `json:"revision"`
+}
+
+func offlineCacheName(gameID int64, version int) string {
+	return fmt.Sprintf("kids-planet-game-%d-v%d", gameID, version)
+}
+
+func offlineManifestURL(gameID int64) string {
+	return fmt.Sprintf("/api/games/%d/offline-manifest", gameID)
+}
+
+// currentOfflineSummary returns nil for games that have no recorded build yet.
+func (s *GameService) currentOfflineSummary(ctx context.Context, gameID int64) (*GameOfflineDTO, error) {
+	build, err := s.buildRepo.GetCurrent(ctx, gameID)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &GameOfflineDTO{
+		CacheVersion: build.CacheVersion,
+		CacheName:    offlineCacheName(gameID, build.CacheVersion),
+		FileCount:    build.FileCount,
+		TotalBytes:   build.TotalBytes,
+		ManifestURL:  offlineManifestURL(gameID),
+	}, nil
+}
+
+func (s *GameService) GetOfflineManifest(ctx context.Context, gameID int64) (*GameOfflineManifestDTO, error) {
+	if gameID < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+
+	if _, err := s.gameRepo.GetByIDPublic(ctx, gameID); err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+
+	build, err := s.buildRepo.GetCurrent(ctx, gameID)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("offline bundle not available")
+		}
+		return nil, utils.ErrInternal()
+	}
+
+	files, err := s.buildRepo.ListFiles(ctx, build.ID)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+
+	basePath := fmt.Sprintf("/games/%s/", gameBuildPrefix(gameID, build.ID))
+	out := make([]GameOfflineFileDTO, 0, len(files))
+	for _, f := range files {
+		out = append(out, GameOfflineFileDTO{
+			URL:         basePath + f.Path,
+			Path:        f.Path,
+			Size:        f.SizeBytes,
+			SHA256:      toNullableString(f.SHA256),
+			ContentType: toNullableString(f.ContentType),
+		})
+	}
+
+	return &GameOfflineManifestDTO{
+		GameID:       gameID,
+		BuildID:      build.ID,
+		CacheVersion: build.CacheVersion,
+		CacheName:    offlineCacheName(gameID, build.CacheVersion),
+		StartURL:     basePath + "index.html",
+		FileCount:    len(out),
+		TotalBytes:   build.TotalBytes,
+		Files:        out,
+	}, nil
+}
+
+// ToPrecacheEntries falls back to the cache version as revision for files
+// recorded before content hashes were stored.
+func (m *GameOfflineManifestDTO) ToPrecacheEntries() []PrecacheEntryDTO {
+	out := make([]PrecacheEntryDTO, 0, len(m.Files))
+	for _, f := range m.Files {
+		revision := fmt.Sprintf("v%d", m.CacheVersion)
+		if f.SHA256 != nil && strings.TrimSpace(*f.SHA256) != "" {
+			revision = *f.SHA256
+		}
+		out = append(out, PrecacheEntryDTO{URL: f.URL, Revision: revision})
+	}
+	return out
+}
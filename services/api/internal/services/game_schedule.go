@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+// GameScheduleService owns publish_at/unpublish_at: admin edits and the
+// scheduler tick that applies them.
+type GameScheduleService struct {
+	gameRepo     *repos.GameRepo
+	catalogCache *CatalogCache
+}
+
+func NewGameScheduleService(gameRepo *repos.GameRepo, catalogCache *CatalogCache) *GameScheduleService {
+	return &GameScheduleService{gameRepo: gameRepo, catalogCache: catalogCache}
+}
+
+type GameScheduleListDTO struct {
+	Items []models.GameScheduleDTO `json:"items"`
+}
+
+func toGameScheduleDTO(s repos.GameSchedule) models.GameScheduleDTO {
+	dto := models.GameScheduleDTO{
+		GameID:    s.GameID,
+		Title:     s.Title,
+		Slug:      s.Slug,
+		Status:    models.GameStatus(s.Status),
+		UpdatedAt: s.UpdatedAt,
+	}
+	if s.PublishAt.Valid {
+		t := s.PublishAt.Time.UTC()
+		dto.PublishAt = &t
+	}
+	if s.UnpublishAt.Valid {
+		t := s.UnpublishAt.Time.UTC()
+		dto.UnpublishAt = &t
+	}
+	return dto
+}
+
+func (s *GameScheduleService) ListSchedules(ctx context.Context) (*GameScheduleListDTO, error) {
+	items, err := s.gameRepo.ListSchedules(ctx, 0)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	out := make([]models.GameScheduleDTO, 0, len(items))
+	for _, it := range items {
+		out = append(out, toGameScheduleDTO(it))
+	}
+	return &GameScheduleListDTO{Items: out}, nil
+}
+
+func (s *GameScheduleService) GetSchedule(ctx context.Context, id int64) (*models.GameScheduleDTO, error) {
+	if id < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	sched, err := s.gameRepo.GetSchedule(ctx, id)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	dto := toGameScheduleDTO(*sched)
+	return &dto, nil
+}
+
+// SetSchedule replaces both times; times are stored in UTC.
+func (s *GameScheduleService) SetSchedule(ctx context.Context, id int64, req models.SetGameScheduleRequest) (*models.GameScheduleDTO, error) {
+	if id < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+
+	g, err := s.gameRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	if g.Status == string(models.GameStatusArchived) && (req.PublishAt != nil || req.UnpublishAt != nil) {
+		return nil, utils.ErrBadRequest("archived game cannot be scheduled")
+	}
+
+	now := time.Now()
+	var publishAt, unpublishAt sql.NullTime
+	if req.PublishAt != nil {
+		if !req.PublishAt.After(now) {
+			return nil, utils.ErrBadRequest("publish_at must be in the future")
+		}
+		publishAt = sql.NullTime{Time: req.PublishAt.UTC(), Valid: true}
+	}
+	if req.UnpublishAt != nil {
+		if !req.UnpublishAt.After(now) {
+			return nil, utils.ErrBadRequest("unpublish_at must be in the future")
+		}
+		unpublishAt = sql.NullTime{Time: req.UnpublishAt.UTC(), Valid: true}
+	}
+	if publishAt.Valid && unpublishAt.Valid && !unpublishAt.Time.After(publishAt.Time) {
+		return nil, utils.ErrBadRequest("unpublish_at must be after publish_at")
+	}
+
+	sched, err := s.gameRepo.SetSchedule(ctx, id, publishAt, unpublishAt)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	dto := toGameScheduleDTO(*sched)
+	return &dto, nil
+}
+
+func (s *GameScheduleService) CancelSchedule(ctx context.Context, id int64) (*models.GameScheduleDTO, error) {
+	return s.SetSchedule(ctx, id, models.SetGameScheduleRequest{})
+}
+
+// ApplyDue is the scheduler tick; it is safe to run on every replica.
+func (s *GameScheduleService) ApplyDue(ctx context.Context) (*repos.AppliedSchedules, error) {
+	applied, err := s.gameRepo.ApplyDueSchedules(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range applied.Published {
+		s.catalogCache.InvalidateGame(ctx, id)
+	}
+	for _, id := range applied.Unpublished {
+		s.catalogCache.InvalidateGame(ctx, id)
+	}
+	return applied, nil
+}
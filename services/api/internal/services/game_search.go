@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+const (
+	maxSearchQueryLen   = 100
+	defaultSuggestLimit = 8
+	maxSuggestLimit     = 20
+)
+
+type GameSuggestionDTO struct {
+	ID                int64             `json:"id"`
+	Title             string            `json:"title"`
+	Slug              string            `json:"slug"`
+	Thumbnail         *string           `json:"thumbnail"`
+	ThumbnailVariants map[string]string `json:"thumbnail_variants,omitempty"`
+}
+
+type GameSuggestListDTO struct {
+	Items []GameSuggestionDTO `json:"items"`
+}
+
+func (s *GameService) SuggestGames(ctx context.Context, q string, limit int, locale string) (*GameSuggestListDTO, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return nil, utils.ErrBadRequest("q is required")
+	}
+	if utf8.RuneCountInString(q) > maxSearchQueryLen {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("q must be <= %d chars", maxSearchQueryLen))
+	}
+	if limit == 0 {
+		limit = defaultSuggestLimit
+	}
+	if limit < 1 || limit > maxSuggestLimit {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("limit must be between 1 and %d", maxSuggestLimit))
+	}
+
+	// The base title is in the default locale; others match translations.
+	locale = s.localizer.Resolve(locale)
+	if !s.localizer.translates(locale) {
+		locale = ""
+	}
+
+	items, err := s.gameRepo.Suggest(ctx, q, locale, limit)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+
+	out := make([]GameSuggestionDTO, 0, len(items))
+	for _, it := range items {
+		out = append(out, GameSuggestionDTO{
+			ID:                it.ID,
+			Title:             it.Title,
+			Slug:              it.Slug,
+			Thumbnail:         toNullableString(it.Thumbnail),
+			ThumbnailVariants: mediaVariantURLs(it.ThumbnailVariantsJSON),
+		})
+	}
+	return &GameSuggestListDTO{Items: out}, nil
+}
@@ -4,12 +4,14 @@ import (
 	"archive/zip"
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"mime"
 	"net/http"
 	"os"
@@ -17,43 +19,68 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
-	"github.com/ZygmaCore/kids_planet/services/api/internal/clients"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/storage"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
 )
 
 type GameService struct {
-	gameRepo    *repos.GameRepo
-	minio       *clients.MinIO
-	minioBucket string
-	zipMaxBytes int64
+	gameRepo           *repos.GameRepo
+	buildRepo          *repos.GameBuildRepo
+	mediaRepo          *repos.GameMediaRepo
+	store              storage.ObjectStore
+	zipMaxBytes        int64
+	uploadConcurrency  int
+	uploadMaxRetries   int
+	uploadRetryBackoff time.Duration
+	catalogCache       *CatalogCache
+	localizer          *Localizer
 }
 
-func NewGameService(gameRepo *repos.GameRepo, minio *clients.MinIO, minioBucket string, zipMaxBytes int64) *GameService {
+func NewGameService(gameRepo *repos.GameRepo, buildRepo *repos.GameBuildRepo, mediaRepo *repos.GameMediaRepo, store storage.ObjectStore, uploadCfg config.UploadConfig, catalogCache *CatalogCache, localizer *Localizer) *GameService {
 	return &GameService{
-		gameRepo:    gameRepo,
-		minio:       minio,
-		minioBucket: strings.TrimSpace(minioBucket),
-		zipMaxBytes: zipMaxBytes,
+		gameRepo:           gameRepo,
+		buildRepo:          buildRepo,
+		mediaRepo:          mediaRepo,
+		store:              store,
+		zipMaxBytes:        uploadCfg.ZipMaxBytes,
+		uploadConcurrency:  uploadCfg.Concurrency,
+		uploadMaxRetries:   uploadCfg.MaxRetries,
+		uploadRetryBackoff: uploadCfg.RetryBackoff,
+		catalogCache:       catalogCache,
+		localizer:          localizer,
 	}
 }
 
+// ListPublicGamesInput filter slices are ORed within a field and ANDed
+// across fields.
 type ListPublicGamesInput struct {
-	AgeCategoryID       *int64
-	EducationCategoryID *int64
-	Sort                string
-	Page                int
-	Limit               int
+	AgeCategoryIDs       []int64
+	EducationCategoryIDs []int64
+	Difficulties         []string
+	Free                 *bool
+	Age                  *int
+	Q                    string
+	Sort                 string
+	Page                 int
+	Limit                int
+	Cursor               string
+	Locale               string
 }
 
 type GameListDTO struct {
-	Items []GameListItemDTO `json:"items"`
-	Page  int               `json:"page"`
-	Limit int               `json:"limit"`
-	Total int               `json:"total"`
+	Items      []GameListItemDTO `json:"items"`
+	Page       int               `json:"page"`
+	Limit      int               `json:"limit"`
+	Total      int               `json:"total"`
+	NextCursor *string           `json:"next_cursor"`
+	Facets     *GameFacetsDTO    `json:"facets,omitempty"`
 }
 
 type PublicEducationCategoryDTO struct {
@@ -65,7 +92,10 @@ type GameListItemDTO struct {
 	ID                   int64                        `json:"id"`
 	Title                string                       `json:"title"`
 	Slug                 string                       `json:"slug"`
+	Description          *string                      `json:"description"`
+	Difficulty           *string                      `json:"difficulty"`
 	Thumbnail            *string                      `json:"thumbnail"`
+	ThumbnailVariants    map[string]string            `json:"thumbnail_variants,omitempty"`
 	GameURL              *string                      `json:"game_url"`
 	AgeCategoryID        int64                        `json:"age_category_id"`
 	AgeLabel             *string                      `json:"age_label,omitempty"`
@@ -74,15 +104,20 @@ type GameListItemDTO struct {
 	EducationCategoryIDs []int64                      `json:"education_category_ids,omitempty"`
 	EducationCategories  []PublicEducationCategoryDTO `json:"education_categories,omitempty"`
 	PlayCount            int64                        `json:"play_count"`
+	Reactions            GameReactionCountsDTO        `json:"reactions"`
 	Free                 bool                         `json:"free"`
 	CreatedAt            string                       `json:"created_at"`
+	Locale               string                       `json:"locale"`
 }
 
 type GameDetailDTO struct {
 	ID                   int64                        `json:"id"`
 	Title                string                       `json:"title"`
 	Slug                 string                       `json:"slug"`
+	Description          *string                      `json:"description"`
+	Difficulty           *string                      `json:"difficulty"`
 	Thumbnail            *string                      `json:"thumbnail"`
+	ThumbnailVariants    map[string]string            `json:"thumbnail_variants,omitempty"`
 	GameURL              *string                      `json:"game_url"`
 	AgeCategoryID        int64                        `json:"age_category_id"`
 	AgeLabel             *string                      `json:"age_label,omitempty"`
@@ -91,8 +126,12 @@ type GameDetailDTO struct {
 	EducationCategoryIDs []int64                      `json:"education_category_ids,omitempty"`
 	EducationCategories  []PublicEducationCategoryDTO `json:"education_categories,omitempty"`
 	PlayCount            int64                        `json:"play_count"`
+	Reactions            GameReactionCountsDTO        `json:"reactions"`
 	Free                 bool                         `json:"free"`
 	CreatedAt            string                       `json:"created_at"`
+	Screenshots          []GameMediaDTO               `json:"screenshots"`
+	Offline              *GameOfflineDTO              `json:"offline"`
+	Locale               string                       `json:"locale"`
 }
 
 type publicEducationCategoryRow struct {
@@ -198,6 +237,29 @@ func toAdminEducationCategories(raw []byte) []models.AdminEducationCategoryDTO {
 	return out
 }
 
+func toGameListItemDTO(it repos.GameListItem) GameListItemDTO {
+	return GameListItemDTO{
+		ID:                   it.ID,
+		Title:                it.Title,
+		Slug:                 it.Slug,
+		Description:          toNullableString(it.Description),
+		Difficulty:           toNullableString(it.Difficulty),
+		Thumbnail:            toNullableString(it.Thumbnail),
+		ThumbnailVariants:    mediaVariantURLs(it.ThumbnailVariantsJSON),
+		GameURL:              toNullableString(it.GameURL),
+		AgeCategoryID:        it.AgeCategoryID,
+		AgeLabel:             toNullableString(it.AgeLabel),
+		MinAge:               toNullableInt(it.MinAge),
+		MaxAge:               toNullableInt(it.MaxAge),
+		EducationCategoryIDs: parseEducationCategoryIDs(it.EducationCategoryIDsJSON),
+		EducationCategories:  parseEducationCategories(it.EducationCategoriesJSON),
+		PlayCount:            it.PlayCount,
+		Reactions:            toGameReactionCountsDTO(it.LoveCount, it.LikeCount, it.MehCount, it.Rating),
+		Free:                 it.Free,
+		CreatedAt:            it.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
+	}
+}
+
 func (s *GameService) ListPublicGames(ctx context.Context, in ListPublicGamesInput) (*GameListDTO, error) {
 	page := in.Page
 	limit := in.Limit
@@ -215,9 +277,18 @@ func (s *GameService) ListPublicGames(ctx context.Context, in ListPublicGamesInp
 		return nil, utils.ErrBadRequest("limit must be between 1 and 100")
 	}
 
+	q := strings.TrimSpace(in.Q)
+	if utf8.RuneCountInString(q) > maxSearchQueryLen {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("q must be <= %d chars", maxSearchQueryLen))
+	}
+
+	// Searches default to relevance order; an explicit sort still applies.
 	sort := strings.TrimSpace(strings.ToLower(in.Sort))
 	if sort == "" {
 		sort = "newest"
+		if q != "" {
+			sort = "relevance"
+		}
 	}
 	var sortEnum repos.GameListSort
 	switch sort {
@@ -225,32 +296,88 @@ func (s *GameService) ListPublicGames(ctx context.Context, in ListPublicGamesInp
 		sortEnum = repos.GameSortNewest
 	case "popular":
 		sortEnum = repos.GameSortPopular
+	case "top_rated":
+		sortEnum = repos.GameSortTopRated
+	case "relevance":
+		if q == "" {
+			return nil, utils.ErrBadRequest("sort=relevance requires q")
+		}
+		sortEnum = repos.GameSortRelevance
 	default:
-		return nil, utils.ErrBadRequest("sort must be one of: newest, popular")
+		return nil, utils.ErrBadRequest("sort must be one of: newest, popular, top_rated, relevance")
+	}
+
+	ageIDs, err := sanitizeFilterIDs("age_category_id", in.AgeCategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	eduIDs, err := sanitizeFilterIDs("education_category_id", in.EducationCategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+	difficulties, err := sanitizeDifficultyFilter(in.Difficulties)
+	if err != nil {
+		return nil, err
+	}
+
+	var free sql.NullBool
+	if in.Free != nil {
+		free = sql.NullBool{Bool: *in.Free, Valid: true}
 	}
 
 	var age sql.NullInt64
-	if in.AgeCategoryID != nil {
-		if *in.AgeCategoryID < 1 {
-			return nil, utils.ErrBadRequest("age_category_id must be >= 1")
+	if in.Age != nil {
+		if *in.Age < 0 || *in.Age > 99 {
+			return nil, utils.ErrBadRequest("age must be between 0 and 99")
 		}
-		age = sql.NullInt64{Int64: *in.AgeCategoryID, Valid: true}
+		age = sql.NullInt64{Int64: int64(*in.Age), Valid: true}
 	}
 
-	var edu sql.NullInt64
-	if in.EducationCategoryID != nil {
-		if *in.EducationCategoryID < 1 {
-			return nil, utils.ErrBadRequest("education_category_id must be >= 1")
+	locale := s.localizer.Resolve(in.Locale)
+
+	cursor := strings.TrimSpace(in.Cursor)
+	after, err := decodeGameListCursor(cursor, sortEnum)
+	if err != nil {
+		return nil, err
+	}
+
+	keyParts := gameListCacheKey{
+		AgeCategoryIDs:       ageIDs,
+		EducationCategoryIDs: eduIDs,
+		Difficulties:         difficulties,
+		Free:                 in.Free,
+		Query:                q,
+		Sort:                 sort,
+		Page:                 page,
+		Limit:                limit,
+		Cursor:               cursor,
+		Locale:               locale,
+	}
+	if age.Valid {
+		keyParts.Age = &age.Int64
+	}
+	cacheKey, cacheable := s.catalogCache.gameListKey(ctx, keyParts)
+	if cacheable {
+		var cached GameListDTO
+		if s.catalogCache.get(ctx, cacheKey, &cached) {
+			return &cached, nil
 		}
-		edu = sql.NullInt64{Int64: *in.EducationCategoryID, Valid: true}
 	}
 
 	filter := repos.GameListFilter{
-		AgeCategoryID:       age,
-		EducationCategoryID: edu,
-		Sort:                sortEnum,
-		Page:                page,
-		Limit:               limit,
+		AgeCategoryIDs:       ageIDs,
+		EducationCategoryIDs: eduIDs,
+		Difficulties:         difficulties,
+		Free:                 free,
+		Age:                  age,
+		Query:                q,
+		Sort:                 sortEnum,
+		Page:                 page,
+		Limit:                limit,
+		After:                after,
+	}
+	if s.localizer.translates(locale) {
+		filter.SearchLocale = locale
 	}
 
 	items, err := s.gameRepo.ListPublic(ctx, filter)
@@ -263,46 +390,56 @@ func (s *GameService) ListPublicGames(ctx context.Context, in ListPublicGamesInp
 		return nil, utils.ErrInternal()
 	}
 
+	facets, err := s.gameRepo.FacetsPublic(ctx, filter)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+
 	out := make([]GameListItemDTO, 0, len(items))
 	for _, it := range items {
-		thumb := toNullableString(it.Thumbnail)
-		url := toNullableString(it.GameURL)
-		ageLabel := toNullableString(it.AgeLabel)
-		minAge := toNullableInt(it.MinAge)
-		maxAge := toNullableInt(it.MaxAge)
-		educationCategoryIDs := parseEducationCategoryIDs(it.EducationCategoryIDsJSON)
-		educationCategories := parseEducationCategories(it.EducationCategoriesJSON)
-
-		out = append(out, GameListItemDTO{
-			ID:                   it.ID,
-			Title:                it.Title,
-			Slug:                 it.Slug,
-			Thumbnail:            thumb,
-			GameURL:              url,
-			AgeCategoryID:        it.AgeCategoryID,
-			AgeLabel:             ageLabel,
-			MinAge:               minAge,
-			MaxAge:               maxAge,
-			EducationCategoryIDs: educationCategoryIDs,
-			EducationCategories:  educationCategories,
-			PlayCount:            it.PlayCount,
-			Free:                 it.Free,
-			CreatedAt:            it.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
-		})
+		out = append(out, toGameListItemDTO(it))
 	}
 
-	return &GameListDTO{
-		Items: out,
-		Page:  page,
-		Limit: limit,
-		Total: total,
-	}, nil
+	if err := s.localizer.LocalizeGameList(ctx, locale, out); err != nil {
+		return nil, err
+	}
+	facetsDTO := toGameFacetsDTO(facets)
+	if err := s.localizer.LocalizeFacets(ctx, locale, facetsDTO); err != nil {
+		return nil, err
+	}
+
+	var next *string
+	if sortEnum != repos.GameSortRelevance && hasNextPage(after != nil, page, limit, len(items), total) {
+		next = encodeGameListCursor(sortEnum, items[len(items)-1])
+	}
+
+	dto := &GameListDTO{
+		Items:      out,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		NextCursor: next,
+		Facets:     facetsDTO,
+	}
+	if cacheable {
+		s.catalogCache.set(ctx, cacheKey, dto)
+	}
+	return dto, nil
 }
 
-func (s *GameService) GetPublicGameByID(ctx context.Context, id int64) (*GameDetailDTO, error) {
+func (s *GameService) GetPublicGameByID(ctx context.Context, id int64, locale string) (*GameDetailDTO, error) {
 	if id < 1 {
 		return nil, utils.ErrBadRequest("id must be an integer >= 1")
 	}
+	locale = s.localizer.Resolve(locale)
+
+	cacheKey, cacheable := s.catalogCache.gameDetailKey(ctx, id, locale)
+	if cacheable {
+		var cached GameDetailDTO
+		if s.catalogCache.get(ctx, cacheKey, &cached) {
+			return &cached, nil
+		}
+	}
 
 	it, err := s.gameRepo.GetByIDPublic(ctx, id)
 	if err != nil {
@@ -312,6 +449,52 @@ func (s *GameService) GetPublicGameByID(ctx context.Context, id int64) (*GameDet
 		return nil, utils.ErrInternal()
 	}
 
+	dto, err := s.toGameDetailDTO(ctx, it, locale)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable {
+		s.catalogCache.set(ctx, cacheKey, dto)
+	}
+	return dto, nil
+}
+
+// GameBySlugResult carries either the game or, for a retired slug, the slug
+// the caller should redirect to.
+type GameBySlugResult struct {
+	Game         *GameDetailDTO
+	RedirectSlug string
+}
+
+func (s *GameService) GetPublicGameBySlug(ctx context.Context, slug, locale string) (*GameBySlugResult, error) {
+	slug = strings.TrimSpace(strings.ToLower(slug))
+	if slug == "" || len(slug) > 150 || !slugRe.MatchString(slug) {
+		return nil, utils.ErrNotFound("game not found")
+	}
+
+	it, err := s.gameRepo.GetBySlugPublic(ctx, slug)
+	if err == nil {
+		dto, err := s.toGameDetailDTO(ctx, it, s.localizer.Resolve(locale))
+		if err != nil {
+			return nil, err
+		}
+		return &GameBySlugResult{Game: dto}, nil
+	}
+	if !errors.Is(err, repos.ErrNotFound) {
+		return nil, utils.ErrInternal()
+	}
+
+	current, err := s.gameRepo.ResolveSlugRedirect(ctx, slug)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	return &GameBySlugResult{RedirectSlug: current}, nil
+}
+
+func (s *GameService) toGameDetailDTO(ctx context.Context, it *repos.GameListItem, locale string) (*GameDetailDTO, error) {
 	thumb := toNullableString(it.Thumbnail)
 	url := toNullableString(it.GameURL)
 	ageLabel := toNullableString(it.AgeLabel)
@@ -320,11 +503,24 @@ func (s *GameService) GetPublicGameByID(ctx context.Context, id int64) (*GameDet
 	educationCategoryIDs := parseEducationCategoryIDs(it.EducationCategoryIDsJSON)
 	educationCategories := parseEducationCategories(it.EducationCategoriesJSON)
 
-	return &GameDetailDTO{
+	screenshots, err := s.mediaRepo.ListByGame(ctx, it.ID, repos.GameMediaKindScreenshot)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+
+	offline, err := s.currentOfflineSummary(ctx, it.ID)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+
+	dto := &GameDetailDTO{
 		ID:                   it.ID,
 		Title:                it.Title,
 		Slug:                 it.Slug,
+		Description:          toNullableString(it.Description),
+		Difficulty:           toNullableString(it.Difficulty),
 		Thumbnail:            thumb,
+		ThumbnailVariants:    mediaVariantURLs(it.ThumbnailVariantsJSON),
 		GameURL:              url,
 		AgeCategoryID:        it.AgeCategoryID,
 		AgeLabel:             ageLabel,
@@ -333,25 +529,68 @@ func (s *GameService) GetPublicGameByID(ctx context.Context, id int64) (*GameDet
 		EducationCategoryIDs: educationCategoryIDs,
 		EducationCategories:  educationCategories,
 		PlayCount:            it.PlayCount,
+		Reactions:            toGameReactionCountsDTO(it.LoveCount, it.LikeCount, it.MehCount, it.Rating),
 		Free:                 it.Free,
 		CreatedAt:            it.CreatedAt.UTC().Format("2006-01-02T15:04:05Z"),
-	}, nil
+		Screenshots:          toGameMediaDTOs(screenshots),
+		Offline:              offline,
+	}
+	if err := s.localizer.LocalizeGameDetail(ctx, locale, dto); err != nil {
+		return nil, err
+	}
+	return dto, nil
 }
 
 var slugRe = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
 
+const maxGameDescriptionLen = 5000
+
+func isValidDifficulty(d string) bool {
+	switch models.GameDifficulty(d) {
+	case models.GameDifficultyEasy, models.GameDifficultyMedium, models.GameDifficultyHard:
+		return true
+	default:
+		return false
+	}
+}
+
+// normalizeDifficulty treats an empty value as "not set".
+func normalizeDifficulty(raw string) (sql.NullString, error) {
+	d := strings.TrimSpace(strings.ToLower(raw))
+	if d == "" {
+		return sql.NullString{}, nil
+	}
+	if !isValidDifficulty(d) {
+		return sql.NullString{}, utils.ErrBadRequest("difficulty must be one of: easy, medium, hard")
+	}
+	return sql.NullString{String: d, Valid: true}, nil
+}
+
+func normalizeDescription(raw string) (sql.NullString, error) {
+	d := strings.TrimSpace(raw)
+	if utf8.RuneCountInString(d) > maxGameDescriptionLen {
+		return sql.NullString{}, utils.ErrBadRequest(fmt.Sprintf("description must be <= %d chars", maxGameDescriptionLen))
+	}
+	if d == "" {
+		return sql.NullString{}, nil
+	}
+	return sql.NullString{String: d, Valid: true}, nil
+}
+
 type AdminListGamesInput struct {
 	Status string
 	Q      string
 	Page   int
 	Limit  int
+	Cursor string
 }
 
 type AdminGameListDTO struct {
-	Items []models.AdminGameDTO `json:"items"`
-	Page  int                   `json:"page"`
-	Limit int                   `json:"limit"`
-	Total int                   `json:"total"`
+	Items      []models.AdminGameDTO `json:"items"`
+	Page       int                   `json:"page"`
+	Limit      int                   `json:"limit"`
+	Total      int                   `json:"total"`
+	NextCursor *string               `json:"next_cursor"`
 }
 
 func (s *GameService) ListAdminGames(ctx context.Context, in AdminListGamesInput) (*AdminGameListDTO, error) {
@@ -385,11 +624,17 @@ func (s *GameService) ListAdminGames(ctx context.Context, in AdminListGamesInput
 		qNS = sql.NullString{String: q, Valid: true}
 	}
 
+	after, err := decodeAdminGameCursor(strings.TrimSpace(in.Cursor))
+	if err != nil {
+		return nil, err
+	}
+
 	filter := repos.AdminGameFilter{
 		Status: statusNS,
 		Q:      qNS,
 		Page:   page,
 		Limit:  limit,
+		After:  after,
 	}
 
 	items, err := s.gameRepo.ListAdmin(ctx, filter)
@@ -407,11 +652,18 @@ func (s *GameService) ListAdminGames(ctx context.Context, in AdminListGamesInput
 		out = append(out, toAdminGameDTO(g))
 	}
 
+	var next *string
+	if hasNextPage(after != nil, page, limit, len(items), total) {
+		last := items[len(items)-1]
+		next = encodeCursorPtr(adminGameCursor{UpdatedAt: last.UpdatedAt, ID: last.ID})
+	}
+
 	return &AdminGameListDTO{
-		Items: out,
-		Page:  page,
-		Limit: limit,
-		Total: total,
+		Items:      out,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		NextCursor: next,
 	}, nil
 }
 
@@ -434,6 +686,14 @@ func (s *GameService) CreateAdminGame(ctx context.Context, createdBy int64, req
 	if !slugRe.MatchString(slug) {
 		return nil, utils.ErrBadRequest("slug must be lowercase and dash-separated (e.g. color-match)")
 	}
+	description, err := normalizeDescription(req.Description)
+	if err != nil {
+		return nil, err
+	}
+	difficulty, err := normalizeDifficulty(req.Difficulty)
+	if err != nil {
+		return nil, err
+	}
 	if req.AgeCategoryID < 1 {
 		return nil, utils.ErrBadRequest("age_category_id must be >= 1")
 	}
@@ -489,6 +749,8 @@ func (s *GameService) CreateAdminGame(ctx context.Context, createdBy int64, req
 	g, err := s.gameRepo.CreateAdminGame(ctx, repos.CreateAdminGameInput{
 		Title:         title,
 		Slug:          slug,
+		Description:   description,
+		Difficulty:    difficulty,
 		Thumbnail:     thumb,
 		GameURL:       gameURL,
 		AgeCategoryID: req.AgeCategoryID,
@@ -558,6 +820,23 @@ func (s *GameService) UpdateAdminGame(ctx context.Context, id int64, req models.
 		req.Slug = &slug
 	}
 
+	// An empty description or difficulty clears the stored value.
+	var description, difficulty *sql.NullString
+	if req.Description != nil {
+		d, err := normalizeDescription(*req.Description)
+		if err != nil {
+			return nil, err
+		}
+		description = &d
+	}
+	if req.Difficulty != nil {
+		d, err := normalizeDifficulty(*req.Difficulty)
+		if err != nil {
+			return nil, err
+		}
+		difficulty = &d
+	}
+
 	if req.AgeCategoryID != nil {
 		if *req.AgeCategoryID < 1 {
 			return nil, utils.ErrBadRequest("age_category_id must be >= 1")
@@ -594,6 +873,8 @@ func (s *GameService) UpdateAdminGame(ctx context.Context, id int64, req models.
 	_, err = s.gameRepo.UpdateAdminGame(ctx, id, repos.UpdateAdminGameInput{
 		Title:         req.Title,
 		Slug:          req.Slug,
+		Description:   description,
+		Difficulty:    difficulty,
 		Thumbnail:     req.Thumbnail,
 		GameURL:       req.GameURL,
 		AgeCategoryID: req.AgeCategoryID,
@@ -611,6 +892,7 @@ func (s *GameService) UpdateAdminGame(ctx context.Context, id int64, req models.
 			return nil, utils.ErrInternal()
 		}
 	}
+	s.catalogCache.InvalidateGame(ctx, id)
 
 	g, err := s.gameRepo.GetByID(ctx, id)
 	if err != nil {
@@ -647,6 +929,7 @@ func (s *GameService) PublishAdminGame(ctx context.Context, id int64) (*models.A
 		}
 		return nil, utils.ErrInternal()
 	}
+	s.catalogCache.InvalidateGame(ctx, id)
 
 	g, err = s.gameRepo.GetByID(ctx, g.ID)
 	if err != nil {
@@ -683,6 +966,7 @@ func (s *GameService) UnpublishAdminGame(ctx context.Context, id int64) (*models
 		}
 		return nil, utils.ErrInternal()
 	}
+	s.catalogCache.InvalidateGame(ctx, id)
 
 	g, err = s.gameRepo.GetByID(ctx, g.ID)
 	if err != nil {
@@ -697,6 +981,7 @@ func (s *GameService) UnpublishAdminGame(ctx context.Context, id int64) (*models
 }
 
 type UploadZipDTO struct {
+	BuildID   int64  `json:"build_id"`
 	ObjectKey string `json:"object_key"`
 	ETag      string `json:"etag"`
 	Size      int64  `json:"size"`
@@ -706,6 +991,7 @@ type UploadZipDTO struct {
 const (
 	maxZipUncompressedBytes int64 = 200 * 1024 * 1024
 	maxZipFileCount               = 2000
+	maxUploadRetryDelay           = 5 * time.Second
 )
 
 var allowedZipFileExtensions = map[string]struct{}{
@@ -737,10 +1023,7 @@ func (s *GameService) UploadAdminGameZip(ctx context.Context, gameID int64, file
 	if gameID < 1 {
 		return nil, utils.ErrBadRequest("id must be an integer >= 1")
 	}
-	if s.minio == nil {
-		return nil, utils.ErrInternal()
-	}
-	if strings.TrimSpace(s.minioBucket) == "" {
+	if s.store == nil {
 		return nil, utils.ErrInternal()
 	}
 	if size <= 0 {
@@ -830,7 +1113,8 @@ func (s *GameService) UploadAdminGameZip(ctx context.Context, gameID int64, file
 		return nil, utils.ErrMissingIndexHTML()
 	}
 
-	if err := s.uploadExtractedGameFiles(ctx, gameID, extractDir, extracted); err != nil {
+	files, err := describeExtractedGameFiles(extractDir, extracted)
+	if err != nil {
 		return nil, utils.ErrInternal()
 	}
 
@@ -844,23 +1128,61 @@ func (s *GameService) UploadAdminGameZip(ctx context.Context, gameID int64, file
 	uploadPrefix := fmt.Sprintf("%d/upload", gameID)
 	objectKey := path.Join(uploadPrefix, fmt.Sprintf("%s_%s.zip", ts, rnd))
 
-	if _, err := zipFile.Seek(0, io.SeekStart); err != nil {
+	var etag string
+	err = s.withUploadRetry(ctx, func() error {
+		if _, err := zipFile.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		var putErr error
+		etag, putErr = s.store.Put(ctx, objectKey, zipFile, info.Size(), ct)
+		return putErr
+	})
+	if err != nil {
+		s.deleteGameObjects(gameID, []string{objectKey})
 		return nil, utils.ErrInternal()
 	}
-	etag, err := s.minio.PutObject(ctx, s.minioBucket, objectKey, zipFile, info.Size(), ct)
+
+	buildFiles := make([]repos.GameBuildFile, 0, len(files))
+	for _, f := range files {
+		buildFiles = append(buildFiles, repos.GameBuildFile{
+			Path:        f.relPath,
+			SizeBytes:   f.size,
+			ContentType: nullString(f.contentType),
+			SHA256:      nullString(f.sha256),
+		})
+	}
+	buildRow, err := s.buildRepo.Create(ctx, repos.CreateGameBuildInput{
+		GameID:          gameID,
+		UploadObjectKey: objectKey,
+		Files:           buildFiles,
+	})
 	if err != nil {
+		s.deleteGameObjects(gameID, []string{objectKey})
 		return nil, utils.ErrInternal()
 	}
 
-	playableURL := fmt.Sprintf("/games/%d/current/index.html", gameID)
-	if _, err := s.gameRepo.SetGameURL(ctx, gameID, playableURL); err != nil {
+	build := newGameBuildUpload(gameID, buildRow.ID, files)
+	if err := s.publishGameBuild(ctx, build); err != nil {
+		log.Printf("level=error msg=%q game_id=%d build_id=%d err=%v", "publish game build failed", gameID, buildRow.ID, err)
+		s.discardGameBuild(build, objectKey)
+		return nil, utils.ErrInternal()
+	}
+
+	// Every object is in place; switching the pointer is the only step that
+	// changes what players load.
+	playableURL := gameBuildURL(gameID, buildRow.ID)
+	if err := s.buildRepo.MarkCurrent(ctx, gameID, buildRow.ID, playableURL); err != nil {
+		s.discardGameBuild(build, objectKey)
 		if errors.Is(err, repos.ErrNotFound) {
 			return nil, utils.ErrNotFound("game not found")
 		}
 		return nil, utils.ErrInternal()
 	}
+	// The detail embeds game_url and the current build's offline summary.
+	s.catalogCache.InvalidateGame(ctx, gameID)
 
 	return &UploadZipDTO{
+		BuildID:   buildRow.ID,
 		ObjectKey: objectKey,
 		ETag:      etag,
 		Size:      info.Size(),
@@ -1062,9 +1384,54 @@ func isWithinRoot(root, target string) bool {
 	return strings.HasPrefix(target, root)
 }
 
-func (s *GameService) uploadExtractedGameFiles(ctx context.Context, gameID int64, root string, files []string) error {
-	currentPrefix := fmt.Sprintf("%d/current", gameID)
+type extractedGameFile struct {
+	relPath     string
+	fullPath    string
+	size        int64
+	contentType string
+	sha256      string
+}
 
+// gameBuildUpload is a build's files on their way to its own storage prefix.
+// Builds never share objects, so a failed upload cannot touch the live one.
+type gameBuildUpload struct {
+	gameID  int64
+	buildID int64
+	prefix  string
+	files   []extractedGameFile
+}
+
+func newGameBuildUpload(gameID int64, buildID int64, files []extractedGameFile) *gameBuildUpload {
+	return &gameBuildUpload{
+		gameID:  gameID,
+		buildID: buildID,
+		prefix:  gameBuildPrefix(gameID, buildID),
+		files:   files,
+	}
+}
+
+func (b *gameBuildUpload) keys() []string {
+	keys := make([]string, 0, len(b.files))
+	for _, f := range b.files {
+		keys = append(keys, path.Join(b.prefix, f.relPath))
+	}
+	return keys
+}
+
+func gameBuildPrefix(gameID int64, buildID int64) string {
+	return fmt.Sprintf("%d/builds/%d", gameID, buildID)
+}
+
+// gameBuildURL is the playable URL of a build. It is the same path under
+// nginx (straight to the bucket) and under the API asset routes.
+func gameBuildURL(gameID int64, buildID int64) string {
+	return fmt.Sprintf("/games/%s/index.html", gameBuildPrefix(gameID, buildID))
+}
+
+// describeExtractedGameFiles records size, content type and hash of every
+// extracted file.
+func describeExtractedGameFiles(root string, files []string) ([]extractedGameFile, error) {
+	out := make([]extractedGameFile, 0, len(files))
 	for _, rel := range files {
 		rel = filepath.ToSlash(rel)
 		if rel == "" {
@@ -1074,37 +1441,212 @@ func (s *GameService) uploadExtractedGameFiles(ctx context.Context, gameID int64
 		fullPath := filepath.Join(root, filepath.FromSlash(rel))
 		info, err := os.Stat(fullPath)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if info.IsDir() {
 			continue
 		}
 
-		f, err := os.Open(fullPath)
+		contentType, err := detectGameFileContentType(fullPath, rel)
 		if err != nil {
-			return err
+			return nil, err
+		}
+
+		sum, err := hashGameFile(fullPath)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, extractedGameFile{
+			relPath:     rel,
+			fullPath:    fullPath,
+			size:        info.Size(),
+			contentType: contentType,
+			sha256:      sum,
+		})
+	}
+	return out, nil
+}
+
+// publishGameBuild uploads every file under the build's prefix. On failure
+// the objects written so far are removed again.
+func (s *GameService) publishGameBuild(ctx context.Context, build *gameBuildUpload) error {
+	err := runUploadPool(ctx, s.uploadConcurrency, len(build.files), func(ctx context.Context, i int) error {
+		f := build.files[i]
+		objectKey := path.Join(build.prefix, f.relPath)
+		return s.withUploadRetry(ctx, func() error {
+			return s.putGameFile(ctx, objectKey, f)
+		})
+	})
+	if err != nil {
+		s.deleteGameObjects(build.gameID, build.keys())
+		return err
+	}
+	return nil
+}
+
+// discardGameBuild undoes a failed upload: the build row, its objects and the
+// ZIP. A build that did become current (the commit landed but its reply was
+// lost) is kept, and so is everything when the database cannot tell; storage
+// GC removes what is left once the build is superseded.
+func (s *GameService) discardGameBuild(build *gameBuildUpload, zipKey string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	if err := s.buildRepo.Delete(ctx, build.buildID); err != nil {
+		if !errors.Is(err, repos.ErrNotFound) {
+			log.Printf("level=warn msg=%q game_id=%d build_id=%d err=%v", "game build cleanup failed", build.gameID, build.buildID, err)
+			return
 		}
+		cur, curErr := s.buildRepo.GetCurrent(ctx, build.gameID)
+		if curErr != nil && !errors.Is(curErr, repos.ErrNotFound) {
+			return
+		}
+		if cur != nil && cur.ID == build.buildID {
+			return
+		}
+	}
+	s.deleteGameObjects(build.gameID, append(build.keys(), zipKey))
+}
+
+// deleteGameObjects runs on a detached context so cleanup still happens when
+// the request context is the reason the upload failed.
+func (s *GameService) deleteGameObjects(gameID int64, keys []string) {
+	if len(keys) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
 
-		contentType := mime.TypeByExtension(filepath.Ext(rel))
-		if contentType == "" {
-			head := make([]byte, 512)
-			n, _ := f.Read(head)
-			contentType = http.DetectContentType(head[:n])
-			if _, err := f.Seek(0, io.SeekStart); err != nil {
-				_ = f.Close()
-				return err
+	if err := s.store.Delete(ctx, keys); err != nil {
+		log.Printf("level=warn msg=%q game_id=%d keys=%d err=%v", "game object cleanup failed", gameID, len(keys), err)
+	}
+}
+
+func (s *GameService) putGameFile(ctx context.Context, objectKey string, file extractedGameFile) error {
+	f, err := os.Open(file.fullPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	_, err = s.store.Put(ctx, objectKey, f, file.size, file.contentType)
+	return err
+}
+
+func (s *GameService) withUploadRetry(ctx context.Context, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= s.uploadMaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := s.uploadRetryBackoff << (attempt - 1)
+			if delay <= 0 || delay > maxUploadRetryDelay {
+				delay = maxUploadRetryDelay
+			}
+
+			timer := time.NewTimer(delay)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return ctx.Err()
+			case <-timer.C:
 			}
 		}
 
-		objectKey := path.Join(currentPrefix, rel)
-		if _, err := s.minio.PutObject(ctx, s.minioBucket, objectKey, f, info.Size(), contentType); err != nil {
-			_ = f.Close()
-			return err
+		if err = fn(); err == nil {
+			return nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
 		}
-		_ = f.Close()
 	}
+	return err
+}
 
-	return nil
+// runUploadPool calls fn for every index in [0, n) using at most concurrency
+// workers. The first error cancels the remaining work and is returned.
+func runUploadPool(ctx context.Context, concurrency int, n int, fn func(ctx context.Context, i int) error) error {
+	if n <= 0 {
+		return nil
+	}
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	if concurrency > n {
+		concurrency = n
+	}
+
+	poolCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+
+	jobs := make(chan int)
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				if err := fn(poolCtx, i); err != nil {
+					errOnce.Do(func() {
+						firstErr = err
+						cancel()
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-poolCtx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+func detectGameFileContentType(fullPath string, rel string) (string, error) {
+	contentType := mime.TypeByExtension(filepath.Ext(rel))
+	if contentType != "" {
+		return contentType, nil
+	}
+
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	head := make([]byte, 512)
+	n, _ := f.Read(head)
+	return http.DetectContentType(head[:n]), nil
+}
+
+func hashGameFile(fullPath string) (string, error) {
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
 }
 
 func randHex(nBytes int) (string, error) {
@@ -1127,6 +1669,14 @@ func toAdminGameDTO(g repos.Game) models.AdminGameDTO {
 	if g.GameURL.Valid {
 		url = g.GameURL.String
 	}
+	var description string
+	if g.Description.Valid {
+		description = g.Description.String
+	}
+	var difficulty models.GameDifficulty
+	if g.Difficulty.Valid {
+		difficulty = models.GameDifficulty(g.Difficulty.String)
+	}
 
 	educationCategoryIDs := parseEducationCategoryIDs(g.EducationCategoryIDsJSON)
 	educationCategories := toAdminEducationCategories(g.EducationCategoriesJSON)
@@ -1136,12 +1686,15 @@ func toAdminGameDTO(g repos.Game) models.AdminGameDTO {
 		Title:                g.Title,
 		Slug:                 g.Slug,
 		Status:               models.GameStatus(g.Status),
+		Description:          description,
+		Difficulty:           difficulty,
 		Thumbnail:            thumb,
 		GameURL:              url,
 		AgeCategoryID:        g.AgeCategoryID,
 		EducationCategoryIDs: educationCategoryIDs,
 		EducationCategories:  educationCategories,
 		Free:                 g.Free,
+		FavoriteCount:        g.FavoriteCount,
 		CreatedAt:            g.CreatedAt,
 		UpdatedAt:            g.UpdatedAt,
 	}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/storage"
+)
+
+// failingStore fails every Put whose key ends in failSuffix.
+type failingStore struct {
+	*storage.MemoryStore
+	failSuffix string
+}
+
+func (s *failingStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	if strings.HasSuffix(key, s.failSuffix) {
+		return "", errors.New("injected put failure")
+	}
+	return s.MemoryStore.Put(ctx, key, r, size, contentType)
+}
+
+func writeExtractedBuild(t *testing.T, files map[string]string) (string, []string) {
+	t.Helper()
+	root := t.TempDir()
+	rels := make([]string, 0, len(files))
+	for rel, body := range files {
+		full := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		rels = append(rels, rel)
+	}
+	slices.Sort(rels)
+	return root, rels
+}
+
+func storeKeys(t *testing.T, store storage.ObjectStore, prefix string) []string {
+	t.Helper()
+	objects, err := store.List(context.Background(), prefix, true)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	keys := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		keys = append(keys, obj.Key)
+	}
+	return keys
+}
+
+var testBuildFiles = map[string]string{
+	"index.html":       "<html><script src=\"js/app.js\"></script></html>",
+	"js/app.js":        "run()",
+	"assets/tile.json": "{}",
+}
+
+func TestPublishGameBuild(t *testing.T) {
+	root, rels := writeExtractedBuild(t, testBuildFiles)
+	files, err := describeExtractedGameFiles(root, rels)
+	if err != nil {
+		t.Fatalf("describeExtractedGameFiles: %v", err)
+	}
+
+	store := storage.NewMemoryStore()
+	// A live build of the same game must not be touched.
+	mustPutObject(t, store, "7/builds/4/index.html", "old")
+
+	svc := &GameService{store: store, uploadConcurrency: 2}
+	if err := svc.publishGameBuild(context.Background(), newGameBuildUpload(7, 5, files)); err != nil {
+		t.Fatalf("publishGameBuild: %v", err)
+	}
+
+	want := []string{"7/builds/5/assets/tile.json", "7/builds/5/index.html", "7/builds/5/js/app.js"}
+	if got := storeKeys(t, store, "7/builds/5/"); !slices.Equal(got, want) {
+		t.Fatalf("build objects = %v, want %v", got, want)
+	}
+	info, err := store.Stat(context.Background(), "7/builds/5/js/app.js")
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if !strings.HasPrefix(info.ContentType, "text/javascript") {
+		t.Fatalf("app.js content type = %q", info.ContentType)
+	}
+	if got := storeKeys(t, store, "7/builds/4/"); !slices.Equal(got, []string{"7/builds/4/index.html"}) {
+		t.Fatalf("live build changed: %v", got)
+	}
+}
+
+func TestPublishGameBuildFailureRemovesObjects(t *testing.T) {
+	root, rels := writeExtractedBuild(t, testBuildFiles)
+	files, err := describeExtractedGameFiles(root, rels)
+	if err != nil {
+		t.Fatalf("describeExtractedGameFiles: %v", err)
+	}
+
+	store := &failingStore{MemoryStore: storage.NewMemoryStore(), failSuffix: "js/app.js"}
+	mustPutObject(t, store.MemoryStore, "7/builds/4/index.html", "old")
+
+	svc := &GameService{store: store, uploadConcurrency: 1}
+	if err := svc.publishGameBuild(context.Background(), newGameBuildUpload(7, 5, files)); err == nil {
+		t.Fatal("publishGameBuild succeeded despite a failing put")
+	}
+
+	if got := storeKeys(t, store, "7/builds/5/"); len(got) != 0 {
+		t.Fatalf("failed build left objects behind: %v", got)
+	}
+	if got := storeKeys(t, store, "7/"); !slices.Equal(got, []string{"7/builds/4/index.html"}) {
+		t.Fatalf("objects after failed publish = %v", got)
+	}
+}
+
+func TestDescribeExtractedGameFiles(t *testing.T) {
+	root, rels := writeExtractedBuild(t, map[string]string{"index.html": "hello"})
+	files, err := describeExtractedGameFiles(root, rels)
+	if err != nil {
+		t.Fatalf("describeExtractedGameFiles: %v", err)
+	}
+	if len(files) != 1 {
+		t.Fatalf("files = %+v", files)
+	}
+	f := files[0]
+	const helloSHA256 = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	if f.relPath != "index.html" || f.size != 5 || f.sha256 != helloSHA256 || !strings.HasPrefix(f.contentType, "text/html") {
+		t.Fatalf("file = %+v", f)
+	}
+}
+
+func TestGameBuildURL(t *testing.T) {
+	if got := gameBuildURL(7, 5); got != "/games/7/builds/5/index.html" {
+		t.Fatalf("gameBuildURL = %q", got)
+	}
+}
+
+func mustPutObject(t *testing.T, store storage.ObjectStore, key, body string) {
+	t.Helper()
+	if _, err := store.Put(context.Background(), key, strings.NewReader(body), int64(len(body)), ""); err != nil {
+		t.Fatalf("Put(%q): %v", key, err)
+	}
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/google/uuid"
@@ -22,6 +23,7 @@ func NewHistoryService(repo *repos.PlayerHistoryRepo) *HistoryService {
 func (s *HistoryService) ListPlayerHistory(
 	ctx context.Context,
 	playerID string,
+	profileID int64,
 	page int,
 	limit int,
 ) (*models.PlayerHistoryResponse, error) {
@@ -43,7 +45,7 @@ func (s *HistoryService) ListPlayerHistory(
 		return nil, utils.ErrBadRequest("limit must be an integer between 1 and 100")
 	}
 
-	items, total, err := s.repo.ListByPlayerID(ctx, playerID, page, limit)
+	items, total, err := s.repo.ListByPlayerID(ctx, playerID, profileID, page, limit)
 	if err != nil {
 		return nil, utils.ErrInternal()
 	}
@@ -78,3 +80,41 @@ func (s *HistoryService) ListPlayerHistory(
 		},
 	}, nil
 }
+
+const (
+	defaultRecentGamesLimit = 12
+	maxRecentGamesLimit     = 50
+)
+
+// ListRecentlyPlayed is the "play again" list: one entry per game rather than
+// per session.
+func (s *HistoryService) ListRecentlyPlayed(ctx context.Context, playerID string, profileID int64, limit int) (*models.PlayerRecentGamesResponse, error) {
+	playerID = normalizePlayerID(playerID)
+	if playerID == "" {
+		return nil, utils.ErrUnauthorized()
+	}
+	if limit == 0 {
+		limit = defaultRecentGamesLimit
+	}
+	if limit < 1 || limit > maxRecentGamesLimit {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("limit must be an integer between 1 and %d", maxRecentGamesLimit))
+	}
+
+	rows, err := s.repo.ListRecentGames(ctx, playerID, profileID, limit)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+
+	out := make([]models.PlayerRecentGameItem, 0, len(rows))
+	for _, it := range rows {
+		out = append(out, models.PlayerRecentGameItem{
+			GameID:       it.GameID,
+			Title:        it.Title,
+			Slug:         it.Slug,
+			Thumbnail:    toNullableString(it.Thumbnail),
+			LastPlayedAt: it.LastPlayedAt.UTC().Format("2006-01-02T15:04:05Z"),
+			Sessions:     it.Sessions,
+		})
+	}
+	return &models.PlayerRecentGamesResponse{Data: out}, nil
+}
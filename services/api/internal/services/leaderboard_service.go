@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"database/sql"
+	"strconv"
 	"strings"
 	"time"
 
@@ -30,11 +31,13 @@ func (s *LeaderboardService) SubmitScore(
 	ctx context.Context,
 	tokenGameID int64,
 	tokenPlayerID string,
+	tokenProfileID int64,
 	guestID string,
 	req models.SubmitScoreRequest,
 	sessionID string,
 	ipHash string,
 	userAgentHash string,
+	preview bool,
 ) (*models.SubmitScoreResponse, *utils.AppError) {
 	if req.GameID <= 0 {
 		e := utils.ErrBadRequest("game_id must be a positive integer")
@@ -63,7 +66,10 @@ func (s *LeaderboardService) SubmitScore(
 	tokenPlayerID = normalizePlayerID(tokenPlayerID)
 	sessionID = strings.TrimSpace(sessionID)
 
-	member := resolveSubmissionMember(tokenPlayerID, sessionID, guestID)
+	if tokenPlayerID == "" {
+		tokenProfileID = 0
+	}
+	member := resolveSubmissionMember(tokenPlayerID, tokenProfileID, sessionID, guestID)
 	if member == "" {
 		e := utils.ErrUnauthorized()
 		return nil, &e
@@ -72,7 +78,7 @@ func (s *LeaderboardService) SubmitScore(
 
 	sub := &repos.LeaderboardSubmission{
 		GameID:        req.GameID,
-		PlayerID:      sql.NullInt64{Valid: false},
+		PlayerID:      sql.NullInt64{Int64: tokenProfileID, Valid: tokenProfileID > 0},
 		SessionID:     nullString(sessionID),
 		Member:        nullString(member),
 		Score:         req.Score,
@@ -80,14 +86,23 @@ func (s *LeaderboardService) SubmitScore(
 		UserAgentHash: nullString(userAgentHash),
 	}
 
-	if _, err := s.submissionRepo.CreateSubmission(ctx, sub); err != nil {
-		e := utils.ErrInternal()
-		return nil, &e
+	// Preview scores live only in the sandbox keys and are never persisted.
+	dKey := clients.KeyPreviewGameDaily(req.GameID, now)
+	wKey := clients.KeyPreviewGameWeekly(req.GameID, now)
+	if !preview {
+		if _, err := s.submissionRepo.CreateSubmission(ctx, sub); err != nil {
+			// The profile was deleted while the play token was live.
+			if isFKViolation(err) {
+				e := utils.ErrUnauthorized()
+				return nil, &e
+			}
+			e := utils.ErrInternal()
+			return nil, &e
+		}
+		dKey = clients.KeyGameDaily(req.GameID, now)
+		wKey = clients.KeyGameWeekly(req.GameID, now)
 	}
 
-	dKey := clients.KeyGameDaily(req.GameID, now)
-	wKey := clients.KeyGameWeekly(req.GameID, now)
-
 	bestDaily, errApp := s.upsertIfHigher(ctx, dKey, member, req.Score, clients.DailyTTL)
 	if errApp != nil {
 		return nil, errApp
@@ -114,8 +129,9 @@ func (s *LeaderboardService) GetTop(
 	period string,
 	scope string,
 	limit int,
+	preview bool,
 ) ([]models.LeaderboardItem, error) {
-	_, _, key, err := resolveLeaderboardKey(gameID, period, scope, time.Now().UTC())
+	_, _, key, err := resolveLeaderboardKey(gameID, period, scope, preview, time.Now().UTC())
 	if err != nil {
 		return nil, err
 	}
@@ -149,13 +165,14 @@ func (s *LeaderboardService) GetSelf(
 	period string,
 	scope string,
 	member string,
+	preview bool,
 ) (*models.LeaderboardSelfDTO, error) {
 	member = strings.TrimSpace(member)
 	if member == "" {
 		return nil, utils.ErrUnauthorized()
 	}
 
-	period, scope, key, err := resolveLeaderboardKey(gameID, period, scope, time.Now().UTC())
+	period, scope, key, err := resolveLeaderboardKey(gameID, period, scope, preview, time.Now().UTC())
 	if err != nil {
 		return nil, err
 	}
@@ -232,6 +249,7 @@ func resolveLeaderboardKey(
 	gameID int64,
 	period string,
 	scope string,
+	preview bool,
 	now time.Time,
 ) (normalizedPeriod string, normalizedScope string, key string, err error) {
 	if gameID <= 0 {
@@ -256,6 +274,16 @@ func resolveLeaderboardKey(
 
 	now = now.UTC()
 
+	if preview {
+		if normalizedScope != "game" {
+			return "", "", "", utils.ErrBadRequest("preview leaderboards only support scope 'game'")
+		}
+		if normalizedPeriod == "daily" {
+			return normalizedPeriod, normalizedScope, clients.KeyPreviewGameDaily(gameID, now), nil
+		}
+		return normalizedPeriod, normalizedScope, clients.KeyPreviewGameWeekly(gameID, now), nil
+	}
+
 	if normalizedScope == "game" {
 		if normalizedPeriod == "daily" {
 			return normalizedPeriod, normalizedScope, clients.KeyGameDaily(gameID, now), nil
@@ -269,10 +297,19 @@ func resolveLeaderboardKey(
 	return normalizedPeriod, normalizedScope, clients.KeyGlobalWeekly(now), nil
 }
 
-func resolveSubmissionMember(playerID string, sessionID string, guestID string) string {
+// PlayerMember is the leaderboard member of a signed-in player: p:<player_id>
+// for the parent account, p:<player_id>:<profile_id> for a child profile.
+func PlayerMember(playerID string, profileID int64) string {
+	if profileID > 0 {
+		return "p:" + playerID + ":" + strconv.FormatInt(profileID, 10)
+	}
+	return "p:" + playerID
+}
+
+func resolveSubmissionMember(playerID string, profileID int64, sessionID string, guestID string) string {
 	playerID = normalizePlayerID(playerID)
 	if playerID != "" {
-		return "p:" + playerID
+		return PlayerMember(playerID, profileID)
 	}
 
 	sessionID = strings.TrimSpace(sessionID)
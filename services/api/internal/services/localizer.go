@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+// Localizer overlays translated titles, descriptions and category names on
+// public catalog DTOs. Base rows hold the default locale, so a request in
+// the default locale never touches the translation tables; any field without
+// a translation keeps its base text.
+type Localizer struct {
+	translationRepo *repos.TranslationRepo
+	localeCfg       config.LocaleConfig
+}
+
+func NewLocalizer(translationRepo *repos.TranslationRepo, localeCfg config.LocaleConfig) *Localizer {
+	return &Localizer{
+		translationRepo: translationRepo,
+		localeCfg:       localeCfg,
+	}
+}
+
+// Resolve maps a requested locale to one the catalog serves.
+func (l *Localizer) Resolve(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if !l.localeCfg.IsSupported(locale) {
+		return l.localeCfg.Default
+	}
+	return locale
+}
+
+func (l *Localizer) translates(locale string) bool {
+	return locale != l.localeCfg.Default
+}
+
+type catalogTranslations struct {
+	locale string
+	games  map[int64]repos.GameTranslation
+	ages   map[int64]string
+	edus   map[int64]string
+}
+
+func (l *Localizer) load(ctx context.Context, locale string, gameIDs, ageIDs, eduIDs []int64) (*catalogTranslations, error) {
+	games, err := l.translationRepo.GameTranslationsFor(ctx, locale, gameIDs)
+	if err != nil {
+		return nil, err
+	}
+	ages, err := l.translationRepo.CategoryTranslationsFor(ctx, repos.CategoryKindAge, locale, ageIDs)
+	if err != nil {
+		return nil, err
+	}
+	edus, err := l.translationRepo.CategoryTranslationsFor(ctx, repos.CategoryKindEducation, locale, eduIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &catalogTranslations{locale: locale, games: games, ages: ages, edus: edus}, nil
+}
+
+// game overlays the game's own fields and returns the locale they are in.
+func (t *catalogTranslations) game(id int64, title *string, description **string, fallback string) string {
+	tr, ok := t.games[id]
+	if !ok {
+		return fallback
+	}
+	*title = tr.Title
+	if d := toNullableString(tr.Description); d != nil {
+		*description = d
+	}
+	return t.locale
+}
+
+func (t *catalogTranslations) ageLabel(id int64, label **string) {
+	if *label == nil {
+		return
+	}
+	if text, ok := t.ages[id]; ok {
+		*label = &text
+	}
+}
+
+func (t *catalogTranslations) educationCategories(cats []PublicEducationCategoryDTO) {
+	for i := range cats {
+		if text, ok := t.edus[cats[i].ID]; ok {
+			cats[i].Name = text
+		}
+	}
+}
+
+// LocalizeGameList translates items in place. Each item's Locale reports
+// whether its title and description came from a translation.
+func (l *Localizer) LocalizeGameList(ctx context.Context, locale string, items []GameListItemDTO) error {
+	def := l.localeCfg.Default
+	for i := range items {
+		items[i].Locale = def
+	}
+	if !l.translates(locale) || len(items) == 0 {
+		return nil
+	}
+
+	gameIDs := make([]int64, 0, len(items))
+	ageIDs := make([]int64, 0, len(items))
+	eduIDs := make([]int64, 0)
+	for _, it := range items {
+		gameIDs = append(gameIDs, it.ID)
+		ageIDs = append(ageIDs, it.AgeCategoryID)
+		for _, ec := range it.EducationCategories {
+			eduIDs = append(eduIDs, ec.ID)
+		}
+	}
+
+	tr, err := l.load(ctx, locale, gameIDs, ageIDs, eduIDs)
+	if err != nil {
+		return utils.ErrInternal()
+	}
+	for i := range items {
+		it := &items[i]
+		it.Locale = tr.game(it.ID, &it.Title, &it.Description, def)
+		tr.ageLabel(it.AgeCategoryID, &it.AgeLabel)
+		tr.educationCategories(it.EducationCategories)
+	}
+	return nil
+}
+
+func (l *Localizer) LocalizeGameDetail(ctx context.Context, locale string, dto *GameDetailDTO) error {
+	dto.Locale = l.localeCfg.Default
+	if !l.translates(locale) {
+		return nil
+	}
+
+	eduIDs := make([]int64, 0, len(dto.EducationCategories))
+	for _, ec := range dto.EducationCategories {
+		eduIDs = append(eduIDs, ec.ID)
+	}
+	tr, err := l.load(ctx, locale, []int64{dto.ID}, []int64{dto.AgeCategoryID}, eduIDs)
+	if err != nil {
+		return utils.ErrInternal()
+	}
+	dto.Locale = tr.game(dto.ID, &dto.Title, &dto.Description, dto.Locale)
+	tr.ageLabel(dto.AgeCategoryID, &dto.AgeLabel)
+	tr.educationCategories(dto.EducationCategories)
+	return nil
+}
+
+func (l *Localizer) LocalizeCategories(ctx context.Context, locale string, dto *PublicCategoriesDTO) error {
+	dto.Locale = l.localeCfg.Default
+	if !l.translates(locale) {
+		return nil
+	}
+
+	tr, err := l.load(ctx, locale, nil, nil, nil)
+	if err != nil {
+		return utils.ErrInternal()
+	}
+	for i := range dto.AgeCategories {
+		if text, ok := tr.ages[dto.AgeCategories[i].ID]; ok {
+			dto.AgeCategories[i].Label = text
+		}
+	}
+	tr.educationCategories(dto.EducationCategories)
+	dto.Locale = locale
+	return nil
+}
+
+// LocalizeFacets translates the category labels of list facets in place, the
+// same way LocalizeCategories does for the category list.
+func (l *Localizer) LocalizeFacets(ctx context.Context, locale string, facets *GameFacetsDTO) error {
+	if facets == nil || !l.translates(locale) {
+		return nil
+	}
+
+	ageIDs := make([]int64, 0, len(facets.AgeCategories))
+	for _, f := range facets.AgeCategories {
+		ageIDs = append(ageIDs, f.ID)
+	}
+	eduIDs := make([]int64, 0, len(facets.EducationCategories))
+	for _, f := range facets.EducationCategories {
+		eduIDs = append(eduIDs, f.ID)
+	}
+	tr, err := l.load(ctx, locale, nil, ageIDs, eduIDs)
+	if err != nil {
+		return utils.ErrInternal()
+	}
+	for i := range facets.AgeCategories {
+		if text, ok := tr.ages[facets.AgeCategories[i].ID]; ok {
+			facets.AgeCategories[i].Label = text
+		}
+	}
+	for i := range facets.EducationCategories {
+		if text, ok := tr.edus[facets.EducationCategories[i].ID]; ok {
+			facets.EducationCategories[i].Label = text
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+)
+
+type PopularityService struct {
+	repo       *repos.PopularityRepo
+	windowDays int
+}
+
+func NewPopularityService(repo *repos.PopularityRepo, popularityCfg config.PopularityConfig) *PopularityService {
+	return &PopularityService{repo: repo, windowDays: popularityCfg.WindowDays}
+}
+
+// Reconcile rebuilds the rollup for the days inside the popularity window,
+// which also backfills it after a fresh deploy.
+func (s *PopularityService) Reconcile(ctx context.Context) (int64, error) {
+	since := time.Now().UTC().AddDate(0, 0, -(s.windowDays - 1))
+	return s.repo.Reconcile(ctx, since)
+}
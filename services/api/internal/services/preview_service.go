@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+// PreviewService issues the signed links admins use to play a game before
+// it is published. Sessions started with one are tagged as preview: scores
+// go to a sandbox leaderboard and plays stay out of popularity and history.
+type PreviewService struct {
+	cfg      config.Config
+	gameRepo *repos.GameRepo
+}
+
+func NewPreviewService(cfg config.Config, gameRepo *repos.GameRepo) *PreviewService {
+	return &PreviewService{cfg: cfg, gameRepo: gameRepo}
+}
+
+func (s *PreviewService) IssueToken(ctx context.Context, gameID, adminID int64) (*models.PreviewTokenResponse, error) {
+	if gameID < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+
+	g, err := s.gameRepo.GetByID(ctx, gameID)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	if g.Status == string(models.GameStatusArchived) {
+		return nil, utils.ErrBadRequest("archived game cannot be previewed")
+	}
+
+	token, exp, err := utils.GeneratePreviewToken(s.cfg.JWT, gameID, adminID, s.cfg.Preview.TokenTTL)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+
+	return &models.PreviewTokenResponse{
+		GameID:       gameID,
+		PreviewToken: token,
+		ExpiresAt:    exp.UTC(),
+	}, nil
+}
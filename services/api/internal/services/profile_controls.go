@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+const (
+	maxProfileControlIDs = 200
+	maxTimeZoneLen       = 64
+	quietHoursLayout     = "15:04"
+)
+
+// ProfileControlService manages parental controls and decides whether a
+// profile may play a game right now. Session start calls Allow; a session
+// heartbeat should call it too once there is one.
+type ProfileControlService struct {
+	profileRepo  *repos.ProfileRepo
+	controlsRepo *repos.ProfileControlsRepo
+	sessRepo     *repos.SessionRepo
+}
+
+func NewProfileControlService(profileRepo *repos.ProfileRepo, controlsRepo *repos.ProfileControlsRepo, sessRepo *repos.SessionRepo) *ProfileControlService {
+	return &ProfileControlService{
+		profileRepo:  profileRepo,
+		controlsRepo: controlsRepo,
+		sessRepo:     sessRepo,
+	}
+}
+
+func (s *ProfileControlService) Get(ctx context.Context, playerID string, profileID int64) (*models.ProfileControlsDTO, error) {
+	if err := s.checkOwner(ctx, playerID, profileID); err != nil {
+		return nil, err
+	}
+	return s.get(ctx, profileID, time.Now().UTC())
+}
+
+func (s *ProfileControlService) Replace(ctx context.Context, playerID string, profileID int64, req models.ProfileControlsRequest) (*models.ProfileControlsDTO, error) {
+	if err := s.checkOwner(ctx, playerID, profileID); err != nil {
+		return nil, err
+	}
+	in, err := validateProfileControls(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.controlsRepo.Replace(ctx, profileID, in); err != nil {
+		switch {
+		case errors.Is(err, repos.ErrNotFound):
+			return nil, utils.ErrNotFound("profile not found")
+		case isFKViolation(err):
+			return nil, utils.ErrBadRequest("unknown age category, education category or game id")
+		}
+		return nil, utils.ErrInternal()
+	}
+	return s.get(ctx, profileID, time.Now().UTC())
+}
+
+// Allow returns nil when the profile may play the game at now, along with
+// the time its play must stop (zero for no limit): the next quiet hours or
+// the end of today's budget, whichever comes first. A refusal is one of the
+// PROFILE_* errors, which the UI explains to the child.
+func (s *ProfileControlService) Allow(ctx context.Context, profileID int64, game *repos.Game, now time.Time) (time.Time, error) {
+	c, err := s.controlsRepo.Get(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return time.Time{}, utils.ErrUnauthorized()
+		}
+		return time.Time{}, utils.ErrInternal()
+	}
+
+	if slices.Contains(c.BlockedGameIDs, game.ID) {
+		return time.Time{}, utils.ErrProfileGameBlocked()
+	}
+	if len(c.AllowedAgeCategoryIDs) > 0 && !slices.Contains(c.AllowedAgeCategoryIDs, game.AgeCategoryID) {
+		return time.Time{}, utils.ErrProfileCategoryNotAllowed()
+	}
+	if len(c.AllowedEducationCategoryIDs) > 0 {
+		allowed := false
+		for _, id := range parseEducationCategoryIDs(game.EducationCategoryIDsJSON) {
+			if slices.Contains(c.AllowedEducationCategoryIDs, id) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return time.Time{}, utils.ErrProfileCategoryNotAllowed()
+		}
+	}
+
+	loc := profileLocation(c.TimeZone)
+	local := now.In(loc)
+	var until time.Time
+
+	if c.QuietStart.Valid && c.QuietEnd.Valid {
+		start, end := int(c.QuietStart.Int64), int(c.QuietEnd.Int64)
+		if inQuietHours(start, end, local.Hour()*60+local.Minute()) {
+			return time.Time{}, utils.ErrProfileQuietHours(formatMinuteOfDay(end))
+		}
+		until = nextMinuteOfDay(local, start)
+	}
+
+	if c.DailyMinutes.Valid {
+		used, err := s.usedSecondsToday(ctx, profileID, local)
+		if err != nil {
+			return time.Time{}, utils.ErrInternal()
+		}
+		remaining := c.DailyMinutes.Int64*60 - used
+		if remaining <= 0 {
+			return time.Time{}, utils.ErrProfileDailyLimitReached()
+		}
+		budgetEnd := now.Add(time.Duration(remaining) * time.Second)
+		if until.IsZero() || budgetEnd.Before(until) {
+			until = budgetEnd
+		}
+	}
+
+	return until, nil
+}
+
+func (s *ProfileControlService) checkOwner(ctx context.Context, playerID string, profileID int64) error {
+	playerID = normalizePlayerID(playerID)
+	if playerID == "" {
+		return utils.ErrUnauthorized()
+	}
+	if profileID < 1 {
+		return utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	if _, err := s.profileRepo.GetForPlayer(ctx, playerID, profileID); err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return utils.ErrNotFound("profile not found")
+		}
+		return utils.ErrInternal()
+	}
+	return nil
+}
+
+func (s *ProfileControlService) get(ctx context.Context, profileID int64, now time.Time) (*models.ProfileControlsDTO, error) {
+	c, err := s.controlsRepo.Get(ctx, profileID)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("profile not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	used, err := s.usedSecondsToday(ctx, profileID, now.In(profileLocation(c.TimeZone)))
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+
+	dto := &models.ProfileControlsDTO{
+		ProfileID:                   profileID,
+		AllowedAgeCategoryIDs:       c.AllowedAgeCategoryIDs,
+		AllowedEducationCategoryIDs: c.AllowedEducationCategoryIDs,
+		BlockedGameIDs:              c.BlockedGameIDs,
+		DailyMinutes:                toNullableInt(c.DailyMinutes),
+		TimeZone:                    c.TimeZone,
+		UsedMinutesToday:            int(used / 60),
+		UpdatedAt:                   nullTimePtr(c.UpdatedAt),
+	}
+	if c.QuietStart.Valid && c.QuietEnd.Valid {
+		dto.QuietHours = &models.QuietHours{
+			Start: formatMinuteOfDay(int(c.QuietStart.Int64)),
+			End:   formatMinuteOfDay(int(c.QuietEnd.Int64)),
+		}
+	}
+	return dto, nil
+}
+
+// usedSecondsToday counts play since local midnight.
+func (s *ProfileControlService) usedSecondsToday(ctx context.Context, profileID int64, local time.Time) (int64, error) {
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	return s.sessRepo.PlayedSecondsSince(ctx, profileID, midnight.UTC(), int(playTokenTTL.Seconds()))
+}
+
+func validateProfileControls(req models.ProfileControlsRequest) (repos.ProfileControls, error) {
+	var out repos.ProfileControls
+	for _, list := range []struct {
+		field string
+		in    []int64
+		out   *[]int64
+	}{
+		{"allowed_age_category_ids", req.AllowedAgeCategoryIDs, &out.AllowedAgeCategoryIDs},
+		{"allowed_education_category_ids", req.AllowedEducationCategoryIDs, &out.AllowedEducationCategoryIDs},
+		{"blocked_game_ids", req.BlockedGameIDs, &out.BlockedGameIDs},
+	} {
+		if len(list.in) > maxProfileControlIDs {
+			return repos.ProfileControls{}, utils.ErrBadRequest(fmt.Sprintf("%s can have at most %d ids", list.field, maxProfileControlIDs))
+		}
+		ids := make([]int64, 0, len(list.in))
+		for _, id := range list.in {
+			if id < 1 {
+				return repos.ProfileControls{}, utils.ErrBadRequest(list.field + " must contain integers >= 1")
+			}
+			if !slices.Contains(ids, id) {
+				ids = append(ids, id)
+			}
+		}
+		*list.out = ids
+	}
+
+	if req.DailyMinutes != nil {
+		if *req.DailyMinutes < 0 || *req.DailyMinutes > 24*60 {
+			return repos.ProfileControls{}, utils.ErrBadRequest("daily_minutes must be between 0 and 1440")
+		}
+		out.DailyMinutes = sql.NullInt64{Int64: int64(*req.DailyMinutes), Valid: true}
+	}
+
+	if req.QuietHours != nil {
+		start, okStart := parseMinuteOfDay(req.QuietHours.Start)
+		end, okEnd := parseMinuteOfDay(req.QuietHours.End)
+		if !okStart || !okEnd {
+			return repos.ProfileControls{}, utils.ErrBadRequest("quiet_hours start and end must be HH:MM")
+		}
+		if start == end {
+			return repos.ProfileControls{}, utils.ErrBadRequest("quiet_hours start and end must differ")
+		}
+		out.QuietStart = sql.NullInt64{Int64: int64(start), Valid: true}
+		out.QuietEnd = sql.NullInt64{Int64: int64(end), Valid: true}
+	}
+
+	out.TimeZone = strings.TrimSpace(req.TimeZone)
+	if out.TimeZone == "" {
+		out.TimeZone = "UTC"
+	}
+	if len(out.TimeZone) > maxTimeZoneLen {
+		return repos.ProfileControls{}, utils.ErrBadRequest("time_zone is too long")
+	}
+	if _, err := time.LoadLocation(out.TimeZone); err != nil {
+		return repos.ProfileControls{}, utils.ErrBadRequest("time_zone must be an IANA time zone such as Asia/Jakarta")
+	}
+	return out, nil
+}
+
+// profileLocation falls back to UTC for zones the runtime no longer knows.
+func profileLocation(name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// inQuietHours reports whether minute falls in [start, end), wrapping past
+// midnight when end < start.
+func inQuietHours(start, end, minute int) bool {
+	if start < end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
+
+// nextMinuteOfDay is the next time after local that the clock reads minute.
+func nextMinuteOfDay(local time.Time, minute int) time.Time {
+	t := time.Date(local.Year(), local.Month(), local.Day(), minute/60, minute%60, 0, 0, local.Location())
+	if !t.After(local) {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t
+}
+
+func parseMinuteOfDay(v string) (int, bool) {
+	t, err := time.Parse(quietHoursLayout, strings.TrimSpace(v))
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
+
+func formatMinuteOfDay(minute int) string {
+	return fmt.Sprintf("%02d:%02d", minute/60, minute%60)
+}
@@ -0,0 +1,240 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+const (
+	maxProfilesPerAccount = 8
+	maxProfileNicknameLen = 40
+	maxProfileAgeYears    = 18
+)
+
+// ProfileService manages the child profiles of a parent account and issues
+// player tokens scoped to one of them.
+type ProfileService struct {
+	cfg         config.Config
+	profileRepo *repos.ProfileRepo
+	userRepo    *repos.UserRepo
+}
+
+func NewProfileService(cfg config.Config, profileRepo *repos.ProfileRepo, userRepo *repos.UserRepo) *ProfileService {
+	return &ProfileService{
+		cfg:         cfg,
+		profileRepo: profileRepo,
+		userRepo:    userRepo,
+	}
+}
+
+type ProfileListDTO struct {
+	Items []models.ProfileDTO `json:"items"`
+}
+
+func nullInt64Ptr(v sql.NullInt64) *int64 {
+	if !v.Valid {
+		return nil
+	}
+	n := v.Int64
+	return &n
+}
+
+func toProfileDTO(p repos.Profile) models.ProfileDTO {
+	return models.ProfileDTO{
+		ID:            p.ID,
+		Nickname:      p.Nickname,
+		BirthYear:     nullInt64Ptr(p.BirthYear),
+		AgeCategoryID: nullInt64Ptr(p.AgeCategoryID),
+		AgeLabel:      toNullableString(p.AgeLabel),
+		Avatar:        p.Avatar,
+		CreatedAt:     p.CreatedAt.UTC(),
+		UpdatedAt:     p.UpdatedAt.UTC(),
+	}
+}
+
+func (s *ProfileService) List(ctx context.Context, playerID string) (*ProfileListDTO, error) {
+	playerID = normalizePlayerID(playerID)
+	if playerID == "" {
+		return nil, utils.ErrUnauthorized()
+	}
+
+	rows, err := s.profileRepo.ListByPlayerID(ctx, playerID)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	out := make([]models.ProfileDTO, 0, len(rows))
+	for _, p := range rows {
+		out = append(out, toProfileDTO(p))
+	}
+	return &ProfileListDTO{Items: out}, nil
+}
+
+func (s *ProfileService) Create(ctx context.Context, playerID string, req models.ProfileRequest) (*models.ProfileDTO, error) {
+	playerID = normalizePlayerID(playerID)
+	if playerID == "" {
+		return nil, utils.ErrUnauthorized()
+	}
+	in, err := validateProfileRequest(req, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := s.profileRepo.Create(ctx, playerID, in, maxProfilesPerAccount)
+	if err != nil {
+		switch {
+		case errors.Is(err, repos.ErrNotFound):
+			// The token outlived its player account.
+			return nil, utils.ErrUnauthorized()
+		case errors.Is(err, repos.ErrProfileLimitReached):
+			return nil, utils.ErrBadRequest(fmt.Sprintf("an account can have at most %d profiles", maxProfilesPerAccount))
+		case isFKViolation(err):
+			return nil, utils.ErrBadRequest("age_category_id does not exist")
+		}
+		return nil, utils.ErrInternal()
+	}
+	return s.get(ctx, playerID, id)
+}
+
+func (s *ProfileService) Update(ctx context.Context, playerID string, profileID int64, req models.ProfileRequest) (*models.ProfileDTO, error) {
+	playerID = normalizePlayerID(playerID)
+	if playerID == "" {
+		return nil, utils.ErrUnauthorized()
+	}
+	if profileID < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	in, err := validateProfileRequest(req, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.profileRepo.Update(ctx, playerID, profileID, in); err != nil {
+		switch {
+		case errors.Is(err, repos.ErrNotFound):
+			return nil, utils.ErrNotFound("profile not found")
+		case isFKViolation(err):
+			return nil, utils.ErrBadRequest("age_category_id does not exist")
+		}
+		return nil, utils.ErrInternal()
+	}
+	return s.get(ctx, playerID, profileID)
+}
+
+func (s *ProfileService) Delete(ctx context.Context, playerID string, profileID int64) error {
+	playerID = normalizePlayerID(playerID)
+	if playerID == "" {
+		return utils.ErrUnauthorized()
+	}
+	if profileID < 1 {
+		return utils.ErrBadRequest("id must be an integer >= 1")
+	}
+
+	if err := s.profileRepo.Delete(ctx, playerID, profileID); err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return utils.ErrNotFound("profile not found")
+		}
+		return utils.ErrInternal()
+	}
+	return nil
+}
+
+// Select issues a player token acting as the profile. Tokens already scoped
+// to a profile expire on their own; there is nothing to revoke.
+func (s *ProfileService) Select(ctx context.Context, playerID string, profileID int64) (*models.ProfileSessionResponse, error) {
+	playerID = normalizePlayerID(playerID)
+	if playerID == "" {
+		return nil, utils.ErrUnauthorized()
+	}
+	if profileID < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+
+	profile, err := s.get(ctx, playerID, profileID)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetPlayerByID(ctx, playerID)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrUnauthorized()
+		}
+		return nil, utils.ErrInternal()
+	}
+
+	token, expiresIn, err := utils.GenerateProfileToken(s.cfg.JWT, user, profileID)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	return &models.ProfileSessionResponse{
+		Token:     token,
+		ExpiresIn: expiresIn,
+		Profile:   *profile,
+	}, nil
+}
+
+func (s *ProfileService) get(ctx context.Context, playerID string, profileID int64) (*models.ProfileDTO, error) {
+	p, err := s.profileRepo.GetForPlayer(ctx, playerID, profileID)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("profile not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	dto := toProfileDTO(*p)
+	return &dto, nil
+}
+
+func validateProfileRequest(req models.ProfileRequest, now time.Time) (repos.ProfileInput, error) {
+	nickname := strings.TrimSpace(req.Nickname)
+	if nickname == "" {
+		return repos.ProfileInput{}, utils.ErrBadRequest("nickname is required")
+	}
+	if utf8.RuneCountInString(nickname) > maxProfileNicknameLen {
+		return repos.ProfileInput{}, utils.ErrBadRequest(fmt.Sprintf("nickname must be at most %d characters", maxProfileNicknameLen))
+	}
+
+	if req.BirthYear == nil && req.AgeCategoryID == nil {
+		return repos.ProfileInput{}, utils.ErrBadRequest("birth_year or age_category_id is required")
+	}
+	var birthYear, ageCategoryID sql.NullInt64
+	if req.BirthYear != nil {
+		year := now.Year()
+		if *req.BirthYear < year-maxProfileAgeYears || *req.BirthYear > year {
+			return repos.ProfileInput{}, utils.ErrBadRequest(fmt.Sprintf("birth_year must be between %d and %d", year-maxProfileAgeYears, year))
+		}
+		birthYear = sql.NullInt64{Int64: int64(*req.BirthYear), Valid: true}
+	}
+	if req.AgeCategoryID != nil {
+		if *req.AgeCategoryID < 1 {
+			return repos.ProfileInput{}, utils.ErrBadRequest("age_category_id must be an integer >= 1")
+		}
+		ageCategoryID = sql.NullInt64{Int64: *req.AgeCategoryID, Valid: true}
+	}
+
+	avatar := strings.ToLower(strings.TrimSpace(req.Avatar))
+	if avatar == "" {
+		avatar = models.ProfileAvatars[0]
+	}
+	if !slices.Contains(models.ProfileAvatars, avatar) {
+		return repos.ProfileInput{}, utils.ErrBadRequest("avatar must be one of: " + strings.Join(models.ProfileAvatars, ", "))
+	}
+
+	return repos.ProfileInput{
+		Nickname:      nickname,
+		BirthYear:     birthYear,
+		AgeCategoryID: ageCategoryID,
+		Avatar:        avatar,
+	}, nil
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+type GameReactionCountsDTO struct {
+	Love   int     `json:"love"`
+	Like   int     `json:"like"`
+	Meh    int     `json:"meh"`
+	Total  int     `json:"total"`
+	Rating float64 `json:"rating"`
+}
+
+type GameReactionDTO struct {
+	GameID int64                 `json:"game_id"`
+	Mine   *models.GameReaction  `json:"mine"`
+	Counts GameReactionCountsDTO `json:"counts"`
+}
+
+func toGameReactionCountsDTO(love, like, meh int, rating float64) GameReactionCountsDTO {
+	return GameReactionCountsDTO{
+		Love:   love,
+		Like:   like,
+		Meh:    meh,
+		Total:  love + like + meh,
+		Rating: rating,
+	}
+}
+
+// ReactionService records one reaction per member per game. Only signed-in
+// players react, as their leaderboard member. Guests have no identity that
+// outlives a play session, so each new session would be a fresh vote; they
+// can read the counts but not react.
+type ReactionService struct {
+	reactionRepo *repos.ReactionRepo
+	gameRepo     *repos.GameRepo
+}
+
+func NewReactionService(reactionRepo *repos.ReactionRepo, gameRepo *repos.GameRepo) *ReactionService {
+	return &ReactionService{
+		reactionRepo: reactionRepo,
+		gameRepo:     gameRepo,
+	}
+}
+
+// ReactionActor is who is reacting, taken from the play token.
+type ReactionActor struct {
+	TokenGameID int64
+	PlayerID    string
+	ProfileID   int64
+	Preview     bool
+}
+
+func (s *ReactionService) GetReaction(ctx context.Context, gameID int64, actor ReactionActor) (*GameReactionDTO, error) {
+	member, err := s.authorize(ctx, gameID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if member == "" {
+		counts, err := s.reactionRepo.Counts(ctx, gameID)
+		if err != nil {
+			return nil, utils.ErrInternal()
+		}
+		return toGameReactionDTO(gameID, "", counts), nil
+	}
+
+	mine, counts, err := s.reactionRepo.Get(ctx, gameID, member)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	return toGameReactionDTO(gameID, mine, counts), nil
+}
+
+func (s *ReactionService) SetReaction(ctx context.Context, gameID int64, actor ReactionActor, reaction models.GameReaction) (*GameReactionDTO, error) {
+	reaction = models.GameReaction(strings.ToLower(strings.TrimSpace(string(reaction))))
+	switch reaction {
+	case models.GameReactionLove, models.GameReactionLike, models.GameReactionMeh:
+	default:
+		return nil, utils.ErrBadRequest("reaction must be one of: love, like, meh")
+	}
+
+	member, err := s.authorize(ctx, gameID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if member == "" {
+		return nil, utils.ErrSignInRequired()
+	}
+
+	counts, err := s.reactionRepo.Set(ctx, gameID, member, string(reaction))
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	return toGameReactionDTO(gameID, string(reaction), counts), nil
+}
+
+func (s *ReactionService) ClearReaction(ctx context.Context, gameID int64, actor ReactionActor) (*GameReactionDTO, error) {
+	member, err := s.authorize(ctx, gameID, actor)
+	if err != nil {
+		return nil, err
+	}
+	if member == "" {
+		return nil, utils.ErrSignInRequired()
+	}
+
+	counts, err := s.reactionRepo.Clear(ctx, gameID, member)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	return toGameReactionDTO(gameID, "", counts), nil
+}
+
+// authorize resolves the member and checks the play token is for this active
+// game. Preview sessions never react so drafts cannot collect ratings. The
+// member is empty for guests.
+func (s *ReactionService) authorize(ctx context.Context, gameID int64, actor ReactionActor) (string, error) {
+	if gameID < 1 {
+		return "", utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	if actor.TokenGameID <= 0 {
+		return "", utils.ErrUnauthorized()
+	}
+	if actor.TokenGameID != gameID || actor.Preview {
+		return "", utils.ErrForbidden()
+	}
+
+	game, err := s.gameRepo.GetDeletion(ctx, gameID)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return "", utils.ErrNotFound("game not found")
+		}
+		return "", utils.ErrInternal()
+	}
+	if game.Status != string(models.GameStatusActive) {
+		return "", utils.ErrNotFound("game not found")
+	}
+
+	playerID := normalizePlayerID(actor.PlayerID)
+	if playerID == "" {
+		return "", nil
+	}
+	return PlayerMember(playerID, actor.ProfileID), nil
+}
+
+func toGameReactionDTO(gameID int64, mine string, counts *repos.GameReactionCounts) *GameReactionDTO {
+	dto := &GameReactionDTO{
+		GameID: gameID,
+		Counts: toGameReactionCountsDTO(counts.Love, counts.Like, counts.Meh, counts.Rating),
+	}
+	if mine != "" {
+		r := models.GameReaction(mine)
+		dto.Mine = &r
+	}
+	return dto
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+const defaultRelatedGamesLimit = 6
+
+// RelatedGamesService serves the precomputed game_related rows and rebuilds
+// them on the related-games job.
+type RelatedGamesService struct {
+	gameRepo     *repos.GameRepo
+	windowDays   int
+	perGame      int
+	catalogCache *CatalogCache
+	localizer    *Localizer
+}
+
+func NewRelatedGamesService(gameRepo *repos.GameRepo, relatedCfg config.RelatedGamesConfig, catalogCache *CatalogCache, localizer *Localizer) *RelatedGamesService {
+	return &RelatedGamesService{
+		gameRepo:     gameRepo,
+		windowDays:   relatedCfg.WindowDays,
+		perGame:      relatedCfg.PerGame,
+		catalogCache: catalogCache,
+		localizer:    localizer,
+	}
+}
+
+type RelatedGamesDTO struct {
+	GameID int64             `json:"game_id"`
+	Items  []GameListItemDTO `json:"items"`
+}
+
+// ListRelated returns up to limit related games; limit defaults to 6 and is
+// capped at the number stored per game.
+func (s *RelatedGamesService) ListRelated(ctx context.Context, id int64, limit int, locale string) (*RelatedGamesDTO, error) {
+	if id < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	if limit == 0 {
+		limit = min(defaultRelatedGamesLimit, s.perGame)
+	}
+	if limit < 1 || limit > s.perGame {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("limit must be between 1 and %d", s.perGame))
+	}
+	locale = s.localizer.Resolve(locale)
+
+	cacheKey, cacheable := s.catalogCache.relatedKey(ctx, id, limit, locale)
+	if cacheable {
+		var cached RelatedGamesDTO
+		if s.catalogCache.get(ctx, cacheKey, &cached) {
+			return &cached, nil
+		}
+	}
+
+	game, err := s.gameRepo.GetDeletion(ctx, id)
+	if err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	if game.Status != string(models.GameStatusActive) {
+		return nil, utils.ErrNotFound("game not found")
+	}
+
+	items, err := s.gameRepo.ListRelatedPublic(ctx, id, limit)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	out := make([]GameListItemDTO, 0, len(items))
+	for _, it := range items {
+		out = append(out, toGameListItemDTO(it))
+	}
+	if err := s.localizer.LocalizeGameList(ctx, locale, out); err != nil {
+		return nil, err
+	}
+
+	dto := &RelatedGamesDTO{GameID: id, Items: out}
+	if cacheable {
+		s.catalogCache.set(ctx, cacheKey, dto)
+	}
+	return dto, nil
+}
+
+// Rebuild recomputes game_related. It reports skipped when another replica
+// is already rebuilding.
+func (s *RelatedGamesService) Rebuild(ctx context.Context) (rows int64, skipped bool, err error) {
+	since := time.Now().UTC().AddDate(0, 0, -s.windowDays)
+	rows, err = s.gameRepo.RebuildRelated(ctx, since, s.perGame)
+	if errors.Is(err, repos.ErrRelatedGamesLocked) {
+		return 0, true, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	s.catalogCache.InvalidateRelated(ctx)
+	return rows, false, nil
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"log"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/mail"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+)
+
+// Inline styles only: most mail clients drop <style> blocks.
+var weeklyReportTemplate = template.Must(template.New("weekly_report").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>{{.Subject}}</title></head>
+<body style="margin:0;padding:16px;background:#f6f7fb;font-family:Arial,Helvetica,sans-serif;color:#222">
+<div style="max-width:600px;margin:0 auto;background:#fff;border-radius:8px;padding:24px">
+<h1 style="font-size:20px;margin:0 0 4px">{{.Heading}}</h1>
+<p style="margin:0 0 16px;color:#666">{{.Report.WeekStart}} to {{.Report.WeekEnd}} ({{.Report.TimeZone}}){{if not .Report.Complete}}, so far{{end}}</p>
+{{- if eq .Report.SessionCount 0}}
+<p>No games were played this week.</p>
+{{- else}}
+<table style="width:100%;border-collapse:collapse;margin-bottom:16px">
+<tr><td style="padding:4px 0">Time played</td><td style="padding:4px 0;text-align:right"><b>{{.Report.TotalMinutes}} min</b></td></tr>
+<tr><td style="padding:4px 0">Sessions</td><td style="padding:4px 0;text-align:right"><b>{{.Report.SessionCount}}</b></td></tr>
+<tr><td style="padding:4px 0">Days played</td><td style="padding:4px 0;text-align:right"><b>{{.Report.DaysPlayed}} of 7</b></td></tr>
+<tr><td style="padding:4px 0">Current streak</td><td style="padding:4px 0;text-align:right"><b>{{.Report.StreakDays}} {{if eq .Report.StreakDays 1}}day{{else}}days{{end}}</b></td></tr>
+<tr><td style="padding:4px 0">Longest streak this week</td><td style="padding:4px 0;text-align:right"><b>{{.Report.LongestStreakDays}} {{if eq .Report.LongestStreakDays 1}}day{{else}}days{{end}}</b></td></tr>
+</table>
+<h2 style="font-size:16px;margin:16px 0 8px">Time by subject</h2>
+<table style="width:100%;border-collapse:collapse">
+{{- range .Report.Categories}}
+<tr><td style="padding:4px 0;border-top:1px solid #eee">{{.Name}}</td><td style="padding:4px 0;border-top:1px solid #eee;text-align:right">{{.Minutes}} min</td></tr>
+{{- end}}
+</table>
+<h2 style="font-size:16px;margin:16px 0 8px">Games</h2>
+<table style="width:100%;border-collapse:collapse">
+<tr><th style="text-align:left;padding:4px 0">Game</th><th style="text-align:right;padding:4px 0">Sessions</th><th style="text-align:right;padding:4px 0">Minutes</th><th style="text-align:right;padding:4px 0">Best score</th></tr>
+{{- range .Report.Games}}
+<tr><td style="padding:4px 0;border-top:1px solid #eee">{{.Title}}</td><td style="padding:4px 0;border-top:1px solid #eee;text-align:right">{{.Sessions}}</td><td style="padding:4px 0;border-top:1px solid #eee;text-align:right">{{.Minutes}}</td><td style="padding:4px 0;border-top:1px solid #eee;text-align:right">{{if .BestScore}}{{.BestScore}}{{else}}-{{end}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+<p style="margin:24px 0 0;font-size:12px;color:#888">Time limits, quiet hours and allowed games can be changed in the parent area of Kids Planet.</p>
+</div>
+</body>
+</html>
+`))
+
+// RenderWeeklyReport renders report as an email subject and HTML body.
+func RenderWeeklyReport(report *models.WeeklyReportDTO) (string, string, error) {
+	heading := "Your week on Kids Planet"
+	if report.Profile != nil {
+		heading = report.Profile.Nickname + "'s week on Kids Planet"
+	}
+	subject := fmt.Sprintf("%s (%s to %s)", heading, report.WeekStart, report.WeekEnd)
+
+	var buf bytes.Buffer
+	if err := weeklyReportTemplate.Execute(&buf, struct {
+		Subject string
+		Heading string
+		Report  *models.WeeklyReportDTO
+	}{subject, heading, report}); err != nil {
+		return "", "", fmt.Errorf("render weekly report: %w", err)
+	}
+	return subject, buf.String(), nil
+}
+
+// WeeklyReportMailer mails last week's report to parents: one per child
+// profile that played, or one for the whole account when it has no
+// profiles. Each report is claimed in weekly_report_deliveries before it
+// is sent, so replicas and reruns never mail it twice.
+type WeeklyReportMailer struct {
+	reports    *ReportService
+	reportRepo *repos.ReportRepo
+	sender     mail.Sender
+}
+
+func NewWeeklyReportMailer(reports *ReportService, reportRepo *repos.ReportRepo, sender mail.Sender) *WeeklyReportMailer {
+	return &WeeklyReportMailer{
+		reports:    reports,
+		reportRepo: reportRepo,
+		sender:     sender,
+	}
+}
+
+// SendDue mails every report of last week that is not sent yet and returns
+// how many went out. Reports whose local week has not ended yet wait for a
+// later run.
+func (m *WeeklyReportMailer) SendDue(ctx context.Context) (int, error) {
+	now := time.Now().UTC()
+	week := weekStart(now.AddDate(0, 0, -7), time.UTC)
+
+	// Pad the UTC week by the widest offsets so every local week is covered.
+	targets, err := m.reportRepo.ListWeeklyTargets(ctx, week.Add(-14*time.Hour), week.AddDate(0, 0, 7).Add(14*time.Hour), week)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, t := range targets {
+		ok, err := m.send(ctx, t, week, now)
+		if err != nil {
+			log.Printf("level=warn msg=%q user_id=%d profile_id=%d err=%v", "weekly report skipped", t.UserID, t.ProfileID, err)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+func (m *WeeklyReportMailer) send(ctx context.Context, t repos.ReportTarget, week, now time.Time) (bool, error) {
+	report, err := m.reports.build(ctx, t.PlayerID, t.ProfileID, week, now)
+	if err != nil {
+		return false, err
+	}
+	if !report.Complete || report.SessionCount == 0 {
+		return false, nil
+	}
+	subject, body, err := RenderWeeklyReport(report)
+	if err != nil {
+		return false, err
+	}
+
+	claimed, err := m.reportRepo.ClaimDelivery(ctx, t.UserID, t.ProfileID, week)
+	if err != nil || !claimed {
+		return false, err
+	}
+	msg := mail.Message{
+		To:      t.Email,
+		Subject: subject,
+		HTML:    body,
+		Ref:     fmt.Sprintf("weekly-report/%d/%d/%s", t.UserID, t.ProfileID, week.Format("2006-01-02")),
+	}
+	if err := m.sender.Send(ctx, msg); err != nil {
+		if relErr := m.reportRepo.ReleaseDelivery(ctx, t.UserID, t.ProfileID, week); relErr != nil {
+			log.Printf("level=error msg=%q user_id=%d profile_id=%d err=%v", "weekly report claim not released", t.UserID, t.ProfileID, relErr)
+		}
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,256 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+const (
+	reportStreakLookbackDays = 90
+	reportUncategorizedName  = "Other"
+)
+
+var errReportWeekInFuture = errors.New("report week is in the future")
+
+// ReportService builds weekly play reports for parents from sessions and
+// analytics events. There is no achievements feature yet, so reports carry
+// none.
+type ReportService struct {
+	reportRepo   *repos.ReportRepo
+	profileRepo  *repos.ProfileRepo
+	controlsRepo *repos.ProfileControlsRepo
+}
+
+func NewReportService(reportRepo *repos.ReportRepo, profileRepo *repos.ProfileRepo, controlsRepo *repos.ProfileControlsRepo) *ReportService {
+	return &ReportService{
+		reportRepo:   reportRepo,
+		profileRepo:  profileRepo,
+		controlsRepo: controlsRepo,
+	}
+}
+
+// Weekly reports on the week containing week (YYYY-MM-DD), or on last week
+// when it is empty, for the whole account or for profileID when > 0.
+func (s *ReportService) Weekly(ctx context.Context, playerID string, profileID int64, week string) (*models.WeeklyReportDTO, error) {
+	playerID = normalizePlayerID(playerID)
+	if playerID == "" {
+		return nil, utils.ErrUnauthorized()
+	}
+
+	var day time.Time
+	if week != "" {
+		d, err := time.Parse(time.DateOnly, week)
+		if err != nil {
+			return nil, utils.ErrBadRequest("week must be a date like 2026-01-05")
+		}
+		day = d
+	}
+
+	dto, err := s.build(ctx, playerID, profileID, day, time.Now().UTC())
+	if err != nil {
+		switch {
+		case errors.Is(err, repos.ErrNotFound):
+			return nil, utils.ErrNotFound("profile not found")
+		case errors.Is(err, errReportWeekInFuture):
+			return nil, utils.ErrBadRequest("week must not be in the future")
+		}
+		return nil, utils.ErrInternal()
+	}
+	return dto, nil
+}
+
+// build reports on the Monday-to-Sunday week containing day, local to the
+// profile's time zone (UTC for the whole account). A zero day means the
+// week before now.
+func (s *ReportService) build(ctx context.Context, playerID string, profileID int64, day time.Time, now time.Time) (*models.WeeklyReportDTO, error) {
+	dto := &models.WeeklyReportDTO{
+		TimeZone:    "UTC",
+		GeneratedAt: now,
+	}
+	if profileID > 0 {
+		p, err := s.profileRepo.GetForPlayer(ctx, playerID, profileID)
+		if err != nil {
+			return nil, err
+		}
+		c, err := s.controlsRepo.Get(ctx, profileID)
+		if err != nil {
+			return nil, err
+		}
+		dto.Profile = &models.WeeklyReportProfile{ID: p.ID, Nickname: p.Nickname, Avatar: p.Avatar}
+		dto.TimeZone = profileLocation(c.TimeZone).String()
+	}
+	loc := profileLocation(dto.TimeZone)
+
+	if day.IsZero() {
+		day = now.In(loc).AddDate(0, 0, -7)
+	}
+	start := weekStart(day, loc)
+	end := start.AddDate(0, 0, 7)
+	if start.After(now) {
+		return nil, errReportWeekInFuture
+	}
+	dto.Complete = !end.After(now)
+	dto.WeekStart = start.Format(time.DateOnly)
+	dto.WeekEnd = end.AddDate(0, 0, -1).Format(time.DateOnly)
+
+	sessions, err := s.reportRepo.ListSessions(ctx, playerID, profileID, start.UTC(), end.UTC(), int(playTokenTTL.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	summarizeReportSessions(dto, sessions)
+
+	// The streak runs up to the week's last day, or today while the week is
+	// still going.
+	last := end.AddDate(0, 0, -1)
+	if !dto.Complete {
+		local := now.In(loc)
+		last = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	}
+	days, err := s.reportRepo.ListPlayDays(ctx, playerID, profileID,
+		last.AddDate(0, 0, -reportStreakLookbackDays).UTC(), last.AddDate(0, 0, 1).UTC(), dto.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	played := make(map[string]bool, len(days))
+	for _, d := range days {
+		played[d.Format(time.DateOnly)] = true
+	}
+	dto.DaysPlayed, dto.LongestStreakDays = weekPlayDays(played, start)
+	dto.StreakDays = streakEndingAt(played, last, !dto.Complete)
+	return dto, nil
+}
+
+type reportCategory struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// summarizeReportSessions fills the totals, categories and games of dto.
+func summarizeReportSessions(dto *models.WeeklyReportDTO, sessions []repos.ReportSession) {
+	type gameTotal struct {
+		dto     models.WeeklyReportGame
+		seconds int64
+	}
+	type categoryTotal struct {
+		id      int64
+		name    string
+		seconds int64
+	}
+
+	var totalSeconds int64
+	games := make(map[int64]*gameTotal)
+	categories := make(map[int64]*categoryTotal)
+	for _, sess := range sessions {
+		totalSeconds += sess.PlayedSeconds
+
+		g, ok := games[sess.GameID]
+		if !ok {
+			g = &gameTotal{dto: models.WeeklyReportGame{GameID: sess.GameID, Title: sess.GameTitle}}
+			games[sess.GameID] = g
+		}
+		g.dto.Sessions++
+		g.seconds += sess.PlayedSeconds
+		if sess.BestScore.Valid && (g.dto.BestScore == nil || sess.BestScore.Int64 > *g.dto.BestScore) {
+			score := sess.BestScore.Int64
+			g.dto.BestScore = &score
+		}
+
+		var cats []reportCategory
+		_ = json.Unmarshal(sess.EducationCategoriesJSON, &cats)
+		if len(cats) == 0 {
+			cats = append(cats, reportCategory{Name: reportUncategorizedName})
+		}
+		for _, cat := range cats {
+			c, ok := categories[cat.ID]
+			if !ok {
+				c = &categoryTotal{id: cat.ID, name: cat.Name}
+				categories[cat.ID] = c
+			}
+			c.seconds += sess.PlayedSeconds
+		}
+	}
+
+	dto.SessionCount = len(sessions)
+	dto.TotalMinutes = secondsToMinutes(totalSeconds)
+
+	gameList := make([]*gameTotal, 0, len(games))
+	for _, g := range games {
+		gameList = append(gameList, g)
+	}
+	slices.SortFunc(gameList, func(a, b *gameTotal) int {
+		return cmp.Or(cmp.Compare(b.seconds, a.seconds), cmp.Compare(a.dto.Title, b.dto.Title), cmp.Compare(a.dto.GameID, b.dto.GameID))
+	})
+	dto.Games = make([]models.WeeklyReportGame, 0, len(gameList))
+	for _, g := range gameList {
+		g.dto.Minutes = secondsToMinutes(g.seconds)
+		dto.Games = append(dto.Games, g.dto)
+	}
+
+	categoryList := make([]*categoryTotal, 0, len(categories))
+	for _, c := range categories {
+		categoryList = append(categoryList, c)
+	}
+	slices.SortFunc(categoryList, func(a, b *categoryTotal) int {
+		return cmp.Or(cmp.Compare(b.seconds, a.seconds), cmp.Compare(a.name, b.name))
+	})
+	dto.Categories = make([]models.WeeklyReportCategory, 0, len(categoryList))
+	for _, c := range categoryList {
+		item := models.WeeklyReportCategory{Name: c.name, Minutes: secondsToMinutes(c.seconds)}
+		if c.id > 0 {
+			id := c.id
+			item.ID = &id
+		}
+		dto.Categories = append(dto.Categories, item)
+	}
+}
+
+// weekStart is local midnight of the Monday on or before day's date.
+func weekStart(day time.Time, loc *time.Location) time.Time {
+	d := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc)
+	offset := (int(d.Weekday()) + 6) % 7
+	return d.AddDate(0, 0, -offset)
+}
+
+// weekPlayDays counts the days of the week starting at start that were
+// played, and the longest run of consecutive ones.
+func weekPlayDays(played map[string]bool, start time.Time) (days, longest int) {
+	run := 0
+	for i := 0; i < 7; i++ {
+		if !played[start.AddDate(0, 0, i).Format(time.DateOnly)] {
+			run = 0
+			continue
+		}
+		days++
+		run++
+		longest = max(longest, run)
+	}
+	return days, longest
+}
+
+// streakEndingAt counts consecutive played days back from last. With
+// inProgress, an unplayed last day does not break the streak yet.
+func streakEndingAt(played map[string]bool, last time.Time, inProgress bool) int {
+	d := last
+	if inProgress && !played[d.Format(time.DateOnly)] {
+		d = d.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for played[d.Format(time.DateOnly)] {
+		streak++
+		d = d.AddDate(0, 0, -1)
+	}
+	return streak
+}
+
+// secondsToMinutes rounds to the nearest minute.
+func secondsToMinutes(secs int64) int {
+	return int((secs + 30) / 60)
+}
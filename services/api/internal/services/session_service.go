@@ -2,11 +2,12 @@ package services
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/google/uuid"
 
 	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
 	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
@@ -14,19 +15,30 @@ import (
 	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
 )
 
+// playTokenTTL is the longest a play token lives; it also caps how long a
+// single session counts toward a profile's daily budget.
+const playTokenTTL = 2 * time.Hour
+
+// maxGuestIDLen bounds the portal's guest id, a UUID in practice.
+const maxGuestIDLen = 64
+
 type SessionService struct {
-	cfg      config.Config
-	gameRepo *repos.GameRepo
-	sessRepo *repos.SessionRepo
-	ttl      time.Duration
+	cfg         config.Config
+	gameRepo    *repos.GameRepo
+	sessRepo    *repos.SessionRepo
+	profileRepo *repos.ProfileRepo
+	controls    *ProfileControlService
+	ttl         time.Duration
 }
 
-func NewSessionService(cfg config.Config, gameRepo *repos.GameRepo, sessRepo *repos.SessionRepo) *SessionService {
+func NewSessionService(cfg config.Config, gameRepo *repos.GameRepo, sessRepo *repos.SessionRepo, profileRepo *repos.ProfileRepo, controls *ProfileControlService) *SessionService {
 	return &SessionService{
-		cfg:      cfg,
-		gameRepo: gameRepo,
-		sessRepo: sessRepo,
-		ttl:      2 * time.Hour,
+		cfg:         cfg,
+		gameRepo:    gameRepo,
+		sessRepo:    sessRepo,
+		profileRepo: profileRepo,
+		controls:    controls,
+		ttl:         playTokenTTL,
 	}
 }
 
@@ -34,14 +46,51 @@ type PlayTokenClaims struct {
 	GameID    int64  `json:"game_id"`
 	SessionID string `json:"session_id"`
 	Typ       string `json:"typ"`
+	Preview   bool   `json:"preview,omitempty"`
+	ProfileID int64  `json:"profile_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func (s *SessionService) StartSession(ctx context.Context, gameID int64, sub string) (*models.StartSessionResponse, *utils.AppError) {
+// StartSession issues a play token. With a valid preview token for the same
+// game, draft games are playable too and the session is marked as preview.
+// A player token scoped to a child profile carries the profile into the
+// play token, subject to the profile's parental controls; the token then
+// expires no later than the profile's play is allowed to last.
+func (s *SessionService) StartSession(ctx context.Context, gameID int64, sub string, profileID int64, previewToken string, guestID string) (*models.StartSessionResponse, *utils.AppError) {
 	if gameID <= 0 {
 		e := utils.ErrBadRequest("game_id must be a positive integer")
 		return nil, &e
 	}
+	if len(guestID) > maxGuestIDLen {
+		e := utils.ErrBadRequest("guest_id is too long")
+		return nil, &e
+	}
+
+	if profileID > 0 {
+		// The profile may have been deleted since the token was issued.
+		if _, err := s.profileRepo.GetForPlayer(ctx, sub, profileID); err != nil {
+			if errors.Is(err, repos.ErrNotFound) {
+				e := utils.ErrUnauthorized()
+				return nil, &e
+			}
+			e := utils.ErrInternal()
+			return nil, &e
+		}
+	}
+
+	var preview *utils.ParsedPreviewToken
+	if previewToken != "" {
+		parsed, err := utils.ParsePreviewToken(s.cfg.JWT, previewToken)
+		if err != nil {
+			e := utils.ErrUnauthorized()
+			return nil, &e
+		}
+		if parsed.GameID != gameID {
+			e := utils.ErrForbidden()
+			return nil, &e
+		}
+		preview = &parsed
+	}
 
 	g, err := s.gameRepo.GetByID(ctx, gameID)
 	if err != nil {
@@ -53,23 +102,57 @@ func (s *SessionService) StartSession(ctx context.Context, gameID int64, sub str
 		return nil, &e
 	}
 
-	if g.Status != "active" {
+	switch {
+	case g.Status == "archived":
+		e := utils.ErrBadRequest("game is not active")
+		return nil, &e
+	case g.Status != "active" && preview == nil:
 		e := utils.ErrBadRequest("game is not active")
 		return nil, &e
 	}
 
 	now := time.Now().UTC()
-	if _, err := s.sessRepo.Create(ctx, gameID, now); err != nil {
+	var playUntil time.Time
+	if profileID > 0 {
+		until, err := s.controls.Allow(ctx, profileID, g, now)
+		if err != nil {
+			if appErr, ok := err.(utils.AppError); ok {
+				return nil, &appErr
+			}
+			e := utils.ErrInternal()
+			return nil, &e
+		}
+		playUntil = until
+	}
+
+	exp := now.Add(s.ttl)
+	if preview != nil && preview.Exp.Before(exp) {
+		exp = preview.Exp.UTC()
+	}
+	if !playUntil.IsZero() && playUntil.Before(exp) {
+		exp = playUntil.UTC()
+	}
+
+	// The session keeps its expiry so play budgets count it until then.
+	sessProfileID := sql.NullInt64{Int64: profileID, Valid: profileID > 0}
+	// A player's sessions are tied together by the token, so the guest id
+	// is only kept for guests; related games count co-plays by it.
+	sessGuestID := sql.NullString{String: guestID, Valid: guestID != "" && sub == ""}
+	sessID, err := s.sessRepo.Create(ctx, gameID, now, exp, preview != nil, sessProfileID, sessGuestID)
+	if err != nil {
 		e := utils.ErrInternal()
 		return nil, &e
 	}
-	exp := now.Add(s.ttl)
-	sessionID := uuid.NewString()
+	// The token's session_id is the sessions row, so analytics events and
+	// score submissions reference it.
+	sessionID := strconv.FormatInt(sessID, 10)
 
 	claims := PlayTokenClaims{
 		GameID:    gameID,
 		SessionID: sessionID,
 		Typ:       "play",
+		Preview:   preview != nil,
+		ProfileID: profileID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Issuer:    s.cfg.JWT.Issuer,
 			Subject:   sub,
@@ -88,5 +171,6 @@ func (s *SessionService) StartSession(ctx context.Context, gameID int64, sub str
 	return &models.StartSessionResponse{
 		PlayToken: tokenStr,
 		ExpiresAt: exp,
+		Preview:   preview != nil,
 	}, nil
 }
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/storage"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+const (
+	StorageGCReasonOldBuild      = "old_build"
+	StorageGCReasonLegacyCurrent = "legacy_current_file"
+	StorageGCReasonOldUpload     = "old_upload_archive"
+	StorageGCReasonStaleStaging  = "stale_staging"
+	StorageGCReasonUnknownGame   = "unknown_game"
+)
+
+type StorageGCService struct {
+	gameRepo    *repos.GameRepo
+	buildRepo   *repos.GameBuildRepo
+	store       storage.ObjectStore
+	keepUploads int
+	minAge      time.Duration
+}
+
+func NewStorageGCService(gameRepo *repos.GameRepo, buildRepo *repos.GameBuildRepo, store storage.ObjectStore, gcCfg config.StorageGCConfig) *StorageGCService {
+	return &StorageGCService{
+		gameRepo:    gameRepo,
+		buildRepo:   buildRepo,
+		store:       store,
+		keepUploads: gcCfg.KeepUploads,
+		minAge:      gcCfg.MinAge,
+	}
+}
+
+type StorageGCInput struct {
+	DryRun      bool
+	KeepUploads int
+}
+
+type StorageGCObjectDTO struct {
+	Key    string `json:"key"`
+	Size   int64  `json:"size"`
+	Reason string `json:"reason"`
+}
+
+type StorageGCReportDTO struct {
+	DryRun         bool                 `json:"dry_run"`
+	KeepUploads    int                  `json:"keep_uploads"`
+	GamesScanned   int                  `json:"games_scanned"`
+	ObjectsScanned int                  `json:"objects_scanned"`
+	DeletedCount   int                  `json:"deleted_count"`
+	DeletedBytes   int64                `json:"deleted_bytes"`
+	Objects        []StorageGCObjectDTO `json:"objects"`
+	StartedAt      string               `json:"started_at"`
+	FinishedAt     string               `json:"finished_at"`
+}
+
+func (s *StorageGCService) Run(ctx context.Context, in StorageGCInput) (*StorageGCReportDTO, error) {
+	if s.store == nil {
+		return nil, utils.ErrInternal()
+	}
+
+	keepUploads := in.KeepUploads
+	if keepUploads == 0 {
+		keepUploads = s.keepUploads
+	}
+	if keepUploads < 1 || keepUploads > 100 {
+		return nil, utils.ErrBadRequest("keep_uploads must be between 1 and 100")
+	}
+
+	release, locked, err := s.buildRepo.TryLockStorageGC(ctx)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	if !locked {
+		return nil, utils.ErrConflict("storage gc is already running")
+	}
+	defer release()
+
+	started := time.Now().UTC()
+	cutoff := started.Add(-s.minAge)
+
+	report := &StorageGCReportDTO{
+		DryRun:      in.DryRun,
+		KeepUploads: keepUploads,
+		Objects:     make([]StorageGCObjectDTO, 0),
+		StartedAt:   started.Format(time.RFC3339),
+	}
+
+	ids, err := s.gameRepo.ListIDs(ctx)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	known := make(map[int64]struct{}, len(ids))
+	for _, id := range ids {
+		known[id] = struct{}{}
+	}
+
+	prefixes, err := s.store.List(ctx, "", false)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+
+	for _, p := range prefixes {
+		gameID, ok := parseGamePrefix(p.Key)
+		if !ok {
+			continue
+		}
+
+		objects, err := s.store.List(ctx, fmt.Sprintf("%d/", gameID), true)
+		if err != nil {
+			return nil, utils.ErrInternal()
+		}
+		report.GamesScanned++
+		report.ObjectsScanned += len(objects)
+
+		var candidates []StorageGCObjectDTO
+		if _, ok := known[gameID]; ok {
+			refs, err := s.loadGameRefs(ctx, gameID)
+			if err != nil {
+				return nil, utils.ErrInternal()
+			}
+			candidates = planGameGarbage(gameID, objects, refs, keepUploads, cutoff)
+		} else {
+			for _, obj := range objects {
+				if obj.LastModified.After(cutoff) {
+					continue
+				}
+				candidates = append(candidates, StorageGCObjectDTO{Key: obj.Key, Size: obj.Size, Reason: StorageGCReasonUnknownGame})
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		if !in.DryRun {
+			keys := make([]string, 0, len(candidates))
+			for _, c := range candidates {
+				keys = append(keys, c.Key)
+			}
+			if err := s.store.Delete(ctx, keys); err != nil {
+				return nil, utils.ErrInternal()
+			}
+		}
+
+		for _, c := range candidates {
+			report.DeletedCount++
+			report.DeletedBytes += c.Size
+		}
+		report.Objects = append(report.Objects, candidates...)
+	}
+
+	report.FinishedAt = time.Now().UTC().Format(time.RFC3339)
+	return report, nil
+}
+
+// gameGCRefs is what the database says must survive for one game.
+type gameGCRefs struct {
+	hasCurrent bool
+	liveBuilds map[int64]struct{}
+	uploads    map[string]struct{}
+}
+
+func (s *StorageGCService) loadGameRefs(ctx context.Context, gameID int64) (gameGCRefs, error) {
+	refs := gameGCRefs{
+		liveBuilds: make(map[int64]struct{}),
+		uploads:    make(map[string]struct{}),
+	}
+
+	if _, err := s.buildRepo.GetCurrent(ctx, gameID); err == nil {
+		refs.hasCurrent = true
+	} else if !errors.Is(err, repos.ErrNotFound) {
+		return refs, err
+	}
+
+	liveIDs, err := s.buildRepo.ListLiveIDs(ctx, gameID)
+	if err != nil {
+		return refs, err
+	}
+	for _, id := range liveIDs {
+		refs.liveBuilds[id] = struct{}{}
+	}
+
+	uploadKeys, err := s.buildRepo.ListUploadObjectKeys(ctx, gameID)
+	if err != nil {
+		return refs, err
+	}
+	for _, k := range uploadKeys {
+		refs.uploads[k] = struct{}{}
+	}
+	return refs, nil
+}
+
+// planGameGarbage picks the objects of one game that can go. Nothing newer
+// than cutoff is picked.
+func planGameGarbage(
+	gameID int64,
+	objects []storage.ObjectInfo,
+	refs gameGCRefs,
+	keepUploads int,
+	cutoff time.Time,
+) []StorageGCObjectDTO {
+	buildsPrefix := fmt.Sprintf("%d/builds/", gameID)
+	currentPrefix := fmt.Sprintf("%d/current/", gameID)
+	stagingPrefix := fmt.Sprintf("%d/staging/", gameID)
+	uploadPrefix := fmt.Sprintf("%d/upload/", gameID)
+
+	out := make([]StorageGCObjectDTO, 0)
+	uploads := make([]storage.ObjectInfo, 0)
+
+	for _, obj := range objects {
+		switch {
+		case strings.HasPrefix(obj.Key, buildsPrefix):
+			if obj.LastModified.After(cutoff) {
+				continue
+			}
+			idStr, _, _ := strings.Cut(strings.TrimPrefix(obj.Key, buildsPrefix), "/")
+			if id, err := strconv.ParseInt(idStr, 10, 64); err == nil {
+				if _, ok := refs.liveBuilds[id]; ok {
+					continue
+				}
+			}
+			out = append(out, StorageGCObjectDTO{Key: obj.Key, Size: obj.Size, Reason: StorageGCReasonOldBuild})
+		case strings.HasPrefix(obj.Key, currentPrefix):
+			// Games uploaded before builds got their own prefix are still
+			// served from current/ until a build becomes current.
+			if !refs.hasCurrent || obj.LastModified.After(cutoff) {
+				continue
+			}
+			out = append(out, StorageGCObjectDTO{Key: obj.Key, Size: obj.Size, Reason: StorageGCReasonLegacyCurrent})
+		case strings.HasPrefix(obj.Key, stagingPrefix):
+			if obj.LastModified.After(cutoff) {
+				continue
+			}
+			out = append(out, StorageGCObjectDTO{Key: obj.Key, Size: obj.Size, Reason: StorageGCReasonStaleStaging})
+		case strings.HasPrefix(obj.Key, uploadPrefix) && strings.HasSuffix(obj.Key, ".zip"):
+			uploads = append(uploads, obj)
+		}
+	}
+
+	sort.Slice(uploads, func(i, j int) bool {
+		if !uploads[i].LastModified.Equal(uploads[j].LastModified) {
+			return uploads[i].LastModified.After(uploads[j].LastModified)
+		}
+		return uploads[i].Key > uploads[j].Key
+	})
+	for i, obj := range uploads {
+		if i < keepUploads || obj.LastModified.After(cutoff) {
+			continue
+		}
+		if _, ok := refs.uploads[obj.Key]; ok {
+			continue
+		}
+		out = append(out, StorageGCObjectDTO{Key: obj.Key, Size: obj.Size, Reason: StorageGCReasonOldUpload})
+	}
+
+	return out
+}
+
+func parseGamePrefix(key string) (int64, bool) {
+	key = strings.TrimSuffix(key, "/")
+	if key == "" || strings.Contains(key, "/") {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(key, 10, 64)
+	if err != nil || id < 1 {
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/storage"
+)
+
+func gcFixture(t *testing.T) *storage.MemoryStore {
+	t.Helper()
+	store := storage.NewMemoryStore()
+	for _, key := range []string{
+		"7/builds/3/index.html",
+		"7/builds/3/js/app.js",
+		"7/builds/4/index.html",
+		"7/builds/5/index.html",
+		"7/builds/x/index.html",
+		"7/current/index.html",
+		"7/staging/ab12/index.html",
+		"7/media/thumb.webp",
+		// Put in key order so the newest upload sorts first either way.
+		"7/upload/20260101_000000_a.zip",
+		"7/upload/20260102_000000_b.zip",
+		"7/upload/20260103_000000_c.zip",
+	} {
+		mustPutObject(t, store, key, "x")
+	}
+	return store
+}
+
+func gcKeys(objects []StorageGCObjectDTO) map[string]string {
+	out := make(map[string]string, len(objects))
+	for _, obj := range objects {
+		out[obj.Key] = obj.Reason
+	}
+	return out
+}
+
+func TestPlanGameGarbage(t *testing.T) {
+	store := gcFixture(t)
+	objects, err := store.List(context.Background(), "7/", true)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	refs := gameGCRefs{
+		hasCurrent: true,
+		// Build 4 is current and build 5 is still uploading.
+		liveBuilds: map[int64]struct{}{4: {}, 5: {}},
+		uploads:    map[string]struct{}{"7/upload/20260101_000000_a.zip": {}},
+	}
+	cutoff := time.Now().Add(time.Hour)
+
+	got := gcKeys(planGameGarbage(7, objects, refs, 1, cutoff))
+	want := map[string]string{
+		"7/builds/3/index.html":          StorageGCReasonOldBuild,
+		"7/builds/3/js/app.js":           StorageGCReasonOldBuild,
+		"7/builds/x/index.html":          StorageGCReasonOldBuild,
+		"7/current/index.html":           StorageGCReasonLegacyCurrent,
+		"7/staging/ab12/index.html":      StorageGCReasonStaleStaging,
+		"7/upload/20260102_000000_b.zip": StorageGCReasonOldUpload,
+	}
+	if len(got) != len(want) {
+		t.Fatalf("plan = %v, want %v", got, want)
+	}
+	for key, reason := range want {
+		if got[key] != reason {
+			t.Fatalf("plan[%q] = %q, want %q (plan %v)", key, got[key], reason, got)
+		}
+	}
+
+	keys := make([]string, 0, len(got))
+	for key := range got {
+		keys = append(keys, key)
+	}
+	if err := store.Delete(context.Background(), keys); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	left := []string{
+		"7/builds/4/index.html",
+		"7/builds/5/index.html",
+		"7/media/thumb.webp",
+		"7/upload/20260101_000000_a.zip",
+		"7/upload/20260103_000000_c.zip",
+	}
+	if got := storeKeys(t, store, "7/"); !slices.Equal(got, left) {
+		t.Fatalf("after GC = %v, want %v", got, left)
+	}
+}
+
+func TestPlanGameGarbageWithoutCurrentBuild(t *testing.T) {
+	store := gcFixture(t)
+	objects, err := store.List(context.Background(), "7/", true)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	// Legacy games are served from current/ until a build becomes current.
+	refs := gameGCRefs{
+		liveBuilds: map[int64]struct{}{3: {}, 4: {}, 5: {}},
+		uploads:    map[string]struct{}{},
+	}
+	got := gcKeys(planGameGarbage(7, objects, refs, 5, time.Now().Add(time.Hour)))
+	if _, ok := got["7/current/index.html"]; ok {
+		t.Fatalf("legacy current/ planned for deletion: %v", got)
+	}
+	for _, key := range []string{"7/builds/3/index.html", "7/builds/4/index.html", "7/builds/5/index.html"} {
+		if _, ok := got[key]; ok {
+			t.Fatalf("live build object %q planned for deletion", key)
+		}
+	}
+}
+
+func TestPlanGameGarbageKeepsYoungObjects(t *testing.T) {
+	store := gcFixture(t)
+	objects, err := store.List(context.Background(), "7/", true)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	refs := gameGCRefs{
+		hasCurrent: true,
+		liveBuilds: map[int64]struct{}{4: {}},
+		uploads:    map[string]struct{}{},
+	}
+	if got := planGameGarbage(7, objects, refs, 1, time.Now().Add(-time.Hour)); len(got) != 0 {
+		t.Fatalf("objects younger than the cutoff planned: %v", got)
+	}
+}
+
+func TestParseGamePrefix(t *testing.T) {
+	tests := []struct {
+		key  string
+		id   int64
+		want bool
+	}{
+		{"7/", 7, true},
+		{"12", 12, true},
+		{"0/", 0, false},
+		{"-1/", 0, false},
+		{"abc/", 0, false},
+		{"7/builds/", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		id, ok := parseGamePrefix(tt.key)
+		if ok != tt.want || id != tt.id {
+			t.Fatalf("parseGamePrefix(%q) = %d, %v; want %d, %v", tt.key, id, ok, tt.id, tt.want)
+		}
+	}
+}
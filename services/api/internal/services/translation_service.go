@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/models"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/repos"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/utils"
+)
+
+// TranslationService manages per-locale overrides of game and category
+// text. The default locale lives on the base rows and is edited through the
+// regular game and category endpoints.
+type TranslationService struct {
+	translationRepo *repos.TranslationRepo
+	gameRepo        *repos.GameRepo
+	localeCfg       config.LocaleConfig
+	catalogCache    *CatalogCache
+}
+
+func NewTranslationService(translationRepo *repos.TranslationRepo, gameRepo *repos.GameRepo, localeCfg config.LocaleConfig, catalogCache *CatalogCache) *TranslationService {
+	return &TranslationService{
+		translationRepo: translationRepo,
+		gameRepo:        gameRepo,
+		localeCfg:       localeCfg,
+		catalogCache:    catalogCache,
+	}
+}
+
+type GameTranslationListDTO struct {
+	DefaultLocale string                      `json:"default_locale"`
+	Items         []models.GameTranslationDTO `json:"items"`
+}
+
+type CategoryTranslationListDTO struct {
+	DefaultLocale string                          `json:"default_locale"`
+	Items         []models.CategoryTranslationDTO `json:"items"`
+}
+
+// categoryTextLimits mirrors the base column sizes.
+var categoryTextLimits = map[repos.CategoryKind]int{
+	repos.CategoryKindAge:       50,
+	repos.CategoryKindEducation: 100,
+}
+
+func (s *TranslationService) translationLocale(raw string) (string, error) {
+	locale := strings.ToLower(strings.TrimSpace(raw))
+	if !s.localeCfg.IsSupported(locale) {
+		return "", utils.ErrBadRequest("locale must be one of: " + strings.Join(s.localeCfg.Supported, ", "))
+	}
+	if locale == s.localeCfg.Default {
+		return "", utils.ErrBadRequest("the default locale is edited on the base record")
+	}
+	return locale, nil
+}
+
+func (s *TranslationService) requireGame(ctx context.Context, gameID int64) error {
+	if gameID < 1 {
+		return utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	if _, err := s.gameRepo.GetByID(ctx, gameID); err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return utils.ErrNotFound("game not found")
+		}
+		return utils.ErrInternal()
+	}
+	return nil
+}
+
+func toGameTranslationDTO(t repos.GameTranslation) models.GameTranslationDTO {
+	return models.GameTranslationDTO{
+		GameID:      t.GameID,
+		Locale:      t.Locale,
+		Title:       t.Title,
+		Description: toNullableString(t.Description),
+		UpdatedAt:   t.UpdatedAt.UTC(),
+	}
+}
+
+func toCategoryTranslationDTO(t repos.CategoryTranslation) models.CategoryTranslationDTO {
+	return models.CategoryTranslationDTO{
+		CategoryID: t.CategoryID,
+		Locale:     t.Locale,
+		Text:       t.Text,
+		UpdatedAt:  t.UpdatedAt.UTC(),
+	}
+}
+
+func (s *TranslationService) ListGameTranslations(ctx context.Context, gameID int64) (*GameTranslationListDTO, error) {
+	if err := s.requireGame(ctx, gameID); err != nil {
+		return nil, err
+	}
+	items, err := s.translationRepo.ListGameTranslations(ctx, gameID)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	out := make([]models.GameTranslationDTO, 0, len(items))
+	for _, it := range items {
+		out = append(out, toGameTranslationDTO(it))
+	}
+	return &GameTranslationListDTO{DefaultLocale: s.localeCfg.Default, Items: out}, nil
+}
+
+func (s *TranslationService) UpsertGameTranslation(ctx context.Context, gameID int64, rawLocale string, req models.UpsertGameTranslationRequest) (*models.GameTranslationDTO, error) {
+	locale, err := s.translationLocale(rawLocale)
+	if err != nil {
+		return nil, err
+	}
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		return nil, utils.ErrBadRequest("title is required")
+	}
+	if len(title) > 150 {
+		return nil, utils.ErrBadRequest("title must be <= 150 chars")
+	}
+	description, err := normalizeDescription(req.Description)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireGame(ctx, gameID); err != nil {
+		return nil, err
+	}
+
+	t, err := s.translationRepo.UpsertGameTranslation(ctx, repos.GameTranslation{
+		GameID:      gameID,
+		Locale:      locale,
+		Title:       title,
+		Description: description,
+	})
+	if err != nil {
+		if isFKViolation(err) {
+			return nil, utils.ErrNotFound("game not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	s.catalogCache.InvalidateGame(ctx, gameID)
+
+	dto := toGameTranslationDTO(*t)
+	return &dto, nil
+}
+
+func (s *TranslationService) DeleteGameTranslation(ctx context.Context, gameID int64, rawLocale string) error {
+	locale, err := s.translationLocale(rawLocale)
+	if err != nil {
+		return err
+	}
+	if err := s.translationRepo.DeleteGameTranslation(ctx, gameID, locale); err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return utils.ErrNotFound("translation not found")
+		}
+		return utils.ErrInternal()
+	}
+	s.catalogCache.InvalidateGame(ctx, gameID)
+	return nil
+}
+
+func (s *TranslationService) ListCategoryTranslations(ctx context.Context, kind repos.CategoryKind, categoryID int64) (*CategoryTranslationListDTO, error) {
+	if categoryID < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	items, err := s.translationRepo.ListCategoryTranslations(ctx, kind, categoryID)
+	if err != nil {
+		return nil, utils.ErrInternal()
+	}
+	out := make([]models.CategoryTranslationDTO, 0, len(items))
+	for _, it := range items {
+		out = append(out, toCategoryTranslationDTO(it))
+	}
+	return &CategoryTranslationListDTO{DefaultLocale: s.localeCfg.Default, Items: out}, nil
+}
+
+func (s *TranslationService) UpsertCategoryTranslation(ctx context.Context, kind repos.CategoryKind, categoryID int64, rawLocale string, req models.UpsertCategoryTranslationRequest) (*models.CategoryTranslationDTO, error) {
+	if categoryID < 1 {
+		return nil, utils.ErrBadRequest("id must be an integer >= 1")
+	}
+	locale, err := s.translationLocale(rawLocale)
+	if err != nil {
+		return nil, err
+	}
+	maxLen, ok := categoryTextLimits[kind]
+	if !ok {
+		return nil, utils.ErrInternal()
+	}
+	text := strings.TrimSpace(req.Text)
+	if text == "" {
+		return nil, utils.ErrBadRequest("text is required")
+	}
+	if utf8.RuneCountInString(text) > maxLen {
+		return nil, utils.ErrBadRequest(fmt.Sprintf("text is too long (max %d)", maxLen))
+	}
+
+	t, err := s.translationRepo.UpsertCategoryTranslation(ctx, kind, repos.CategoryTranslation{
+		CategoryID: categoryID,
+		Locale:     locale,
+		Text:       text,
+	})
+	if err != nil {
+		if isFKViolation(err) {
+			return nil, utils.ErrNotFound(string(kind) + " category not found")
+		}
+		return nil, utils.ErrInternal()
+	}
+	s.catalogCache.InvalidateCategories(ctx)
+
+	dto := toCategoryTranslationDTO(*t)
+	return &dto, nil
+}
+
+func (s *TranslationService) DeleteCategoryTranslation(ctx context.Context, kind repos.CategoryKind, categoryID int64, rawLocale string) error {
+	locale, err := s.translationLocale(rawLocale)
+	if err != nil {
+		return err
+	}
+	if err := s.translationRepo.DeleteCategoryTranslation(ctx, kind, categoryID, locale); err != nil {
+		if errors.Is(err, repos.ErrNotFound) {
+			return utils.ErrNotFound("translation not found")
+		}
+		return utils.ErrInternal()
+	}
+	s.catalogCache.InvalidateCategories(ctx)
+	return nil
+}
@@ -0,0 +1,286 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// localTmpDir lives inside the root so renames stay on one filesystem.
+// localMetaDir mirrors the object tree with one file per object holding its
+// content type. Both are hidden from listings and cannot be addressed as keys.
+const (
+	localTmpDir  = ".tmp"
+	localMetaDir = ".meta"
+)
+
+type LocalStore struct {
+	root string
+}
+
+func NewLocalStore(root string) (*LocalStore, error) {
+	root = strings.TrimSpace(root)
+	if root == "" {
+		return nil, fmt.Errorf("local storage dir is empty")
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("local storage dir: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Join(abs, localTmpDir), 0o755); err != nil {
+		return nil, fmt.Errorf("local storage mkdir: %w", err)
+	}
+	return &LocalStore{root: abs}, nil
+}
+
+func (s *LocalStore) Backend() string {
+	return "local"
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	full, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	// The type goes first so a new object is never seen with a stale one.
+	ct := strings.TrimSpace(contentType)
+	if ct == "" {
+		ct = "application/octet-stream"
+	}
+	if err := s.writeFile(s.metaPath(key), strings.NewReader(ct), -1); err != nil {
+		return "", err
+	}
+	if err := s.writeFile(full, r, size); err != nil {
+		return "", err
+	}
+	info, err := s.stat(key, full)
+	if err != nil {
+		return "", err
+	}
+	return info.ETag, nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadSeekCloser, ObjectInfo, error) {
+	full, err := s.path(key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	f, err := os.Open(full)
+	if err != nil {
+		return nil, ObjectInfo{}, mapFSErr(err)
+	}
+	info, err := s.stat(key, full)
+	if err != nil {
+		_ = f.Close()
+		return nil, ObjectInfo{}, err
+	}
+	return f, info, nil
+}
+
+func (s *LocalStore) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	full, err := s.path(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return s.stat(key, full)
+}
+
+func (s *LocalStore) List(ctx context.Context, prefix string, recursive bool) ([]ObjectInfo, error) {
+	dir := prefix
+	if !strings.HasSuffix(dir, "/") {
+		dir = path.Dir(dir)
+	}
+	walkRoot := filepath.Join(s.root, filepath.FromSlash(dir))
+
+	out := make([]ObjectInfo, 0)
+	err := filepath.WalkDir(walkRoot, func(full string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.root, full)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if d.IsDir() {
+			if key == localTmpDir || key == localMetaDir {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+		info, err := s.stat(key, full)
+		if err != nil {
+			return err
+		}
+		out = append(out, info)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return collapseListing(out, prefix, recursive), nil
+}
+
+func (s *LocalStore) Copy(ctx context.Context, srcKey, dstKey string) error {
+	src, err := s.path(srcKey)
+	if err != nil {
+		return err
+	}
+	dst, err := s.path(dstKey)
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	f, err := os.Open(src)
+	if err != nil {
+		return mapFSErr(err)
+	}
+	defer func() { _ = f.Close() }()
+
+	if err := s.writeFile(s.metaPath(dstKey), strings.NewReader(s.contentType(srcKey)), -1); err != nil {
+		return err
+	}
+	return s.writeFile(dst, f, -1)
+}
+
+func (s *LocalStore) Delete(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		full, err := s.path(key)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(full); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("local remove %q: %w", key, err)
+		}
+		s.pruneEmptyDirs(filepath.Dir(full))
+
+		meta := s.metaPath(key)
+		if err := os.Remove(meta); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("local remove %q metadata: %w", key, err)
+		}
+		s.pruneEmptyDirs(filepath.Dir(meta))
+	}
+	return nil
+}
+
+func (s *LocalStore) PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return "", ErrNotSupported
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	for _, hidden := range []string{localTmpDir, localMetaDir} {
+		if key == hidden || strings.HasPrefix(key, hidden+"/") {
+			return "", ErrInvalidKey
+		}
+	}
+	return filepath.Join(s.root, filepath.FromSlash(key)), nil
+}
+
+// metaPath expects a key that already passed path.
+func (s *LocalStore) metaPath(key string) string {
+	return filepath.Join(s.root, localMetaDir, filepath.FromSlash(key))
+}
+
+// contentType is the type stored with the object, or a guess from the
+// extension for objects written before types were kept.
+func (s *LocalStore) contentType(key string) string {
+	if b, err := os.ReadFile(s.metaPath(key)); err == nil {
+		if ct := strings.TrimSpace(string(b)); ct != "" {
+			return ct
+		}
+	}
+	if ct := mime.TypeByExtension(path.Ext(key)); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
+
+func (s *LocalStore) stat(key, full string) (ObjectInfo, error) {
+	fi, err := os.Stat(full)
+	if err != nil {
+		return ObjectInfo{}, mapFSErr(err)
+	}
+	if fi.IsDir() {
+		return ObjectInfo{}, ErrNotFound
+	}
+	return ObjectInfo{
+		Key:          key,
+		Size:         fi.Size(),
+		ETag:         fmt.Sprintf("%x-%x", fi.ModTime().UnixNano(), fi.Size()),
+		ContentType:  s.contentType(key),
+		LastModified: fi.ModTime().UTC(),
+	}, nil
+}
+
+// writeFile writes through a temp file and renames it into place so readers
+// never observe a partially written object.
+func (s *LocalStore) writeFile(full string, r io.Reader, size int64) error {
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		return fmt.Errorf("local mkdir: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Join(s.root, localTmpDir), "obj-*")
+	if err != nil {
+		return fmt.Errorf("local create temp: %w", err)
+	}
+	tmpName := tmp.Name()
+	defer func() { _ = os.Remove(tmpName) }()
+
+	n, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("local write: %w", err)
+	}
+	if size >= 0 && n != size {
+		return fmt.Errorf("local write: wrote %d bytes, expected %d", n, size)
+	}
+
+	if err := os.Rename(tmpName, full); err != nil {
+		return fmt.Errorf("local rename: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) pruneEmptyDirs(dir string) {
+	for dir != s.root && strings.HasPrefix(dir, s.root) {
+		if err := os.Remove(dir); err != nil {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
+
+func mapFSErr(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
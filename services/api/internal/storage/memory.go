@@ -0,0 +1,147 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps objects in process memory. It is meant for tests and
+// throwaway local runs; nothing survives a restart.
+type MemoryStore struct {
+	mu      sync.RWMutex
+	objects map[string]memoryObject
+}
+
+type memoryObject struct {
+	data []byte
+	info ObjectInfo
+}
+
+type memoryReader struct {
+	*bytes.Reader
+}
+
+func (memoryReader) Close() error { return nil }
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{objects: make(map[string]memoryObject)}
+}
+
+func (s *MemoryStore) Backend() string {
+	return "memory"
+}
+
+func (s *MemoryStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	if size >= 0 && int64(len(data)) != size {
+		return "", fmt.Errorf("memory put: read %d bytes, expected %d", len(data), size)
+	}
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
+	sum := md5.Sum(data)
+	ct := strings.TrimSpace(contentType)
+	if ct == "" {
+		ct = "application/octet-stream"
+	}
+	obj := memoryObject{
+		data: data,
+		info: ObjectInfo{
+			Key:          key,
+			Size:         int64(len(data)),
+			ETag:         hex.EncodeToString(sum[:]),
+			ContentType:  ct,
+			LastModified: time.Now().UTC(),
+		},
+	}
+
+	s.mu.Lock()
+	s.objects[key] = obj
+	s.mu.Unlock()
+	return obj.info.ETag, nil
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (io.ReadSeekCloser, ObjectInfo, error) {
+	obj, err := s.lookup(key)
+	if err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	return memoryReader{bytes.NewReader(obj.data)}, obj.info, nil
+}
+
+func (s *MemoryStore) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	obj, err := s.lookup(key)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	return obj.info, nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, prefix string, recursive bool) ([]ObjectInfo, error) {
+	s.mu.RLock()
+	out := make([]ObjectInfo, 0)
+	for key, obj := range s.objects {
+		if strings.HasPrefix(key, prefix) {
+			out = append(out, obj.info)
+		}
+	}
+	s.mu.RUnlock()
+	return collapseListing(out, prefix, recursive), nil
+}
+
+func (s *MemoryStore) Copy(ctx context.Context, srcKey, dstKey string) error {
+	if err := validateKey(dstKey); err != nil {
+		return err
+	}
+	obj, err := s.lookup(srcKey)
+	if err != nil {
+		return err
+	}
+	obj.info.Key = dstKey
+	obj.info.LastModified = time.Now().UTC()
+
+	s.mu.Lock()
+	s.objects[dstKey] = obj
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, keys []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, key := range keys {
+		delete(s.objects, key)
+	}
+	return nil
+}
+
+func (s *MemoryStore) PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	return "", ErrNotSupported
+}
+
+func (s *MemoryStore) lookup(key string) (memoryObject, error) {
+	if err := validateKey(key); err != nil {
+		return memoryObject{}, err
+	}
+	s.mu.RLock()
+	obj, ok := s.objects[key]
+	s.mu.RUnlock()
+	if !ok {
+		return memoryObject{}, ErrNotFound
+	}
+	return obj, nil
+}
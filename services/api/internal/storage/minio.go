@@ -0,0 +1,93 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/clients"
+)
+
+type MinIOStore struct {
+	cli    *clients.MinIO
+	bucket string
+}
+
+func NewMinIOStore(cli *clients.MinIO, bucket string) *MinIOStore {
+	return &MinIOStore{cli: cli, bucket: strings.TrimSpace(bucket)}
+}
+
+func (s *MinIOStore) Backend() string {
+	return "minio"
+}
+
+func (s *MinIOStore) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return s.cli.PutObject(ctx, s.bucket, key, r, size, contentType)
+}
+
+func (s *MinIOStore) Get(ctx context.Context, key string) (io.ReadSeekCloser, ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return nil, ObjectInfo{}, err
+	}
+	rc, info, err := s.cli.GetObject(ctx, s.bucket, key)
+	if err != nil {
+		return nil, ObjectInfo{}, mapClientErr(err)
+	}
+	return rc, ObjectInfo(info), nil
+}
+
+func (s *MinIOStore) Stat(ctx context.Context, key string) (ObjectInfo, error) {
+	if err := validateKey(key); err != nil {
+		return ObjectInfo{}, err
+	}
+	info, err := s.cli.StatObject(ctx, s.bucket, key)
+	if err != nil {
+		return ObjectInfo{}, mapClientErr(err)
+	}
+	return ObjectInfo(info), nil
+}
+
+func (s *MinIOStore) List(ctx context.Context, prefix string, recursive bool) ([]ObjectInfo, error) {
+	objects, err := s.cli.ListObjects(ctx, s.bucket, prefix, recursive)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ObjectInfo, 0, len(objects))
+	for _, obj := range objects {
+		out = append(out, ObjectInfo(obj))
+	}
+	return out, nil
+}
+
+func (s *MinIOStore) Copy(ctx context.Context, srcKey, dstKey string) error {
+	if err := validateKey(srcKey); err != nil {
+		return err
+	}
+	if err := validateKey(dstKey); err != nil {
+		return err
+	}
+	return mapClientErr(s.cli.CopyObject(ctx, s.bucket, srcKey, dstKey))
+}
+
+func (s *MinIOStore) Delete(ctx context.Context, keys []string) error {
+	return s.cli.RemoveObjects(ctx, s.bucket, keys)
+}
+
+func (s *MinIOStore) PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return s.cli.PresignGetObject(ctx, s.bucket, key, expiry)
+}
+
+func mapClientErr(err error) error {
+	if errors.Is(err, clients.ErrObjectNotFound) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,98 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ZygmaCore/kids_planet/services/api/internal/clients"
+	"github.com/ZygmaCore/kids_planet/services/api/internal/config"
+)
+
+var (
+	ErrNotFound     = errors.New("storage: object not found")
+	ErrInvalidKey   = errors.New("storage: invalid object key")
+	ErrNotSupported = errors.New("storage: operation not supported by backend")
+)
+
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ETag         string
+	ContentType  string
+	LastModified time.Time
+}
+
+// ObjectStore is a single bucket of game objects. Keys use "/" separators
+// regardless of backend; non-recursive List returns common prefixes as keys
+// ending in "/".
+type ObjectStore interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error)
+	Get(ctx context.Context, key string) (io.ReadSeekCloser, ObjectInfo, error)
+	Stat(ctx context.Context, key string) (ObjectInfo, error)
+	List(ctx context.Context, prefix string, recursive bool) ([]ObjectInfo, error)
+	Copy(ctx context.Context, srcKey, dstKey string) error
+	Delete(ctx context.Context, keys []string) error
+	PresignGet(ctx context.Context, key string, expiry time.Duration) (string, error)
+	Backend() string
+}
+
+func New(ctx context.Context, cfg config.Config) (ObjectStore, error) {
+	switch cfg.Storage.Backend {
+	case config.StorageBackendMinIO:
+		mo, err := clients.NewMinIO(ctx, cfg.MinIO)
+		if err != nil {
+			return nil, err
+		}
+		return NewMinIOStore(mo, cfg.MinIO.Bucket), nil
+	case config.StorageBackendLocal:
+		return NewLocalStore(cfg.Storage.LocalDir)
+	case config.StorageBackendMemory:
+		return NewMemoryStore(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage.Backend)
+	}
+}
+
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
+
+// collapseListing turns a recursive, key-sorted listing into what a
+// delimiter-based listing of prefix would return.
+func collapseListing(objects []ObjectInfo, prefix string, recursive bool) []ObjectInfo {
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	if recursive {
+		return objects
+	}
+
+	out := make([]ObjectInfo, 0, len(objects))
+	seen := make(map[string]struct{})
+	for _, obj := range objects {
+		rest := strings.TrimPrefix(obj.Key, prefix)
+		idx := strings.Index(rest, "/")
+		if idx < 0 {
+			out = append(out, obj)
+			continue
+		}
+		common := prefix + rest[:idx+1]
+		if _, ok := seen[common]; ok {
+			continue
+		}
+		seen[common] = struct{}{}
+		out = append(out, ObjectInfo{Key: common})
+	}
+	return out
+}
@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func testStores(t *testing.T) map[string]ObjectStore {
+	t.Helper()
+	local, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	return map[string]ObjectStore{
+		"memory": NewMemoryStore(),
+		"local":  local,
+	}
+}
+
+func mustPut(t *testing.T, store ObjectStore, key, body, contentType string) {
+	t.Helper()
+	if _, err := store.Put(context.Background(), key, strings.NewReader(body), int64(len(body)), contentType); err != nil {
+		t.Fatalf("Put(%q): %v", key, err)
+	}
+}
+
+func listKeys(t *testing.T, store ObjectStore, prefix string, recursive bool) []string {
+	t.Helper()
+	objects, err := store.List(context.Background(), prefix, recursive)
+	if err != nil {
+		t.Fatalf("List(%q, %v): %v", prefix, recursive, err)
+	}
+	keys := make([]string, 0, len(objects))
+	for _, obj := range objects {
+		keys = append(keys, obj.Key)
+	}
+	return keys
+}
+
+func TestListPrefixes(t *testing.T) {
+	tests := []struct {
+		name      string
+		prefix    string
+		recursive bool
+		want      []string
+	}{
+		{"root common prefixes", "", false, []string{"1/", "12/", "top.txt"}},
+		{"root recursive", "", true, []string{"1/builds/3/index.html", "1/builds/3/js/app.js", "1/upload/a.zip", "12/upload/b.zip", "top.txt"}},
+		{"game prefix", "1/", false, []string{"1/builds/", "1/upload/"}},
+		{"game prefix recursive", "1/", true, []string{"1/builds/3/index.html", "1/builds/3/js/app.js", "1/upload/a.zip"}},
+		{"build prefix", "1/builds/3/", false, []string{"1/builds/3/index.html", "1/builds/3/js/"}},
+		{"partial name", "1/up", true, []string{"1/upload/a.zip"}},
+		{"sibling with same digits", "1", false, []string{"1/", "12/"}},
+		{"missing prefix", "9/", true, []string{}},
+	}
+
+	for backend, store := range testStores(t) {
+		mustPut(t, store, "1/builds/3/index.html", "<html></html>", "text/html")
+		mustPut(t, store, "1/builds/3/js/app.js", "run()", "text/javascript")
+		mustPut(t, store, "1/upload/a.zip", "PK", "application/zip")
+		mustPut(t, store, "12/upload/b.zip", "PK", "application/zip")
+		mustPut(t, store, "top.txt", "hi", "text/plain")
+
+		for _, tt := range tests {
+			t.Run(backend+"/"+tt.name, func(t *testing.T) {
+				got := listKeys(t, store, tt.prefix, tt.recursive)
+				if !slices.Equal(got, tt.want) {
+					t.Fatalf("List(%q, %v) = %v, want %v", tt.prefix, tt.recursive, got, tt.want)
+				}
+			})
+		}
+	}
+}
+
+func TestKeyValidation(t *testing.T) {
+	invalid := []string{
+		"",
+		"/1/a.txt",
+		"1//a.txt",
+		"1/./a.txt",
+		"1/../a.txt",
+		"..",
+		"1/a.txt/",
+		`1\a.txt`,
+	}
+
+	for backend, store := range testStores(t) {
+		for _, key := range invalid {
+			t.Run(backend+"/"+key, func(t *testing.T) {
+				ctx := context.Background()
+				if _, err := store.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"); !errors.Is(err, ErrInvalidKey) {
+					t.Fatalf("Put(%q) err = %v, want ErrInvalidKey", key, err)
+				}
+				if _, _, err := store.Get(ctx, key); !errors.Is(err, ErrInvalidKey) {
+					t.Fatalf("Get(%q) err = %v, want ErrInvalidKey", key, err)
+				}
+				if err := store.Copy(ctx, "1/a.txt", key); !errors.Is(err, ErrInvalidKey) {
+					t.Fatalf("Copy to %q err = %v, want ErrInvalidKey", key, err)
+				}
+			})
+		}
+	}
+}
+
+func TestLocalHiddenDirs(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	for _, key := range []string{".tmp", ".tmp/obj", ".meta", ".meta/1/a.txt"} {
+		if _, err := store.Put(context.Background(), key, strings.NewReader("x"), 1, ""); !errors.Is(err, ErrInvalidKey) {
+			t.Fatalf("Put(%q) err = %v, want ErrInvalidKey", key, err)
+		}
+	}
+
+	mustPut(t, store, "1/a.txt", "a", "text/plain")
+	if got := listKeys(t, store, "", true); !slices.Equal(got, []string{"1/a.txt"}) {
+		t.Fatalf("List = %v, want only 1/a.txt", got)
+	}
+}
+
+func TestGetAndContentType(t *testing.T) {
+	for backend, store := range testStores(t) {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			// The stored type wins over the extension.
+			mustPut(t, store, "1/builds/3/data.bin", "payload", "application/wasm")
+			mustPut(t, store, "1/builds/3/blank.bin", "x", "")
+
+			body, info, err := store.Get(ctx, "1/builds/3/data.bin")
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			b, err := io.ReadAll(body)
+			_ = body.Close()
+			if err != nil || string(b) != "payload" {
+				t.Fatalf("body = %q, %v", b, err)
+			}
+			if info.ContentType != "application/wasm" || info.Size != 7 || info.ETag == "" {
+				t.Fatalf("info = %+v", info)
+			}
+
+			blank, err := store.Stat(ctx, "1/builds/3/blank.bin")
+			if err != nil {
+				t.Fatalf("Stat: %v", err)
+			}
+			if blank.ContentType != "application/octet-stream" {
+				t.Fatalf("empty type stored as %q", blank.ContentType)
+			}
+
+			if _, _, err := store.Get(ctx, "1/builds/3/missing.js"); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("Get missing err = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestCopy(t *testing.T) {
+	for backend, store := range testStores(t) {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			mustPut(t, store, "1/builds/3/app.js", "run()", "text/javascript")
+
+			if err := store.Copy(ctx, "1/builds/3/app.js", "1/builds/4/js/app.js"); err != nil {
+				t.Fatalf("Copy: %v", err)
+			}
+			body, info, err := store.Get(ctx, "1/builds/4/js/app.js")
+			if err != nil {
+				t.Fatalf("Get copy: %v", err)
+			}
+			b, _ := io.ReadAll(body)
+			_ = body.Close()
+			if string(b) != "run()" || info.ContentType != "text/javascript" || info.Key != "1/builds/4/js/app.js" {
+				t.Fatalf("copy = %q %+v", b, info)
+			}
+
+			// The source is untouched.
+			if _, err := store.Stat(ctx, "1/builds/3/app.js"); err != nil {
+				t.Fatalf("Stat source: %v", err)
+			}
+
+			if err := store.Copy(ctx, "1/builds/3/missing.js", "1/builds/4/missing.js"); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("Copy missing err = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	for backend, store := range testStores(t) {
+		t.Run(backend, func(t *testing.T) {
+			ctx := context.Background()
+			mustPut(t, store, "1/builds/3/index.html", "<html></html>", "text/html")
+			mustPut(t, store, "1/builds/3/js/app.js", "run()", "text/javascript")
+			mustPut(t, store, "1/upload/a.zip", "PK", "application/zip")
+
+			// Missing keys are not an error, like S3.
+			if err := store.Delete(ctx, []string{"1/builds/3/index.html", "1/builds/3/js/app.js", "1/builds/3/gone.css"}); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+			if got := listKeys(t, store, "", true); !slices.Equal(got, []string{"1/upload/a.zip"}) {
+				t.Fatalf("after delete List = %v", got)
+			}
+			if got := listKeys(t, store, "1/", false); !slices.Equal(got, []string{"1/upload/"}) {
+				t.Fatalf("empty prefixes still listed: %v", got)
+			}
+		})
+	}
+}
+
+func TestLocalDeleteRemovesMetadata(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalStore(root)
+	if err != nil {
+		t.Fatalf("NewLocalStore: %v", err)
+	}
+	mustPut(t, store, "1/builds/3/app.js", "run()", "text/javascript")
+	if err := store.Delete(context.Background(), []string{"1/builds/3/app.js"}); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, localMetaDir, "1")); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("metadata dir left behind: %v", err)
+	}
+}
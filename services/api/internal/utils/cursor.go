@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// maxCursorLen keeps a tampered token from making us decode arbitrary input.
+const maxCursorLen = 512
+
+// EncodeCursor packs v into an opaque URL-safe token. Cursors are not signed:
+// a forged one only changes which page the caller gets.
+func EncodeCursor(v any) (string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+func DecodeCursor(token string, v any) error {
+	if token == "" || len(token) > maxCursorLen {
+		return ErrInvalidCursor
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return ErrInvalidCursor
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
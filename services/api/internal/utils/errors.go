@@ -20,6 +20,7 @@ const (
 	CodeNotFound                = CodeResourceNotFound
 	CodeInternal                = "INTERNAL_ERROR"
 	CodeRateLimited             = "RATE_LIMITED"
+	CodeConflict                = "CONFLICT"
 	CodeInvalidZip              = "INVALID_ZIP"
 	CodeZipTooLarge             = "ZIP_TOO_LARGE"
 	CodeInvalidZipPath          = "INVALID_ZIP_PATH"
@@ -27,6 +28,15 @@ const (
 	CodeZipTooManyFiles         = "ZIP_TOO_MANY_FILES"
 	CodeInvalidFileType         = "INVALID_FILE_TYPE"
 	CodeMissingIndexHTML        = "MISSING_INDEX_HTML"
+	CodeInvalidImage            = "INVALID_IMAGE"
+	CodeImageTooLarge           = "IMAGE_TOO_LARGE"
+	CodeSignInRequired          = "SIGN_IN_REQUIRED"
+
+	// Parental control blocks, one code per reason so the UI can explain it.
+	CodeProfileGameBlocked        = "PROFILE_GAME_BLOCKED"
+	CodeProfileCategoryNotAllowed = "PROFILE_CATEGORY_NOT_ALLOWED"
+	CodeProfileDailyLimitReached  = "PROFILE_DAILY_LIMIT_REACHED"
+	CodeProfileQuietHours         = "PROFILE_QUIET_HOURS"
 )
 
 type APIError struct {
@@ -110,6 +120,14 @@ func ErrRateLimited(msg string) AppError {
 	}
 }
 
+func ErrConflict(msg string) AppError {
+	return AppError{
+		Code:       CodeConflict,
+		Message:    normalizeMessage(msg, "conflict"),
+		HTTPStatus: http.StatusConflict,
+	}
+}
+
 func ErrInvalidZip(msg string) AppError {
 	return AppError{
 		Code:       CodeInvalidZip,
@@ -182,6 +200,72 @@ func ErrMissingIndexHTML() AppError {
 	}
 }
 
+func ErrInvalidImage(msg string) AppError {
+	return AppError{
+		Code:       CodeInvalidImage,
+		Message:    normalizeMessage(msg, "invalid image"),
+		HTTPStatus: http.StatusUnprocessableEntity,
+	}
+}
+
+func ErrImageTooLarge(maxBytes int64) AppError {
+	msg := "image too large"
+	if maxBytes > 0 {
+		msg = fmt.Sprintf("image too large (max %d bytes)", maxBytes)
+	}
+	return AppError{
+		Code:       CodeImageTooLarge,
+		Message:    msg,
+		HTTPStatus: http.StatusRequestEntityTooLarge,
+	}
+}
+
+// ErrSignInRequired rejects a guest play token where only a signed-in player
+// may act.
+func ErrSignInRequired() AppError {
+	return AppError{
+		Code:       CodeSignInRequired,
+		Message:    "sign in to do this",
+		HTTPStatus: http.StatusForbidden,
+	}
+}
+
+func ErrProfileGameBlocked() AppError {
+	return AppError{
+		Code:       CodeProfileGameBlocked,
+		Message:    "this game is blocked by a parent",
+		HTTPStatus: http.StatusForbidden,
+	}
+}
+
+func ErrProfileCategoryNotAllowed() AppError {
+	return AppError{
+		Code:       CodeProfileCategoryNotAllowed,
+		Message:    "this kind of game is not allowed by a parent",
+		HTTPStatus: http.StatusForbidden,
+	}
+}
+
+func ErrProfileDailyLimitReached() AppError {
+	return AppError{
+		Code:       CodeProfileDailyLimitReached,
+		Message:    "today's play time is used up",
+		HTTPStatus: http.StatusForbidden,
+	}
+}
+
+func ErrProfileQuietHours(until string) AppError {
+	msg := "it is quiet time"
+	if until != "" {
+		msg = "it is quiet time until " + until
+	}
+	return AppError{
+		Code:       CodeProfileQuietHours,
+		Message:    msg,
+		HTTPStatus: http.StatusForbidden,
+	}
+}
+
 func RequestIDFromContext(c *fiber.Ctx) string {
 	if c == nil {
 		return ""
@@ -211,7 +295,11 @@ func statusFromCode(code string) int {
 		return http.StatusBadRequest
 	case CodeUnauthorized:
 		return http.StatusUnauthorized
-	case CodeForbidden:
+	case CodeForbidden,
+		CodeProfileGameBlocked,
+		CodeProfileCategoryNotAllowed,
+		CodeProfileDailyLimitReached,
+		CodeProfileQuietHours:
 		return http.StatusForbidden
 	case CodeResourceNotFound:
 		return http.StatusNotFound
@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/draw"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"math"
+)
+
+type ImageInputError struct {
+	Reason string
+}
+
+func (e ImageInputError) Error() string {
+	if e.Reason == "" {
+		return "invalid image"
+	}
+	return e.Reason
+}
+
+var allowedImageFormats = map[string]struct{}{
+	"png":  {},
+	"jpeg": {},
+	"gif":  {},
+}
+
+// SafeDecodeImage checks the header dimensions before decoding so a small
+// file cannot expand into a huge bitmap.
+func SafeDecodeImage(r io.ReadSeeker, maxPixels int) (image.Image, error) {
+	if r == nil {
+		return nil, errors.New("image reader is nil")
+	}
+
+	cfg, format, err := image.DecodeConfig(r)
+	if err != nil {
+		return nil, ImageInputError{Reason: "file is not a supported image (png, jpeg, gif)"}
+	}
+	if _, ok := allowedImageFormats[format]; !ok {
+		return nil, ImageInputError{Reason: "file is not a supported image (png, jpeg, gif)"}
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, ImageInputError{Reason: "image has invalid dimensions"}
+	}
+	if maxPixels > 0 && int64(cfg.Width)*int64(cfg.Height) > int64(maxPixels) {
+		return nil, ImageInputError{Reason: "image dimensions too large"}
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(r)
+	if err != nil {
+		return nil, ImageInputError{Reason: "image data is corrupt"}
+	}
+	return img, nil
+}
+
+// ResizeCover scales src to fill width x height, cropping the overflow
+// around the center. Downscaling averages every covered source pixel.
+func ResizeCover(src image.Image, width, height int) *image.RGBA {
+	sb := src.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, sb.Dx(), sb.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), src, sb.Min, draw.Src)
+
+	sw, sh := float64(sb.Dx()), float64(sb.Dy())
+	scale := math.Max(float64(width)/sw, float64(height)/sh)
+	cropW, cropH := float64(width)/scale, float64(height)/scale
+	cropX, cropY := (sw-cropW)/2, (sh-cropH)/2
+	stepX, stepY := cropW/float64(width), cropH/float64(height)
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for dy := 0; dy < height; dy++ {
+		y0 := int(cropY + float64(dy)*stepY)
+		y1 := int(math.Ceil(cropY + float64(dy+1)*stepY))
+		y0, y1 = clampSpan(y0, y1, sb.Dy())
+
+		for dx := 0; dx < width; dx++ {
+			x0 := int(cropX + float64(dx)*stepX)
+			x1 := int(math.Ceil(cropX + float64(dx+1)*stepX))
+			x0, x1 = clampSpan(x0, x1, sb.Dx())
+
+			var r, g, b, a, n uint64
+			for y := y0; y < y1; y++ {
+				off := y*rgba.Stride + x0*4
+				for x := x0; x < x1; x++ {
+					r += uint64(rgba.Pix[off])
+					g += uint64(rgba.Pix[off+1])
+					b += uint64(rgba.Pix[off+2])
+					a += uint64(rgba.Pix[off+3])
+					off += 4
+					n++
+				}
+			}
+
+			o := dy*dst.Stride + dx*4
+			dst.Pix[o] = uint8(r / n)
+			dst.Pix[o+1] = uint8(g / n)
+			dst.Pix[o+2] = uint8(b / n)
+			dst.Pix[o+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// EncodePNG re-encodes the pixels only, which drops EXIF and other metadata.
+func EncodePNG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := enc.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func clampSpan(lo, hi, limit int) (int, int) {
+	if lo < 0 {
+		lo = 0
+	}
+	if lo > limit-1 {
+		lo = limit - 1
+	}
+	if hi <= lo {
+		hi = lo + 1
+	}
+	if hi > limit {
+		hi = limit
+	}
+	return lo, hi
+}
@@ -17,11 +17,27 @@ type AdminClaims struct {
 	jwt.RegisteredClaims
 }
 
+// PlayerClaims carry the active child profile once one is selected; tokens
+// without it act for the parent account.
 type PlayerClaims struct {
-	Role string `json:"role"`
+	Role      string `json:"role"`
+	ProfileID int64  `json:"profile_id,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// PreviewClaims let an admin start sessions for a game that is not active.
+type PreviewClaims struct {
+	GameID int64  `json:"game_id"`
+	Typ    string `json:"typ"`
 	jwt.RegisteredClaims
 }
 
+type ParsedPreviewToken struct {
+	GameID  int64
+	AdminID int64
+	Exp     time.Time
+}
+
 type ParsedToken struct {
 	UserID int64
 	Role   string
@@ -31,11 +47,12 @@ type ParsedToken struct {
 }
 
 type ParsedPlayerToken struct {
-	PlayerID string
-	Role     string
-	Issuer   string
-	Iat      time.Time
-	Exp      time.Time
+	PlayerID  string
+	ProfileID int64
+	Role      string
+	Issuer    string
+	Iat       time.Time
+	Exp       time.Time
 }
 
 func GenerateAdminToken(jwtCfg config.JWTConfig, user *repos.User) (tokenString string, expiresInSec int64, err error) {
@@ -70,6 +87,19 @@ func GenerateAdminToken(jwtCfg config.JWTConfig, user *repos.User) (tokenString
 }
 
 func GeneratePlayerToken(jwtCfg config.JWTConfig, user *repos.User) (tokenString string, expiresInSec int64, err error) {
+	return generatePlayerToken(jwtCfg, user, 0)
+}
+
+// GenerateProfileToken issues a player token acting as one of the account's
+// child profiles. Ownership of the profile is the caller's to check.
+func GenerateProfileToken(jwtCfg config.JWTConfig, user *repos.User, profileID int64) (tokenString string, expiresInSec int64, err error) {
+	if profileID <= 0 {
+		return "", 0, errors.New("invalid profile id")
+	}
+	return generatePlayerToken(jwtCfg, user, profileID)
+}
+
+func generatePlayerToken(jwtCfg config.JWTConfig, user *repos.User, profileID int64) (tokenString string, expiresInSec int64, err error) {
 	if user == nil {
 		return "", 0, errors.New("invalid user")
 	}
@@ -87,7 +117,8 @@ func GeneratePlayerToken(jwtCfg config.JWTConfig, user *repos.User) (tokenString
 	exp := now.Add(jwtCfg.ExpiresIn)
 
 	claims := PlayerClaims{
-		Role: "player",
+		Role:      "player",
+		ProfileID: profileID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   user.PublicID.String,
 			Issuer:    jwtCfg.Issuer,
@@ -106,6 +137,70 @@ func GeneratePlayerToken(jwtCfg config.JWTConfig, user *repos.User) (tokenString
 	return signed, int64(jwtCfg.ExpiresIn.Seconds()), nil
 }
 
+func GeneratePreviewToken(jwtCfg config.JWTConfig, gameID, adminID int64, ttl time.Duration) (tokenString string, expiresAt time.Time, err error) {
+	if gameID <= 0 || adminID <= 0 {
+		return "", time.Time{}, errors.New("invalid preview subject")
+	}
+	if ttl <= 0 {
+		return "", time.Time{}, errors.New("invalid preview ttl")
+	}
+
+	now := time.Now()
+	exp := now.Add(ttl)
+
+	claims := PreviewClaims{
+		GameID: gameID,
+		Typ:    "preview",
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatInt(adminID, 10),
+			Issuer:    jwtCfg.Issuer,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(exp),
+		},
+	}
+
+	tok := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+
+	signed, err := tok.SignedString([]byte(jwtCfg.Secret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return signed, exp, nil
+}
+
+func ParsePreviewToken(jwtCfg config.JWTConfig, tokenString string) (ParsedPreviewToken, error) {
+	claims := &PreviewClaims{}
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(jwtCfg.Issuer),
+		jwt.WithExpirationRequired(),
+	)
+
+	_, err := parser.ParseWithClaims(tokenString, claims, func(t *jwt.Token) (any, error) {
+		return []byte(jwtCfg.Secret), nil
+	})
+	if err != nil {
+		return ParsedPreviewToken{}, ErrUnauthorized()
+	}
+
+	if claims.Typ != "preview" || claims.GameID <= 0 {
+		return ParsedPreviewToken{}, ErrUnauthorized()
+	}
+
+	adminID, convErr := strconv.ParseInt(claims.Subject, 10, 64)
+	if convErr != nil || adminID <= 0 {
+		return ParsedPreviewToken{}, ErrUnauthorized()
+	}
+
+	return ParsedPreviewToken{
+		GameID:  claims.GameID,
+		AdminID: adminID,
+		Exp:     claims.ExpiresAt.Time,
+	}, nil
+}
+
 func ParseToken(jwtCfg config.JWTConfig, tokenString string) (ParsedToken, error) {
 	claims := &AdminClaims{}
 
@@ -190,6 +285,9 @@ func ParsePlayerToken(jwtCfg config.JWTConfig, tokenString string) (ParsedPlayer
 	if _, parseErr := uuid.Parse(playerID); parseErr != nil {
 		return ParsedPlayerToken{}, ErrUnauthorized()
 	}
+	if claims.ProfileID < 0 {
+		return ParsedPlayerToken{}, ErrUnauthorized()
+	}
 
 	var iat, exp time.Time
 	if claims.IssuedAt != nil {
@@ -200,10 +298,11 @@ func ParsePlayerToken(jwtCfg config.JWTConfig, tokenString string) (ParsedPlayer
 	}
 
 	return ParsedPlayerToken{
-		PlayerID: playerID,
-		Role:     claims.Role,
-		Issuer:   claims.Issuer,
-		Iat:      iat,
-		Exp:      exp,
+		PlayerID:  playerID,
+		ProfileID: claims.ProfileID,
+		Role:      claims.Role,
+		Issuer:    claims.Issuer,
+		Iat:       iat,
+		Exp:       exp,
 	}, nil
 }
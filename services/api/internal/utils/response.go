@@ -1,6 +1,15 @@
 package utils
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
 
 func Success(c *fiber.Ctx, data any) error {
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
@@ -8,6 +17,49 @@ func Success(c *fiber.Ctx, data any) error {
 	})
 }
 
+// SuccessCacheable writes the same envelope as Success with an ETag and
+// Cache-Control, and answers a matching If-None-Match with 304.
+func SuccessCacheable(c *fiber.Ctx, data any, maxAge time.Duration) error {
+	body, err := json.Marshal(fiber.Map{"data": data})
+	if err != nil {
+		return Fail(c, ErrInternal())
+	}
+
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	c.Set(fiber.HeaderETag, etag)
+	if maxAge > 0 {
+		c.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	} else {
+		c.Set(fiber.HeaderCacheControl, "no-cache")
+	}
+
+	if ETagMatches(c.Get(fiber.HeaderIfNoneMatch), etag) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(fiber.StatusOK).Send(body)
+}
+
+// ETagMatches reports whether an If-None-Match header matches etag.
+func ETagMatches(header string, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	for _, part := range strings.Split(header, ",") {
+		candidate := strings.TrimPrefix(strings.TrimSpace(part), "W/")
+		if candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
 func Fail(c *fiber.Ctx, appErr AppError) error {
 	return WriteError(c, appErr)
 }
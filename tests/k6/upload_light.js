@@ -51,7 +51,7 @@ export default function (data) {
     },
     "upload returns playable game_url": (r) => {
       const gameUrl = r.json("data.game_url");
-      return typeof gameUrl === "string" && gameUrl.includes(`/games/${GAME_ID}/current/index.html`);
+      return typeof gameUrl === "string" && gameUrl.startsWith(`/games/${GAME_ID}/builds/`);
     },
   });
 